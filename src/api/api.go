@@ -0,0 +1,233 @@
+package api
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"etl-service/src/config/logger"
+	"etl-service/src/config/metricas"
+	"etl-service/src/exec/execucao"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	tamanhoPaginaPadrao = 50  // Ocorrências por página quando o parâmetro tamanho não é informado
+	tamanhoPaginaMax    = 500 // Maior página aceita em GET /runs/{id}/errors
+	limiteCorpo         = 1 << 16
+)
+
+// requisicaoExecucao é o corpo aceito por POST /runs.
+type requisicaoExecucao struct {
+	Job         string `json:"job"`
+	DryRun      *bool  `json:"dryRun,omitempty"`
+	Incremental *bool  `json:"incremental,omitempty"`
+}
+
+// respostaErro é o corpo das respostas de erro da API.
+type respostaErro struct {
+	Erro string `json:"erro"`
+}
+
+// servidor agrupa as dependências dos handlers da API de controle.
+type servidor struct {
+	gerenciador execucao.Gerenciador
+	token       string
+}
+
+// NewHandler cria o handler HTTP da API de controle das execuções.
+//
+// Rotas:
+//   - GET    /healthz               verificação de vida
+//   - GET    /metrics               métricas Prometheus
+//   - GET    /jobs                  jobs disponíveis
+//   - POST   /runs                  inicia uma execução ({"job": "...", "dryRun": bool, "incremental": bool})
+//   - GET    /runs/{id}             progresso e resultado da execução
+//   - GET    /runs/{id}/errors      duplicados, falhas, avisos e inconsistências paginados (?tipo=&pagina=&tamanho=)
+//   - DELETE /runs/{id}             cancela a execução
+//
+// Quando token não é vazio, as rotas /jobs e /runs exigem o cabeçalho "Authorization: Bearer <token>".
+func NewHandler(g execucao.Gerenciador, token string) http.Handler {
+	s := &servidor{gerenciador: g, token: token}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		responder(w, http.StatusOK, map[string]string{"status": "ok"})
+	})
+	mux.Handle("GET /metrics", metricas.Handler())
+	mux.Handle("GET /jobs", s.autenticar(s.listarJobs))
+	mux.Handle("POST /runs", s.autenticar(s.iniciar))
+	mux.Handle("GET /runs/{id}", s.autenticar(s.estado))
+	mux.Handle("GET /runs/{id}/errors", s.autenticar(s.ocorrencias))
+	mux.Handle("DELETE /runs/{id}", s.autenticar(s.cancelar))
+
+	return registrarAcesso(mux)
+}
+
+// autenticar exige o token configurado no cabeçalho Authorization.
+func (s *servidor) autenticar(next http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.token != "" {
+			recebido, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if subtle.ConstantTimeCompare([]byte(recebido), []byte(s.token)) != 1 {
+				responderErro(w, http.StatusUnauthorized, errors.New("token ausente ou inválido"))
+				return
+			}
+		}
+		next(w, r)
+	})
+}
+
+// listarJobs responde GET /jobs.
+func (s *servidor) listarJobs(w http.ResponseWriter, r *http.Request) {
+	responder(w, http.StatusOK, s.gerenciador.Jobs())
+}
+
+// iniciar responde POST /runs, retornando 202 com o registro da execução criada.
+func (s *servidor) iniciar(w http.ResponseWriter, r *http.Request) {
+	var req requisicaoExecucao
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, limiteCorpo))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		responderErro(w, http.StatusBadRequest, errors.New("corpo inválido: "+err.Error()))
+		return
+	}
+	if strings.TrimSpace(req.Job) == "" {
+		responderErro(w, http.StatusBadRequest, errors.New("campo 'job' é obrigatório"))
+		return
+	}
+
+	registro, err := s.gerenciador.Iniciar(r.Context(), req.Job, execucao.Opcoes{
+		DryRun:      req.DryRun,
+		Incremental: req.Incremental,
+		Origem:      execucao.OrigemAPI,
+	})
+	if err != nil {
+		responderErro(w, statusDoErro(err), err)
+		return
+	}
+
+	w.Header().Set("Location", "/runs/"+registro.ID)
+	responder(w, http.StatusAccepted, registro)
+}
+
+// estado responde GET /runs/{id}.
+func (s *servidor) estado(w http.ResponseWriter, r *http.Request) {
+	estado, err := s.gerenciador.Estado(r.Context(), r.PathValue("id"))
+	if err != nil {
+		responderErro(w, statusDoErro(err), err)
+		return
+	}
+	responder(w, http.StatusOK, estado)
+}
+
+// ocorrencias responde GET /runs/{id}/errors.
+func (s *servidor) ocorrencias(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	pagina, err := inteiroPositivo(q.Get("pagina"), 1)
+	if err != nil {
+		responderErro(w, http.StatusBadRequest, errors.New("parâmetro 'pagina' inválido"))
+		return
+	}
+	tamanho, err := inteiroPositivo(q.Get("tamanho"), tamanhoPaginaPadrao)
+	if err != nil || tamanho > tamanhoPaginaMax {
+		responderErro(w, http.StatusBadRequest, errors.New("parâmetro 'tamanho' inválido (1 a "+strconv.Itoa(tamanhoPaginaMax)+")"))
+		return
+	}
+
+	resultado, err := s.gerenciador.Ocorrencias(r.Context(), r.PathValue("id"), q.Get("tipo"), pagina, tamanho)
+	if err != nil {
+		responderErro(w, statusDoErro(err), err)
+		return
+	}
+	responder(w, http.StatusOK, resultado)
+}
+
+// cancelar responde DELETE /runs/{id} com 202: o cancelamento é assíncrono
+// e o status final pode ser consultado em GET /runs/{id}.
+func (s *servidor) cancelar(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if err := s.gerenciador.Cancelar(r.Context(), id); err != nil {
+		responderErro(w, statusDoErro(err), err)
+		return
+	}
+	responder(w, http.StatusAccepted, map[string]string{"id": id, "status": "cancelamento solicitado"})
+}
+
+// statusDoErro mapeia os erros do gerenciador para status HTTP.
+func statusDoErro(err error) int {
+	switch {
+	case errors.Is(err, execucao.ErrJobDesconhecido):
+		return http.StatusBadRequest
+	case errors.Is(err, execucao.ErrExecucaoNaoEncontrada):
+		return http.StatusNotFound
+	case errors.Is(err, execucao.ErrJobEmExecucao),
+		errors.Is(err, execucao.ErrExecucaoFinalizada),
+		errors.Is(err, execucao.ErrExecucaoEmAndamento),
+		errors.Is(err, execucao.ErrExecucaoOutraInstancia):
+		return http.StatusConflict
+	case errors.Is(err, execucao.ErrGerenciadorEncerrando):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// inteiroPositivo converte o parâmetro de query, retornando padrao quando vazio.
+func inteiroPositivo(valor string, padrao int) (int, error) {
+	if valor == "" {
+		return padrao, nil
+	}
+	n, err := strconv.Atoi(valor)
+	if err != nil || n < 1 {
+		return 0, errors.New("valor inválido")
+	}
+	return n, nil
+}
+
+// responder serializa v como JSON com o status informado.
+func responder(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		slog.Warn("erro ao escrever resposta da API", logger.Erro(err))
+	}
+}
+
+// responderErro responde com {"erro": "..."}. Erros internos também são registrados no log.
+func responderErro(w http.ResponseWriter, status int, err error) {
+	if status >= http.StatusInternalServerError {
+		slog.Error("erro na API de controle", logger.Erro(err))
+	}
+	responder(w, status, respostaErro{Erro: err.Error()})
+}
+
+// respostaRegistrada captura o status escrito pelo handler para o log de acesso.
+type respostaRegistrada struct {
+	http.ResponseWriter
+	status int
+}
+
+// WriteHeader guarda o status antes de repassá-lo.
+func (r *respostaRegistrada) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// registrarAcesso registra cada requisição (método, caminho, status e duração) no nível debug.
+func registrarAcesso(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		inicio := time.Now()
+		rw := &respostaRegistrada{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rw, r)
+		slog.Debug("requisição atendida",
+			"metodo", r.Method,
+			"caminho", r.URL.Path,
+			"status", rw.status,
+			"duracao", time.Since(inicio),
+		)
+	})
+}
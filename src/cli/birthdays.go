@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"etl-service/src/config/logger"
+	"etl-service/src/exec/aniversarios"
+	"etl-service/src/exec/domain"
+	finalrepository "etl-service/src/exec/repository/final_repository"
+)
+
+// formatoDataCLI é o formato das datas aceitas e impressas pelo subcomando birthdays.
+const formatoDataCLI = "02/01/2006"
+
+// birthdaysCmd lista os aniversários de nascimento e de casamento dos membros do banco final em uma janela
+// de datas, ou grava com -ics o calendário com todos os aniversários, para assinatura.
+func birthdaysCmd(args []string) int {
+	fs := flag.NewFlagSet("birthdays", flag.ContinueOnError)
+	de := fs.String("de", "", "início da janela no formato DD/MM/AAAA (padrão: hoje)")
+	ate := fs.String("ate", "", "fim da janela no formato DD/MM/AAAA (padrão: início + -dias)")
+	dias := fs.Int("dias", 30, "tamanho da janela em dias, quando -ate não é informado")
+	tipos := fs.String("tipo", "", "tipos de evento separados por vírgula: aniversario, casamento (padrão: todos)")
+	status := fs.String("status", "", "status dos membros incluídos, separados por vírgula (padrão: todos)")
+	ics := fs.String("ics", "", "grava o calendário iCalendar com todos os aniversários no arquivo, em vez de listar")
+	asJSON := fs.Bool("json", false, "imprime o resultado em JSON")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	filtro := aniversarios.Filtro{Status: separarLista(*status)}
+	for _, t := range separarLista(*tipos) {
+		switch tipo := aniversarios.Tipo(t); tipo {
+		case aniversarios.TipoAniversario, aniversarios.TipoCasamento:
+			filtro.Tipos = append(filtro.Tipos, tipo)
+		default:
+			fmt.Fprintf(os.Stderr, "tipo de evento desconhecido: %q (use aniversario ou casamento)\n", t)
+			return ExitUso
+		}
+	}
+
+	inicio := time.Now().In(domain.FusoHorario)
+	if *de != "" {
+		var err error
+		if inicio, err = time.ParseInLocation(formatoDataCLI, *de, domain.FusoHorario); err != nil {
+			fmt.Fprintf(os.Stderr, "data inválida em -de: %q (use DD/MM/AAAA)\n", *de)
+			return ExitUso
+		}
+	}
+	fim := inicio.AddDate(0, 0, *dias)
+	if *ate != "" {
+		var err error
+		if fim, err = time.ParseInLocation(formatoDataCLI, *ate, domain.FusoHorario); err != nil {
+			fmt.Fprintf(os.Stderr, "data inválida em -ate: %q (use DD/MM/AAAA)\n", *ate)
+			return ExitUso
+		}
+	}
+	if fim.Before(inicio) {
+		fmt.Fprintln(os.Stderr, "o fim da janela deve ser posterior ao início")
+		return ExitUso
+	}
+
+	conn, fechar, err := conectar()
+	if err != nil {
+		slog.Error("falha ao consultar aniversários", logger.Erro(err))
+		return ExitErroFatal
+	}
+	defer fechar()
+
+	ctx, cancel := contextoInterrompivel()
+	defer cancel()
+	agenda := aniversarios.NewAgenda(finalrepository.NewDataFinalRepository(conn))
+
+	if *ics != "" {
+		file, err := os.Create(*ics)
+		if err != nil {
+			slog.Error("erro ao criar arquivo do calendário", "arquivo", *ics, logger.Erro(err))
+			return ExitErroFatal
+		}
+		defer file.Close()
+		total, err := agenda.Calendario(ctx, file, filtro)
+		if err != nil {
+			slog.Error("falha ao gerar o calendário", logger.Erro(err))
+			return ExitErroFatal
+		}
+		fmt.Printf("Calendário com %d eventos gravado em '%s'\n", total, *ics)
+		return ExitSucesso
+	}
+
+	eventos, err := agenda.Proximos(ctx, inicio, fim, filtro)
+	if err != nil {
+		slog.Error("falha ao consultar aniversários", logger.Erro(err))
+		return ExitErroFatal
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(eventos); err != nil {
+			slog.Error("falha ao consultar aniversários", logger.Erro(err))
+			return ExitErroFatal
+		}
+		return ExitSucesso
+	}
+
+	fmt.Printf("Aniversários de %s a %s: %d\n", inicio.Format(formatoDataCLI), fim.Format(formatoDataCLI), len(eventos))
+	for _, e := range eventos {
+		descricao := fmt.Sprintf("%s (%d anos)", e.Nome, e.Anos)
+		if e.Tipo == aniversarios.TipoCasamento {
+			descricao = fmt.Sprintf("%s e %s (%d anos de casamento)", e.Nome, e.Conjuge, e.Anos)
+			if e.Conjuge == "" {
+				descricao = fmt.Sprintf("%s (%d anos de casamento)", e.Nome, e.Anos)
+			}
+		}
+		fmt.Printf("  %s  %-11s %s\n", e.Data.Format("02/01"), e.Tipo, descricao)
+	}
+	return ExitSucesso
+}
+
+// separarLista divide um valor separado por vírgulas, descartando itens vazios.
+func separarLista(valor string) []string {
+	var lista []string
+	for _, item := range strings.Split(valor, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			lista = append(lista, item)
+		}
+	}
+	return lista
+}
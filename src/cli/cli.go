@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// Códigos de saída da aplicação, utilizados pelo agendador para decidir
+// se a execução deve ser considerada sucesso, repetida ou investigada.
+const (
+	ExitSucesso      = 0 // Execução concluída sem falhas
+	ExitErroFatal    = 1 // Falha fatal (conexão, configuração, consulta) — nada ou quase nada foi processado
+	ExitUso          = 2 // Subcomando ou flags inválidos
+	ExitFalhaParcial = 3 // Execução concluída, porém com membros que falharam
+	ExitOcupado      = 4 // Execução não iniciada: outra instância detém a trava da carga
+)
+
+// comando representa um subcomando da CLI.
+type comando struct {
+	descricao string                  // Texto exibido na ajuda
+	executar  func(args []string) int // Executa o subcomando e retorna o código de saída
+}
+
+// comandos registra os subcomandos disponíveis, indexados pelo nome.
+var comandos = map[string]comando{
+	"run":       {"executa o ETL completo (extração, transformação e carga)", runCmd},
+	"validate":  {"valida os dados do banco inicial sem escrever no banco final", validateCmd},
+	"profile":   {"levanta o perfil dos campos do banco inicial (JSON e HTML)", profileCmd},
+	"daemon":    {"executa os jobs agendados por expressões cron", daemonCmd},
+	"export":    {"exporta a coleção do banco final", exportCmd},
+	"stats":     {"imprime contagens e agregações das coleções", statsCmd},
+	"birthdays": {"lista aniversários e bodas em uma janela de datas ou gera o calendário .ics", birthdaysCmd},
+	"preflight": {"verifica conectividade, configuração e permissões", preflightCmd},
+	"schema":    {"gera, aplica e verifica o schema e os índices do banco final", schemaCmd},
+	"serve":     {"inicia a API de controle para disparar e acompanhar execuções", serveCmd},
+	"version":   {"imprime informações de build", versionCmd},
+}
+
+// Executar interpreta os argumentos da linha de comando (sem o nome do binário),
+// despacha para o subcomando correspondente e retorna o código de saída.
+func Executar(args []string) int {
+	if len(args) == 0 {
+		uso(os.Stderr)
+		return ExitUso
+	}
+
+	nome := args[0]
+	if nome == "help" || nome == "-h" || nome == "--help" {
+		uso(os.Stdout)
+		return ExitSucesso
+	}
+
+	if err := configurarLogger(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return ExitUso
+	}
+
+	cmd, ok := comandos[nome]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "subcomando desconhecido: %q\n\n", nome)
+		uso(os.Stderr)
+		return ExitUso
+	}
+
+	return cmd.executar(args[1:])
+}
+
+// uso imprime a lista de subcomandos disponíveis.
+func uso(w io.Writer) {
+	fmt.Fprintln(w, "Uso: etl-service <subcomando> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Subcomandos:")
+
+	nomes := make([]string, 0, len(comandos))
+	for nome := range comandos {
+		nomes = append(nomes, nome)
+	}
+	sort.Strings(nomes)
+	for _, nome := range nomes {
+		fmt.Fprintf(w, "  %-10s %s\n", nome, comandos[nome].descricao)
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Use 'etl-service <subcomando> -h' para ver as flags de cada subcomando.")
+}
@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"etl-service/src/config/database"
+	"etl-service/src/config/env"
+	"etl-service/src/config/logger"
+	"etl-service/src/config/metricas"
+	"etl-service/src/config/tracing"
+)
+
+// conectar carrega as variáveis de ambiente e abre a conexão com o MongoDB
+// utilizando a URI definida em BANCO_INICIAL.
+//
+// Retorna a conexão e uma função para encerrá-la, que deve ser chamada via defer.
+func conectar() (database.MongoConnection, func(), error) {
+	// Carrega as variáveis do arquivo .env para o ambiente
+	env.LoadEnv()
+	if err := configurarLogger(); err != nil {
+		return nil, nil, err
+	}
+
+	// Lê a variável de ambiente com a URI do MongoDB
+	bancoInicial := os.Getenv("BANCO_INICIAL")
+	if bancoInicial == "" {
+		return nil, nil, errors.New("variável de ambiente BANCO_INICIAL não configurada")
+	}
+
+	// Cria a conexão com o MongoDB via interface MongoConnection
+	conn := database.NewMongoConnection(metricas.MonitorMongo(), tracing.MonitorMongo())
+	if err := conn.Connect(bancoInicial); err != nil {
+		return nil, nil, fmt.Errorf("erro ao conectar ao MongoDB: %w", err)
+	}
+
+	fechar := func() {
+		if err := conn.Disconnect(context.Background()); err != nil {
+			slog.Error("erro ao desconectar do MongoDB", logger.Erro(err))
+		}
+	}
+	return conn, fechar, nil
+}
+
+// parseFlags interpreta as flags do subcomando, retornando o código de saída
+// a ser usado caso a interpretação falhe (ou a ajuda seja solicitada) e false.
+func parseFlags(fs *flag.FlagSet, args []string) (int, bool) {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return ExitSucesso, false
+		}
+		return ExitUso, false
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(os.Stderr, "argumentos inesperados: %v\n", fs.Args())
+		return ExitUso, false
+	}
+	return 0, true
+}
@@ -0,0 +1,174 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+
+	"etl-service/src/config/logger"
+	bancofinal "etl-service/src/config/model/banco_final"
+	"etl-service/src/exec/domain"
+	finalrepository "etl-service/src/exec/repository/final_repository"
+	"etl-service/src/exec/vcard"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Formatos aceitos pelo subcomando export.
+const (
+	formatoJSON  = "json" // Extended JSON relaxado (array ou, com -ndjson, um documento por linha)
+	formatoVCard = "vcf"  // vCard 4.0, um contato por membro
+)
+
+// exportCmd exporta os membros do banco final em JSON (Extended JSON relaxado) ou vCard,
+// gravando na saída padrão ou no arquivo indicado por -o. Os membros podem ser filtrados
+// por status, bairro e família.
+func exportCmd(args []string) int {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	saida := fs.String("o", "", "arquivo de saída (padrão: saída padrão)")
+	formato := fs.String("format", formatoJSON, "formato de saída: json ou vcf")
+	ndjson := fs.Bool("ndjson", false, "grava um documento por linha em vez de um array JSON")
+	status := fs.String("status", "", "exporta apenas os membros com estes status, separados por vírgula")
+	bairro := fs.String("bairro", "", "exporta apenas os membros destes bairros, separados por vírgula")
+	familia := fs.String("familia", "", "exporta apenas a família com este _id ou a família do membro com este nome")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if *formato != formatoJSON && *formato != formatoVCard {
+		fmt.Fprintf(os.Stderr, "formato de exportação desconhecido: %q (use json ou vcf)\n", *formato)
+		return ExitUso
+	}
+	if *ndjson && *formato != formatoJSON {
+		fmt.Fprintln(os.Stderr, "-ndjson só pode ser usado com -format json")
+		return ExitUso
+	}
+
+	conn, fechar, err := conectar()
+	if err != nil {
+		slog.Error("falha ao exportar membros", logger.Erro(err))
+		return ExitErroFatal
+	}
+	defer fechar()
+
+	membros, err := finalrepository.NewDataFinalRepository(conn).GetAll()
+	if err != nil {
+		slog.Error("falha ao exportar membros", logger.Erro(err))
+		return ExitErroFatal
+	}
+	membros, err = filtrarMembros(membros, separarLista(*status), separarLista(*bairro), *familia)
+	if err != nil {
+		slog.Error("falha ao exportar membros", logger.Erro(err))
+		return ExitErroFatal
+	}
+
+	var w io.Writer = os.Stdout
+	if *saida != "" {
+		file, err := os.Create(*saida)
+		if err != nil {
+			slog.Error("erro ao criar arquivo de exportação", "arquivo", *saida, logger.Erro(err))
+			return ExitErroFatal
+		}
+		defer file.Close()
+		w = file
+	}
+
+	if *formato == formatoVCard {
+		_, err = vcard.Escrever(w, membros)
+	} else {
+		err = escreverJSON(w, membros, *ndjson)
+	}
+	if err != nil {
+		slog.Error("erro ao gravar exportação", logger.Erro(err))
+		return ExitErroFatal
+	}
+
+	if *saida != "" {
+		fmt.Printf("Exportados %d membros para '%s'\n", len(membros), *saida)
+	}
+	return ExitSucesso
+}
+
+// escreverJSON grava os membros em Extended JSON relaxado, como array ou um documento por linha.
+func escreverJSON(w io.Writer, membros []bancofinal.Membro, ndjson bool) error {
+	buf := bufio.NewWriter(w)
+	if !ndjson {
+		buf.WriteString("[\n")
+	}
+	for i, m := range membros {
+		doc, err := bson.MarshalExtJSON(m, false, false)
+		if err != nil {
+			return fmt.Errorf("erro ao serializar membro '%s': %w", m.Name, err)
+		}
+		if !ndjson && i > 0 {
+			buf.WriteString(",\n")
+		}
+		buf.Write(doc)
+		if ndjson {
+			buf.WriteString("\n")
+		}
+	}
+	if !ndjson {
+		buf.WriteString("\n]\n")
+	}
+	return buf.Flush()
+}
+
+// filtrarMembros mantém os membros com um dos status e um dos bairros informados (comparados sem acentos
+// e maiúsculas; listas vazias não filtram) e, quando familia não é vazio, apenas os da família indicada
+// pelo _id ou pelo nome de um dos seus membros.
+func filtrarMembros(membros []bancofinal.Membro, status, bairros []string, familia string) ([]bancofinal.Membro, error) {
+	chaves := func(valores []string) map[string]bool {
+		conjunto := make(map[string]bool, len(valores))
+		for _, v := range valores {
+			conjunto[domain.ChaveTexto(v)] = true
+		}
+		return conjunto
+	}
+	porStatus, porBairro := chaves(status), chaves(bairros)
+
+	var familiaID *primitive.ObjectID
+	if familia != "" {
+		id, err := resolverFamilia(membros, familia)
+		if err != nil {
+			return nil, err
+		}
+		familiaID = &id
+	}
+
+	return slices.DeleteFunc(membros, func(m bancofinal.Membro) bool {
+		switch {
+		case len(porStatus) > 0 && !porStatus[domain.ChaveTexto(m.Status)]:
+			return true
+		case len(porBairro) > 0 && !porBairro[domain.ChaveTexto(m.Endereco.Bairro)]:
+			return true
+		case familiaID != nil && (m.FamiliaID == nil || *m.FamiliaID != *familiaID):
+			return true
+		}
+		return false
+	}), nil
+}
+
+// resolverFamilia interpreta o valor como _id da família ou, se não for um ObjectID, como o nome de um membro,
+// retornando a família dele.
+func resolverFamilia(membros []bancofinal.Membro, valor string) (primitive.ObjectID, error) {
+	if id, err := primitive.ObjectIDFromHex(valor); err == nil {
+		return id, nil
+	}
+	chave := domain.ChaveTexto(valor)
+	for _, m := range membros {
+		if domain.ChaveTexto(m.Name) != chave {
+			continue
+		}
+		if m.FamiliaID == nil {
+			return primitive.NilObjectID, fmt.Errorf("o membro '%s' ainda não tem família; execute o run antes de exportar", m.Name)
+		}
+		return *m.FamiliaID, nil
+	}
+	return primitive.NilObjectID, errors.New("família não encontrada: informe o _id da família ou o nome de um membro")
+}
@@ -0,0 +1,16 @@
+package cli
+
+import (
+	"etl-service/src/config/env"
+	"etl-service/src/config/logger"
+)
+
+// configurarLogger configura o slog com o nível (ETL_LOG_LEVEL) e o formato (ETL_LOG_FORMAT) do ambiente.
+// É chamada no início da CLI e novamente após o carregamento do .env.
+func configurarLogger() error {
+	_, err := logger.Configurar(logger.Config{
+		Nivel:   env.GetString("ETL_LOG_LEVEL", "info"),
+		Formato: env.GetString("ETL_LOG_FORMAT", "text"),
+	})
+	return err
+}
@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"etl-service/src/config/env"
+	"etl-service/src/config/logger"
+	"etl-service/src/config/metricas"
+)
+
+// flagsMetricas agrupa as flags de exportação das métricas Prometheus.
+type flagsMetricas struct {
+	addr     *string
+	push     *string
+	textfile *string
+}
+
+// registrarFlagsMetricas adiciona ao FlagSet as flags de métricas.
+// Valores vazios mantêm o que estiver configurado nas variáveis de ambiente.
+func registrarFlagsMetricas(fs *flag.FlagSet) flagsMetricas {
+	return flagsMetricas{
+		addr:     fs.String("metrics-addr", "", "endereço do endpoint /metrics durante a execução, ex: :9090 (padrão: ETL_METRICS_ADDR)"),
+		push:     fs.String("metrics-push", "", "URL do Pushgateway para enviar as métricas ao final (padrão: ETL_METRICS_PUSH_URL)"),
+		textfile: fs.String("metrics-textfile", "", "arquivo .prom para o textfile collector (padrão: ETL_METRICS_TEXTFILE)"),
+	}
+}
+
+// iniciar sobe o endpoint /metrics (se configurado) e retorna a função que deve ser chamada
+// ao final da execução para registrar o resultado, publicar no Pushgateway/arquivo e encerrar o endpoint.
+// Deve ser chamada após o carregamento do .env.
+func (f flagsMetricas) iniciar() func(duracao time.Duration, sucesso bool) {
+	addr := valorOuEnv(*f.addr, "ETL_METRICS_ADDR")
+	pushURL := valorOuEnv(*f.push, "ETL_METRICS_PUSH_URL")
+	textfile := valorOuEnv(*f.textfile, "ETL_METRICS_TEXTFILE")
+
+	var srv *http.Server
+	if addr != "" {
+		srv = metricas.Servir(addr)
+		slog.Info("endpoint de métricas disponível", "url", "http://"+addr+"/metrics")
+	}
+
+	return func(duracao time.Duration, sucesso bool) {
+		metricas.DuracaoExecucao.Set(duracao.Seconds())
+		if sucesso {
+			metricas.UltimaExecucaoSucesso.SetToCurrentTime()
+		}
+
+		if pushURL != "" {
+			if err := metricas.Enviar(pushURL, "etl-service"); err != nil {
+				slog.Warn("falha ao enviar métricas ao Pushgateway", logger.Erro(err))
+			}
+		}
+		if textfile != "" {
+			if err := metricas.GravarArquivo(textfile); err != nil {
+				slog.Warn("falha ao gravar o arquivo de métricas", logger.Erro(err))
+			}
+		}
+		if srv != nil {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			srv.Shutdown(ctx)
+		}
+	}
+}
+
+// valorOuEnv retorna o valor da flag, ou o da variável de ambiente quando a flag não foi informada.
+func valorOuEnv(valor, chave string) string {
+	if valor != "" {
+		return valor
+	}
+	return env.GetString(chave, "")
+}
@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"etl-service/src/config/database"
+	"etl-service/src/config/logger"
+	inicialrepository "etl-service/src/exec/repository/inicial_repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// variaveisObrigatorias lista as variáveis de ambiente necessárias para executar o ETL.
+var variaveisObrigatorias = []string{
+	"BANCO_INICIAL",
+	"MONGO_DB_NAME",
+	"MONGO_COLLECTION_MEMBRO",
+	"MONGO_DB_BANCO_FINAL",
+	"MONGO_COLLECTION_BANCO_FINAL",
+}
+
+// privilegio representa um privilégio retornado pelo comando connectionStatus.
+type privilegio struct {
+	Resource struct {
+		DB          *string `bson:"db"`
+		Collection  *string `bson:"collection"`
+		Cluster     bool    `bson:"cluster"`
+		AnyResource bool    `bson:"anyResource"`
+	} `bson:"resource"`
+	Actions []string `bson:"actions"`
+}
+
+// preflightCmd verifica se o ambiente está pronto para executar o ETL:
+// variáveis de ambiente, conectividade, leitura na origem e permissões de escrita no destino.
+// Retorna ExitErroFatal se qualquer verificação falhar.
+func preflightCmd(args []string) int {
+	fs := flag.NewFlagSet("preflight", flag.ContinueOnError)
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	ok := true
+	reportar := func(nome string, err error) {
+		if err != nil {
+			ok = false
+			slog.Error("verificação falhou", "verificacao", nome, logger.Erro(err))
+			return
+		}
+		slog.Info("verificação concluída", "verificacao", nome)
+	}
+
+	conn, fechar, err := conectar()
+	for _, v := range variaveisObrigatorias {
+		if os.Getenv(v) == "" {
+			reportar("variável "+v, fmt.Errorf("não configurada"))
+		}
+	}
+	if err != nil {
+		reportar("conexão com o MongoDB", err)
+		return ExitErroFatal
+	}
+	defer fechar()
+	reportar("conexão com o MongoDB", nil)
+	if !ok {
+		return ExitErroFatal
+	}
+
+	_, err = inicialrepository.NewDataInicialRepository(conn).Count()
+	reportar("leitura no banco inicial", err)
+
+	privilegios, autenticado, err := carregarPrivilegios(conn)
+	if err != nil {
+		reportar("consulta de privilégios (connectionStatus)", err)
+		return ExitErroFatal
+	}
+	if !autenticado {
+		slog.Warn("servidor sem autenticação: permissões não verificadas")
+	} else {
+		origemDB, origemColl := os.Getenv("MONGO_DB_NAME"), os.Getenv("MONGO_COLLECTION_MEMBRO")
+		destinoDB, destinoColl := os.Getenv("MONGO_DB_BANCO_FINAL"), os.Getenv("MONGO_COLLECTION_BANCO_FINAL")
+
+		reportar("permissão find em "+origemDB+"."+origemColl, exigirAcoes(privilegios, origemDB, origemColl, "find"))
+		reportar("permissões find/insert em "+destinoDB+"."+destinoColl, exigirAcoes(privilegios, destinoDB, destinoColl, "find", "insert"))
+	}
+
+	if !ok {
+		return ExitErroFatal
+	}
+	slog.Info("preflight concluído com sucesso")
+	return ExitSucesso
+}
+
+// carregarPrivilegios executa connectionStatus com showPrivileges e retorna os privilégios
+// do usuário autenticado. O segundo retorno é false quando a conexão não possui usuário autenticado.
+func carregarPrivilegios(conn database.MongoConnection) ([]privilegio, bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var resultado struct {
+		AuthInfo struct {
+			AuthenticatedUsers []bson.Raw   `bson:"authenticatedUsers"`
+			Privileges         []privilegio `bson:"authenticatedUserPrivileges"`
+		} `bson:"authInfo"`
+	}
+
+	cmd := bson.D{{Key: "connectionStatus", Value: 1}, {Key: "showPrivileges", Value: true}}
+	if err := conn.Database("admin").RunCommand(ctx, cmd).Decode(&resultado); err != nil {
+		return nil, false, err
+	}
+
+	return resultado.AuthInfo.Privileges, len(resultado.AuthInfo.AuthenticatedUsers) > 0, nil
+}
+
+// exigirAcoes verifica se as ações informadas são concedidas para a coleção db.coll
+// por algum dos privilégios, considerando recursos de banco inteiro, cluster e anyResource.
+func exigirAcoes(privilegios []privilegio, db, coll string, acoes ...string) error {
+	concedidas := make(map[string]bool)
+	for _, p := range privilegios {
+		r := p.Resource
+		cobre := r.AnyResource ||
+			(r.DB != nil && (*r.DB == "" || *r.DB == db) &&
+				r.Collection != nil && (*r.Collection == "" || *r.Collection == coll))
+		if !cobre {
+			continue
+		}
+		for _, a := range p.Actions {
+			concedidas[a] = true
+		}
+	}
+
+	var faltando []string
+	for _, a := range acoes {
+		if !concedidas[a] {
+			faltando = append(faltando, a)
+		}
+	}
+	if len(faltando) > 0 {
+		return fmt.Errorf("ações não concedidas: %v", faltando)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"etl-service/src/config/logger"
+	"etl-service/src/exec/perfil"
+	inicialrepository "etl-service/src/exec/repository/inicial_repository"
+)
+
+// profileCmd levanta o perfil da coleção do banco inicial (preenchimento, valores distintos e mais frequentes,
+// formatos, tamanhos e valores que cada transformação rejeitaria), gravando o resultado em JSON e em HTML.
+func profileCmd(args []string) int {
+	fs := flag.NewFlagSet("profile", flag.ContinueOnError)
+	padrao := perfil.ConfigPadrao()
+	saidaJSON := fs.String("o", "perfil.json", "arquivo JSON com o perfil completo (vazio não grava)")
+	saidaHTML := fs.String("html", "perfil.html", "arquivo HTML com o resumo do perfil (vazio não grava)")
+	top := fs.Int("top", padrao.Principais, "quantos valores mais frequentes listar por campo")
+	exemplos := fs.Int("exemplos", padrao.Exemplos, "quantos valores rejeitados listar por campo")
+	arquivoEnums := registrarFlagEnums(fs)
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if *top < 0 || *exemplos < 0 {
+		fmt.Fprintln(os.Stderr, "-top e -exemplos não podem ser negativos")
+		return ExitUso
+	}
+
+	enums, err := carregarEnums(*arquivoEnums)
+	if err != nil {
+		slog.Error("falha ao carregar a tabela de enums", logger.Erro(err))
+		return ExitErroFatal
+	}
+
+	conn, fechar, err := conectar()
+	if err != nil {
+		slog.Error("falha ao levantar o perfil", logger.Erro(err))
+		return ExitErroFatal
+	}
+	defer fechar()
+
+	ctx, cancel := contextoInterrompivel()
+	defer cancel()
+
+	cfg := perfil.Config{Principais: *top, Exemplos: *exemplos, MaxDistintos: padrao.MaxDistintos}
+	relatorio, err := perfil.NewPerfilador(inicialrepository.NewDataInicialRepository(conn), enums, cfg).Perfilar(ctx)
+	if err != nil {
+		slog.Error("falha ao levantar o perfil", logger.Erro(err))
+		return ExitErroFatal
+	}
+
+	if *saidaJSON != "" {
+		doc, err := json.MarshalIndent(relatorio, "", "  ")
+		if err != nil {
+			slog.Error("erro ao serializar o perfil", logger.Erro(err))
+			return ExitErroFatal
+		}
+		if err := os.WriteFile(*saidaJSON, append(doc, '\n'), 0o644); err != nil {
+			slog.Error("erro ao gravar o perfil", "arquivo", *saidaJSON, logger.Erro(err))
+			return ExitErroFatal
+		}
+	}
+	if *saidaHTML != "" {
+		file, err := os.Create(*saidaHTML)
+		if err != nil {
+			slog.Error("erro ao criar o resumo do perfil", "arquivo", *saidaHTML, logger.Erro(err))
+			return ExitErroFatal
+		}
+		defer file.Close()
+		if err := perfil.EscreverHTML(file, relatorio); err != nil {
+			slog.Error("erro ao gravar o resumo do perfil", "arquivo", *saidaHTML, logger.Erro(err))
+			return ExitErroFatal
+		}
+	}
+
+	fmt.Printf("Documentos lidos: %d, válidos: %d, inválidos: %d\n", relatorio.Total, relatorio.Conversao.Validos, relatorio.Conversao.Invalidos)
+	for _, c := range relatorio.Campos {
+		if c.Rejeitados > 0 {
+			fmt.Printf("  %-22s %d valores rejeitados\n", c.Nome, c.Rejeitados)
+		}
+	}
+	return ExitSucesso
+}
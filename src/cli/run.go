@@ -0,0 +1,242 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"etl-service/src/config/logger"
+	"etl-service/src/config/model/historico"
+	"etl-service/src/exec/cep"
+	"etl-service/src/exec/domain"
+	"etl-service/src/exec/execucao"
+	getdata "etl-service/src/exec/get_data"
+	"etl-service/src/exec/notificacao"
+	familiarepository "etl-service/src/exec/repository/familia_repository"
+	finalrepository "etl-service/src/exec/repository/final_repository"
+	historicorepository "etl-service/src/exec/repository/historico_repository"
+	inicialrepository "etl-service/src/exec/repository/inicial_repository"
+	"etl-service/src/exec/trava"
+)
+
+// jobRun é o job registrado no histórico pelo subcomando run, equivalente ao job padrão completo.
+const jobRun = "completo"
+
+// flagsPipeline agrupa as flags que sobrescrevem a configuração do pipeline lida do ambiente.
+type flagsPipeline struct {
+	transformWorkers *int
+	loadWorkers      *int
+	buffer           *int
+	lote             *int
+	taxa             *float64
+	adaptativo       *bool
+	baseCep          *string
+	enums            *string
+}
+
+// registrarFlagsPipeline adiciona ao FlagSet as flags de dimensionamento do pipeline.
+// O valor zero mantém o que estiver configurado nas variáveis de ambiente.
+func registrarFlagsPipeline(fs *flag.FlagSet) flagsPipeline {
+	return flagsPipeline{
+		transformWorkers: fs.Int("transform-workers", 0, "workers da transformação (padrão: ETL_TRANSFORM_WORKERS ou número de CPUs)"),
+		loadWorkers:      fs.Int("load-workers", 0, "workers da carga (padrão: ETL_LOAD_WORKERS ou 10)"),
+		buffer:           fs.Int("buffer", 0, "capacidade dos canais entre etapas (padrão: ETL_BUFFER ou 100)"),
+		lote:             fs.Int("dedup-lote", 0, "nomes consultados por lote na deduplicação (padrão: ETL_DEDUP_LOTE ou 500)"),
+		taxa:             fs.Float64("load-rate", 0, "limite de escritas por segundo na carga (padrão: ETL_LOAD_RATE ou sem limite)"),
+		adaptativo:       fs.Bool("adaptive", false, "ajusta a concorrência da carga pela latência e erros observados (padrão: ETL_LOAD_ADAPTIVE)"),
+		baseCep:          fs.String("cep-base", "", "CSV da base de CEPs para validar e completar os endereços (padrão: ETL_CEP_BASE)"),
+		enums:            registrarFlagEnums(fs),
+	}
+}
+
+// registrarFlagEnums adiciona ao FlagSet a flag do arquivo da tabela de enums.
+func registrarFlagEnums(fs *flag.FlagSet) *string {
+	return fs.String("enums", "", "JSON com os valores canônicos e sinônimos de sexo, estado civil e status (padrão: ETL_ENUMS_FILE ou tabela embutida)")
+}
+
+// carregarEnums lê a tabela de enums do arquivo informado ou de ETL_ENUMS_FILE.
+// Sem arquivo, retorna nil, e a tabela padrão do domínio é usada.
+func carregarEnums(arquivo string) (*domain.Enums, error) {
+	caminho := valorOuEnv(arquivo, "ETL_ENUMS_FILE")
+	if caminho == "" {
+		return nil, nil
+	}
+	conteudo, err := os.ReadFile(caminho)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler tabela de enums '%s': %w", caminho, err)
+	}
+	enums, err := domain.ParseEnums(conteudo)
+	if err != nil {
+		return nil, fmt.Errorf("arquivo '%s': %w", caminho, err)
+	}
+	return enums, nil
+}
+
+// config combina a configuração do ambiente com as flags informadas e carrega a base de CEPs, se configurada.
+// Deve ser chamada após o carregamento do .env.
+func (f flagsPipeline) config() (getdata.Config, error) {
+	cfg := getdata.ConfigPadrao()
+	if *f.transformWorkers > 0 {
+		cfg.TransformWorkers = *f.transformWorkers
+	}
+	if *f.loadWorkers > 0 {
+		cfg.LoadWorkers = *f.loadWorkers
+	}
+	if *f.buffer > 0 {
+		cfg.Buffer = *f.buffer
+	}
+	if *f.lote > 0 {
+		cfg.TamanhoLote = *f.lote
+	}
+	if *f.taxa > 0 {
+		cfg.Carga.OpsPorSegundo = *f.taxa
+	}
+	if *f.adaptativo {
+		cfg.Carga.Adaptativo = true
+	}
+	if caminho := valorOuEnv(*f.baseCep, "ETL_CEP_BASE"); caminho != "" {
+		base, err := cep.Carregar(caminho)
+		if err != nil {
+			return cfg, err
+		}
+		slog.Info("base de CEPs carregada", "arquivo", caminho, "ceps", base.Total())
+		cfg.Ceps = base
+	}
+	enums, err := carregarEnums(*f.enums)
+	if err != nil {
+		return cfg, err
+	}
+	cfg.Enums = enums
+	return cfg, nil
+}
+
+// contextoInterrompivel retorna um contexto cancelado ao receber SIGINT ou SIGTERM,
+// permitindo que o pipeline encerre as etapas de forma ordenada.
+func contextoInterrompivel() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+}
+
+// runCmd executa o ETL completo: lê o banco inicial, converte e insere no banco final.
+//
+// A execução é protegida pela mesma trava distribuída dos jobs (a do destino da carga): se outra instância
+// estiver carregando o banco final, aguarda até -lock-wait e, persistindo a ocupação, encerra sem executar (ExitOcupado).
+//
+// A execução é registrada no histórico com suas ocorrências, como as disparadas pela API e pelo agendador,
+// e conta como base das execuções incrementais do job completo. Ao final, o resultado é notificado
+// conforme ETL_NOTIFY_* (veja o pacote notificacao).
+//
+// Retorna ExitFalhaParcial quando a execução termina com membros que falharam
+// na transformação ou na inserção, e ExitErroFatal quando a execução não pôde ser concluída.
+func runCmd(args []string) int {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	esperaTrava := registrarFlagEsperaTrava(fs)
+	pf := registrarFlagsPipeline(fs)
+	tf := registrarFlagsTracing(fs)
+	mf := registrarFlagsMetricas(fs)
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	conn, fechar, err := conectar()
+	if err != nil {
+		slog.Error("falha ao preparar a execução", logger.Erro(err))
+		return ExitErroFatal
+	}
+	defer fechar()
+
+	encerrarTracing, err := tf.iniciar()
+	if err != nil {
+		slog.Error("falha ao configurar o tracing", logger.Erro(err))
+		return ExitErroFatal
+	}
+	defer encerrarTracing()
+
+	cfg, err := pf.config()
+	if err != nil {
+		slog.Error("falha ao configurar o pipeline", logger.Erro(err))
+		return ExitErroFatal
+	}
+
+	notificador, err := notificacao.NewNotificador(notificacao.ConfigPadrao())
+	if err != nil {
+		slog.Error("falha ao configurar as notificações", logger.Erro(err))
+		return ExitErroFatal
+	}
+
+	ctx, cancel := contextoInterrompivel()
+	defer cancel()
+	registro := historico.Execucao{
+		ID:     logger.NovoRunID(),
+		Job:    jobRun,
+		Origem: execucao.OrigemCLI,
+		Status: historico.StatusExecutando,
+		Inicio: time.Now(),
+	}
+	ctx = logger.ComRunID(ctx, registro.ID)
+	log := logger.DoContexto(ctx)
+
+	lease, err := novaTrava(conn, *esperaTrava).Adquirir(ctx, execucao.RecursoCarga())
+	if errors.Is(err, trava.ErrOcupada) {
+		log.Warn("outra instância está carregando o banco final: encerrando sem executar", "recurso", execucao.RecursoCarga())
+		return ExitOcupado
+	}
+	if err != nil {
+		log.Error("falha ao adquirir a trava do job", logger.Erro(err))
+		return ExitErroFatal
+	}
+	defer lease.Liberar()
+	ctx = lease.Contexto()
+
+	repoHistorico := historicorepository.NewDataHistoricoRepository(conn)
+	if err := repoHistorico.Salvar(ctx, registro); err != nil {
+		log.Error("falha ao registrar a execução no histórico", logger.Erro(err))
+		return ExitErroFatal
+	}
+
+	// Inicializa o serviço de acesso a dados, injetando os repositórios e a configuração do pipeline
+	service := getdata.NewGetDataBancoInicial(
+		inicialrepository.NewDataInicialRepository(conn),
+		finalrepository.NewDataFinalRepository(conn),
+		familiarepository.NewDataFamiliaRepository(conn),
+		cfg,
+	)
+
+	finalizarMetricas := mf.iniciar()
+	relatorio, err := service.GetAll(ctx)
+	if causa := context.Cause(ctx); errors.Is(causa, trava.ErrPerdida) {
+		err = causa
+	}
+	finalizarMetricas(relatorio.Duracao, err == nil && !relatorio.Parcial())
+
+	execucao.ConcluirRegistro(&registro, relatorio, err)
+	// O resultado é gravado mesmo quando a execução foi interrompida
+	if errHistorico := execucao.SalvarResultado(context.WithoutCancel(ctx), repoHistorico, registro, relatorio); errHistorico != nil {
+		log.Error("erro ao gravar execução no histórico", logger.Erro(errHistorico))
+	}
+
+	// A trava é liberada antes da notificação para não segurar outras instâncias durante o envio
+	lease.Liberar()
+	if errNotificacao := notificador.Notificar(context.WithoutCancel(ctx), registro, relatorio); errNotificacao != nil {
+		log.Error("erro ao enviar notificação da execução", logger.Erro(errNotificacao))
+	}
+
+	if err != nil {
+		log.Error("erro ao processar membros", logger.Erro(err))
+		return ExitErroFatal
+	}
+
+	if relatorio.Parcial() {
+		log.Warn("execução concluída com falhas", "falhas", relatorio.Falhas(), "total", relatorio.Total)
+		for categoria, total := range relatorio.FalhasPorCategoria {
+			log.Warn("falhas por categoria", logger.ChaveCategoria, string(categoria), "total", total)
+		}
+		return ExitFalhaParcial
+	}
+	return ExitSucesso
+}
@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"etl-service/src/config/logger"
+	"etl-service/src/exec/provisionamento"
+)
+
+// schemaCmd gerencia o schema e os índices da coleção do banco final.
+//
+// Ações disponíveis:
+// - print: imprime o validador gerado a partir do modelo (não requer conexão).
+// - apply: cria a coleção ou atualiza o validador via collMod e garante os índices.
+// - drift: compara o validador e os índices atuais com os esperados; retorna ExitFalhaParcial se houver divergência.
+func schemaCmd(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "Uso: etl-service schema <print|apply|drift> [flags]")
+		return ExitUso
+	}
+
+	acao, args := args[0], args[1:]
+	switch acao {
+	case "print":
+		return schemaPrint(args)
+	case "apply":
+		return schemaApply(args)
+	case "drift":
+		return schemaDrift(args)
+	default:
+		fmt.Fprintf(os.Stderr, "ação de schema desconhecida: %q (use print, apply ou drift)\n", acao)
+		return ExitUso
+	}
+}
+
+// schemaPrint imprime o validador esperado no formato aceito por db.createCollection/collMod.
+func schemaPrint(args []string) int {
+	fs := flag.NewFlagSet("schema print", flag.ContinueOnError)
+	saida := fs.String("o", "", "arquivo de saída (padrão: saída padrão)")
+	arquivoEnums := registrarFlagEnums(fs)
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	enums, err := carregarEnums(*arquivoEnums)
+	if err != nil {
+		slog.Error("falha ao carregar a tabela de enums", logger.Erro(err))
+		return ExitErroFatal
+	}
+
+	doc, err := json.MarshalIndent(map[string]interface{}{"validator": provisionamento.ValidadorEsperado(enums)}, "", "  ")
+	if err != nil {
+		slog.Error("erro ao serializar validador", logger.Erro(err))
+		return ExitErroFatal
+	}
+	doc = append(doc, '\n')
+
+	if *saida == "" {
+		os.Stdout.Write(doc)
+		return ExitSucesso
+	}
+	if err := os.WriteFile(*saida, doc, 0o644); err != nil {
+		slog.Error("erro ao gravar validador", "arquivo", *saida, logger.Erro(err))
+		return ExitErroFatal
+	}
+	return ExitSucesso
+}
+
+// schemaApply aplica o validador e os índices na coleção do banco final.
+func schemaApply(args []string) int {
+	fs := flag.NewFlagSet("schema apply", flag.ContinueOnError)
+	arquivoEnums := registrarFlagEnums(fs)
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	conn, fechar, err := conectar()
+	if err != nil {
+		slog.Error("falha ao provisionar o schema", logger.Erro(err))
+		return ExitErroFatal
+	}
+	defer fechar()
+
+	enums, err := carregarEnums(*arquivoEnums)
+	if err != nil {
+		slog.Error("falha ao carregar a tabela de enums", logger.Erro(err))
+		return ExitErroFatal
+	}
+
+	resultado, err := provisionamento.NewProvisionamentoFinal(conn, enums).Aplicar()
+	if err != nil {
+		slog.Error("falha ao provisionar o schema", logger.Erro(err))
+		return ExitErroFatal
+	}
+
+	if resultado.ColecaoCriada {
+		slog.Info("coleção criada com o validador esperado")
+	} else {
+		slog.Info("validador atualizado via collMod")
+	}
+	slog.Info("índices garantidos", "indices", resultado.Indices)
+	return ExitSucesso
+}
+
+// schemaDrift lista as divergências entre o estado atual da coleção e o esperado.
+func schemaDrift(args []string) int {
+	fs := flag.NewFlagSet("schema drift", flag.ContinueOnError)
+	arquivoEnums := registrarFlagEnums(fs)
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	conn, fechar, err := conectar()
+	if err != nil {
+		slog.Error("falha ao verificar o schema", logger.Erro(err))
+		return ExitErroFatal
+	}
+	defer fechar()
+
+	enums, err := carregarEnums(*arquivoEnums)
+	if err != nil {
+		slog.Error("falha ao carregar a tabela de enums", logger.Erro(err))
+		return ExitErroFatal
+	}
+
+	drift, err := provisionamento.NewProvisionamentoFinal(conn, enums).Drift()
+	if err != nil {
+		slog.Error("falha ao verificar o schema", logger.Erro(err))
+		return ExitErroFatal
+	}
+
+	if len(drift) == 0 {
+		slog.Info("nenhuma divergência entre o schema atual e o esperado")
+		return ExitSucesso
+	}
+	for _, d := range drift {
+		slog.Warn("divergência no schema", "detalhe", d)
+	}
+	return ExitFalhaParcial
+}
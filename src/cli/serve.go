@@ -0,0 +1,170 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"etl-service/src/api"
+	"etl-service/src/config/env"
+	"etl-service/src/config/jobs"
+	"etl-service/src/config/logger"
+	"etl-service/src/exec/agendador"
+	"etl-service/src/exec/execucao"
+	"etl-service/src/exec/notificacao"
+	familiarepository "etl-service/src/exec/repository/familia_repository"
+	finalrepository "etl-service/src/exec/repository/final_repository"
+	historicorepository "etl-service/src/exec/repository/historico_repository"
+	inicialrepository "etl-service/src/exec/repository/inicial_repository"
+)
+
+// serveCmd inicia o modo servidor: expõe a API de controle para disparar, acompanhar
+// e cancelar execuções dos jobs configurados, além de /metrics e /healthz.
+func serveCmd(args []string) int {
+	return servir("serve", args, false)
+}
+
+// daemonCmd inicia o modo daemon: executa os jobs que possuem expressão cron nos horários agendados.
+// A API de controle também é exposta quando -addr ou ETL_API_ADDR é informado.
+func daemonCmd(args []string) int {
+	return servir("daemon", args, true)
+}
+
+// servir é a implementação comum de serve e daemon.
+//
+// A API de controle só é exposta sem ETL_API_TOKEN com -insecure, pois sem token qualquer cliente
+// que alcance o endereço pode disparar uma carga completa ou cancelar execuções.
+//
+// O processo roda até receber SIGINT ou SIGTERM; nesse momento o servidor para de aceitar
+// requisições, o agendador para de disparar e as execuções em andamento são canceladas
+// e registradas no histórico.
+func servir(nome string, args []string, agendar bool) int {
+	fs := flag.NewFlagSet(nome, flag.ContinueOnError)
+	ajudaAddr := "endereço da API de controle (padrão: ETL_API_ADDR ou 127.0.0.1:8080)"
+	if agendar {
+		ajudaAddr = "endereço da API de controle (padrão: ETL_API_ADDR; vazio desabilita a API)"
+	}
+	addr := fs.String("addr", "", ajudaAddr)
+	inseguro := fs.Bool("insecure", false, "permite expor a API de controle sem ETL_API_TOKEN (qualquer cliente pode iniciar e cancelar execuções)")
+	arquivoJobs := fs.String("jobs", "", "arquivo JSON com os jobs disponíveis (padrão: ETL_JOBS_FILE ou jobs completo/incremental)")
+	esperaTrava := registrarFlagEsperaTrava(fs)
+	pf := registrarFlagsPipeline(fs)
+	tf := registrarFlagsTracing(fs)
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	conn, fechar, err := conectar()
+	if err != nil {
+		slog.Error("falha ao preparar o "+nome, logger.Erro(err))
+		return ExitErroFatal
+	}
+	defer fechar()
+
+	encerrarTracing, err := tf.iniciar()
+	if err != nil {
+		slog.Error("falha ao configurar o tracing", logger.Erro(err))
+		return ExitErroFatal
+	}
+	defer encerrarTracing()
+
+	cfg, err := pf.config()
+	if err != nil {
+		slog.Error("falha ao configurar o pipeline", logger.Erro(err))
+		return ExitErroFatal
+	}
+
+	lista, err := jobs.Carregar(valorOuEnv(*arquivoJobs, "ETL_JOBS_FILE"))
+	if err != nil {
+		slog.Error("falha ao carregar os jobs", logger.Erro(err))
+		return ExitErroFatal
+	}
+
+	notificador, err := notificacao.NewNotificador(notificacao.ConfigPadrao())
+	if err != nil {
+		slog.Error("falha ao configurar as notificações", logger.Erro(err))
+		return ExitErroFatal
+	}
+
+	ctx, cancel := contextoInterrompivel()
+	defer cancel()
+
+	historico := historicorepository.NewDataHistoricoRepository(conn)
+	if err := historico.GarantirIndices(ctx); err != nil {
+		slog.Error("falha ao preparar o histórico de execuções", logger.Erro(err))
+		return ExitErroFatal
+	}
+
+	gerenciador := execucao.NewGerenciador(
+		inicialrepository.NewDataInicialRepository(conn),
+		finalrepository.NewDataFinalRepository(conn),
+		familiarepository.NewDataFamiliaRepository(conn),
+		historico,
+		novaTrava(conn, *esperaTrava),
+		notificador,
+		lista,
+		cfg,
+	)
+	defer gerenciador.Encerrar()
+
+	erros := make(chan error, 2)
+
+	enderecoAPI := valorOuEnv(*addr, "ETL_API_ADDR")
+	if enderecoAPI == "" && !agendar {
+		enderecoAPI = "127.0.0.1:8080"
+	}
+	if enderecoAPI != "" {
+		token := env.GetString("ETL_API_TOKEN", "")
+		if token == "" {
+			if !*inseguro {
+				slog.Error("ETL_API_TOKEN não configurado: defina o token ou use -insecure para expor a API sem autenticação", "addr", enderecoAPI)
+				return ExitUso
+			}
+			slog.Warn("API de controle sem autenticação: qualquer cliente que alcance o endereço pode iniciar e cancelar execuções", "addr", enderecoAPI)
+		}
+		srv := &http.Server{
+			Addr:              enderecoAPI,
+			Handler:           api.NewHandler(gerenciador, token),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		go func() {
+			if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+				erros <- err
+			}
+		}()
+		defer func() {
+			ctxShutdown, cancelShutdown := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancelShutdown()
+			srv.Shutdown(ctxShutdown)
+		}()
+		slog.Info("API de controle disponível", "addr", enderecoAPI, "jobs", len(lista))
+	}
+
+	agendadorEncerrado := make(chan struct{})
+	if agendar {
+		ag := agendador.NewAgendador(gerenciador, historico, lista)
+		go func() {
+			defer close(agendadorEncerrado)
+			if err := ag.Executar(ctx); err != nil {
+				erros <- err
+			}
+		}()
+	} else {
+		close(agendadorEncerrado)
+	}
+
+	code := ExitSucesso
+	select {
+	case <-ctx.Done():
+		slog.Info("encerrando o " + nome)
+	case err := <-erros:
+		slog.Error("erro no "+nome, logger.Erro(err))
+		code = ExitErroFatal
+		cancel()
+	}
+	<-agendadorEncerrado
+	return code
+}
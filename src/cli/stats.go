@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"sort"
+
+	"etl-service/src/config/logger"
+	finalrepository "etl-service/src/exec/repository/final_repository"
+	inicialrepository "etl-service/src/exec/repository/inicial_repository"
+)
+
+// camposStats lista os campos do banco final agregados pelo subcomando stats.
+var camposStats = []string{"status", "sexo", "estadoCivil", "endereco.bairro"}
+
+// estatisticas agrupa as contagens exibidas pelo subcomando stats.
+type estatisticas struct {
+	TotalInicial int64                       `json:"totalInicial"`
+	TotalFinal   int64                       `json:"totalFinal"`
+	Agregacoes   map[string]map[string]int64 `json:"agregacoes"`
+}
+
+// statsCmd imprime a contagem de membros nas coleções inicial e final
+// e a distribuição dos membros do banco final pelos principais campos.
+func statsCmd(args []string) int {
+	fs := flag.NewFlagSet("stats", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "imprime o resultado em JSON")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	conn, fechar, err := conectar()
+	if err != nil {
+		slog.Error("falha ao obter estatísticas", logger.Erro(err))
+		return ExitErroFatal
+	}
+	defer fechar()
+
+	inicial := inicialrepository.NewDataInicialRepository(conn)
+	final := finalrepository.NewDataFinalRepository(conn)
+
+	est := estatisticas{Agregacoes: make(map[string]map[string]int64)}
+	if est.TotalInicial, err = inicial.Count(); err != nil {
+		slog.Error("falha ao obter estatísticas", logger.Erro(err))
+		return ExitErroFatal
+	}
+	if est.TotalFinal, err = final.Count(); err != nil {
+		slog.Error("falha ao obter estatísticas", logger.Erro(err))
+		return ExitErroFatal
+	}
+	for _, campo := range camposStats {
+		contagem, err := final.CountBy(campo)
+		if err != nil {
+			slog.Error("falha ao obter estatísticas", logger.Erro(err))
+			return ExitErroFatal
+		}
+		est.Agregacoes[campo] = contagem
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(est); err != nil {
+			slog.Error("falha ao obter estatísticas", logger.Erro(err))
+			return ExitErroFatal
+		}
+		return ExitSucesso
+	}
+
+	fmt.Printf("Membros no banco inicial: %d\n", est.TotalInicial)
+	fmt.Printf("Membros no banco final:   %d\n", est.TotalFinal)
+	for _, campo := range camposStats {
+		fmt.Printf("\nPor %s:\n", campo)
+		imprimirContagem(est.Agregacoes[campo])
+	}
+	return ExitSucesso
+}
+
+// imprimirContagem imprime as contagens ordenadas da maior para a menor.
+func imprimirContagem(contagem map[string]int64) {
+	chaves := make([]string, 0, len(contagem))
+	for k := range contagem {
+		chaves = append(chaves, k)
+	}
+	sort.Slice(chaves, func(i, j int) bool {
+		if contagem[chaves[i]] != contagem[chaves[j]] {
+			return contagem[chaves[i]] > contagem[chaves[j]]
+		}
+		return chaves[i] < chaves[j]
+	})
+	for _, k := range chaves {
+		rotulo := k
+		if rotulo == "" {
+			rotulo = "(vazio)"
+		}
+		fmt.Printf("  %-30s %d\n", rotulo, contagem[k])
+	}
+}
@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+	"time"
+
+	"etl-service/src/config/logger"
+	"etl-service/src/config/tracing"
+)
+
+// flagsTracing agrupa as flags de exportação dos traces OpenTelemetry.
+type flagsTracing struct {
+	exportador *string
+	arquivo    *string
+}
+
+// registrarFlagsTracing adiciona ao FlagSet as flags de tracing.
+// Valores vazios mantêm o que estiver configurado nas variáveis de ambiente.
+func registrarFlagsTracing(fs *flag.FlagSet) flagsTracing {
+	return flagsTracing{
+		exportador: fs.String("trace-exporter", "", "exportador de traces: otlp, stdout ou file (padrão: ETL_TRACING_EXPORTER; vazio desabilita)"),
+		arquivo:    fs.String("trace-file", "", "arquivo de saída do exportador file (padrão: ETL_TRACING_FILE ou traces.json)"),
+	}
+}
+
+// iniciar configura o TracerProvider e retorna a função que exporta os spans pendentes ao final.
+// Deve ser chamada após o carregamento do .env.
+func (f flagsTracing) iniciar() (func(), error) {
+	cfg := tracing.Config{
+		Exportador: valorOuEnv(*f.exportador, "ETL_TRACING_EXPORTER"),
+		Arquivo:    valorOuEnv(*f.arquivo, "ETL_TRACING_FILE"),
+		Versao:     Version,
+	}
+	if cfg.Exportador == tracing.ExportadorFile && cfg.Arquivo == "" {
+		cfg.Arquivo = "traces.json"
+	}
+
+	encerrar, err := tracing.Iniciar(context.Background(), cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := encerrar(ctx); err != nil {
+			slog.Warn("erro ao exportar traces", logger.Erro(err))
+		}
+	}, nil
+}
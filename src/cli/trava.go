@@ -0,0 +1,26 @@
+package cli
+
+import (
+	"flag"
+	"time"
+
+	"etl-service/src/config/database"
+	travarepository "etl-service/src/exec/repository/trava_repository"
+	"etl-service/src/exec/trava"
+)
+
+// registrarFlagEsperaTrava adiciona ao FlagSet a flag de espera pela trava distribuída.
+// O valor zero mantém o que estiver configurado em ETL_LOCK_ESPERA.
+func registrarFlagEsperaTrava(fs *flag.FlagSet) *time.Duration {
+	return fs.Duration("lock-wait", 0, "quanto aguardar se outra instância estiver executando o job (padrão: ETL_LOCK_ESPERA ou 0, encerra sem executar)")
+}
+
+// novaTrava cria o serviço de travas distribuídas com a configuração do ambiente.
+// Deve ser chamada após o carregamento do .env.
+func novaTrava(conn database.MongoConnection, espera time.Duration) trava.Trava {
+	cfg := trava.ConfigPadrao()
+	if espera > 0 {
+		cfg.Espera = espera
+	}
+	return trava.NewTrava(travarepository.NewDataTravaRepository(conn), cfg)
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+
+	"etl-service/src/config/logger"
+	getdata "etl-service/src/exec/get_data"
+	familiarepository "etl-service/src/exec/repository/familia_repository"
+	finalrepository "etl-service/src/exec/repository/final_repository"
+	inicialrepository "etl-service/src/exec/repository/inicial_repository"
+)
+
+// validateCmd lê o banco inicial e executa apenas a transformação dos membros,
+// listando os registros que falhariam na conversão e os avisos de qualidade. Nada é escrito no banco final.
+func validateCmd(args []string) int {
+	fs := flag.NewFlagSet("validate", flag.ContinueOnError)
+	pf := registrarFlagsPipeline(fs)
+	tf := registrarFlagsTracing(fs)
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	conn, fechar, err := conectar()
+	if err != nil {
+		slog.Error("falha ao preparar a validação", logger.Erro(err))
+		return ExitErroFatal
+	}
+	defer fechar()
+
+	encerrarTracing, err := tf.iniciar()
+	if err != nil {
+		slog.Error("falha ao configurar o tracing", logger.Erro(err))
+		return ExitErroFatal
+	}
+	defer encerrarTracing()
+
+	cfg, err := pf.config()
+	if err != nil {
+		slog.Error("falha ao configurar o pipeline", logger.Erro(err))
+		return ExitErroFatal
+	}
+
+	ctx, cancel := contextoInterrompivel()
+	defer cancel()
+	ctx = logger.ComRunID(ctx, logger.NovoRunID())
+
+	service := getdata.NewGetDataBancoInicial(
+		inicialrepository.NewDataInicialRepository(conn),
+		finalrepository.NewDataFinalRepository(conn),
+		familiarepository.NewDataFamiliaRepository(conn),
+		cfg,
+	)
+
+	relatorio, err := service.Validate(ctx)
+	if err != nil {
+		logger.DoContexto(ctx).Error("erro ao validar membros", logger.Erro(err))
+		return ExitErroFatal
+	}
+
+	for _, linha := range relatorio.ErrosTransformacao {
+		fmt.Println(linha)
+	}
+	for _, linha := range relatorio.Avisos {
+		fmt.Println("aviso: " + linha)
+	}
+	fmt.Printf("Membros validados: %d, inválidos: %d, avisos: %d (%s)\n", relatorio.Total, relatorio.Falhas(), len(relatorio.Avisos), relatorio.Duracao)
+	for _, codigo := range slices.Sorted(maps.Keys(relatorio.AvisosPorCodigo)) {
+		fmt.Printf("  %s: %d\n", codigo, relatorio.AvisosPorCodigo[codigo])
+	}
+
+	if relatorio.Parcial() {
+		return ExitFalhaParcial
+	}
+	return ExitSucesso
+}
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+)
+
+// Informações de build, preenchidas via -ldflags no momento da compilação, por exemplo:
+//
+//	go build -ldflags "-X etl-service/src/cli.Version=1.2.0 -X etl-service/src/cli.Commit=abc123"
+var (
+	Version   = "dev"
+	Commit    = ""
+	BuildDate = ""
+)
+
+// versionCmd imprime a versão, o commit e a data de build da aplicação.
+// Quando o commit não foi informado via ldflags, utiliza as informações de VCS embutidas pelo Go.
+func versionCmd(args []string) int {
+	fs := flag.NewFlagSet("version", flag.ContinueOnError)
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	commit, data := Commit, BuildDate
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, s := range info.Settings {
+			switch {
+			case s.Key == "vcs.revision" && commit == "":
+				commit = s.Value
+			case s.Key == "vcs.time" && data == "":
+				data = s.Value
+			}
+		}
+	}
+	if commit == "" {
+		commit = "desconhecido"
+	}
+	if data == "" {
+		data = "desconhecida"
+	}
+
+	fmt.Printf("etl-service %s\n", Version)
+	fmt.Printf("  commit:     %s\n", commit)
+	fmt.Printf("  build:      %s\n", data)
+	fmt.Printf("  go:         %s %s/%s\n", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	return ExitSucesso
+}
@@ -17,10 +17,18 @@ type MongoConnection interface {
 	// Permite realizar operações de leitura e escrita nesta coleção.
 	Collection(dbName, collectionName string) *mongo.Collection
 
+	// Database retorna uma referência para o banco especificado,
+	// útil para comandos administrativos (ex: connectionStatus, collMod).
+	Database(dbName string) *mongo.Database
+
 	// ContextWithTimeout cria e retorna um contexto com timeout predefinido (ex: 15 segundos),
 	// útil para limitar o tempo de execução de operações que acessam o banco.
 	ContextWithTimeout() (context.Context, context.CancelFunc)
 
+	// ContextWithTimeoutFrom cria um contexto derivado de parent com o mesmo timeout predefinido,
+	// preservando cancelamento e valores do contexto da execução (ex: cancelamento do pipeline).
+	ContextWithTimeoutFrom(parent context.Context) (context.Context, context.CancelFunc)
+
 	// Disconnect encerra a conexão com o MongoDB utilizando o contexto informado.
 	// Retorna erro caso ocorra falha durante a desconexão.
 	Disconnect(ctx context.Context) error
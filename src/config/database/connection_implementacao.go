@@ -3,8 +3,10 @@ package database
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"time"
 
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -12,12 +14,14 @@ import (
 // mongoConnectionImpl é a implementação concreta da interface MongoConnection,
 // responsável por gerenciar a conexão com o banco MongoDB.
 type mongoConnectionImpl struct {
-	client *mongo.Client
+	client    *mongo.Client
+	monitores []*event.CommandMonitor // Monitores de comandos (métricas, tracing) registrados no cliente
 }
 
 // NewMongoConnection cria uma nova instância da implementação de MongoConnection.
-func NewMongoConnection() MongoConnection {
-	return &mongoConnectionImpl{}
+// Os monitores informados recebem os eventos de todos os comandos enviados ao banco.
+func NewMongoConnection(monitores ...*event.CommandMonitor) MongoConnection {
+	return &mongoConnectionImpl{monitores: monitores}
 }
 
 // Connect estabelece conexão com o MongoDB utilizando a URI fornecida.
@@ -25,6 +29,9 @@ func NewMongoConnection() MongoConnection {
 // Retorna erro caso a conexão falhe.
 func (m *mongoConnectionImpl) Connect(uri string) error {
 	clientOptions := options.Client().ApplyURI(uri)
+	if len(m.monitores) > 0 {
+		clientOptions.SetMonitor(combinarMonitores(m.monitores))
+	}
 
 	// Cria um contexto com timeout de 10 segundos para limitar o tempo de conexão
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -41,7 +48,7 @@ func (m *mongoConnectionImpl) Connect(uri string) error {
 		return fmt.Errorf("erro ao pingar o banco: %w", err)
 	}
 
-	fmt.Println("✅ Conectado ao MongoDB com sucesso!")
+	slog.Info("conectado ao MongoDB")
 	m.client = client
 	return nil
 }
@@ -52,10 +59,20 @@ func (m *mongoConnectionImpl) Collection(dbName, collectionName string) *mongo.C
 	return m.client.Database(dbName).Collection(collectionName)
 }
 
+// Database retorna uma referência para o banco especificado.
+func (m *mongoConnectionImpl) Database(dbName string) *mongo.Database {
+	return m.client.Database(dbName)
+}
+
 // ContextWithTimeout retorna um contexto com timeout de 15 segundos,
 // usado para operações que precisam ser canceladas se demorarem muito.
 func (m *mongoConnectionImpl) ContextWithTimeout() (context.Context, context.CancelFunc) {
-	return context.WithTimeout(context.Background(), 15*time.Second)
+	return m.ContextWithTimeoutFrom(context.Background())
+}
+
+// ContextWithTimeoutFrom retorna um contexto derivado de parent com timeout de 15 segundos.
+func (m *mongoConnectionImpl) ContextWithTimeoutFrom(parent context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(parent, 15*time.Second)
 }
 
 // Disconnect encerra a conexão com o MongoDB utilizando o contexto fornecido.
@@ -63,3 +80,31 @@ func (m *mongoConnectionImpl) ContextWithTimeout() (context.Context, context.Can
 func (m *mongoConnectionImpl) Disconnect(ctx context.Context) error {
 	return m.client.Disconnect(ctx)
 }
+
+// combinarMonitores cria um único monitor que repassa cada evento para todos os monitores informados,
+// já que o driver aceita apenas um CommandMonitor por cliente.
+func combinarMonitores(monitores []*event.CommandMonitor) *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, e *event.CommandStartedEvent) {
+			for _, mon := range monitores {
+				if mon.Started != nil {
+					mon.Started(ctx, e)
+				}
+			}
+		},
+		Succeeded: func(ctx context.Context, e *event.CommandSucceededEvent) {
+			for _, mon := range monitores {
+				if mon.Succeeded != nil {
+					mon.Succeeded(ctx, e)
+				}
+			}
+		},
+		Failed: func(ctx context.Context, e *event.CommandFailedEvent) {
+			for _, mon := range monitores {
+				if mon.Failed != nil {
+					mon.Failed(ctx, e)
+				}
+			}
+		},
+	}
+}
@@ -0,0 +1,215 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Categoria classifica um erro de escrita no MongoDB de acordo com a ação esperada.
+type Categoria string
+
+const (
+	CategoriaDuplicado    Categoria = "duplicado"   // Violação de índice único (E11000)
+	CategoriaValidacao    Categoria = "validacao"   // Documento rejeitado pelo $jsonSchema (código 121)
+	CategoriaTimeout      Categoria = "timeout"     // Tempo limite excedido no cliente ou no servidor
+	CategoriaTransitorio  Categoria = "transitorio" // Falha de rede, troca de primário ou erro marcado como repetível
+	CategoriaDesconhecido Categoria = "desconhecido"
+)
+
+// codigoValidacao é o código retornado pelo servidor quando o documento falha na validação do schema.
+const codigoValidacao = 121
+
+// codigosTransitorios lista códigos de erro do servidor que indicam falhas temporárias,
+// como eleição de novo primário ou desligamento do nó.
+var codigosTransitorios = map[int]bool{
+	6:     true, // HostUnreachable
+	7:     true, // HostNotFound
+	89:    true, // NetworkTimeout
+	91:    true, // ShutdownInProgress
+	189:   true, // PrimarySteppedDown
+	262:   true, // ExceededTimeLimit
+	9001:  true, // SocketException
+	10107: true, // NotWritablePrimary
+	11600: true, // InterruptedAtShutdown
+	11602: true, // InterruptedDueToReplStateChange
+	13435: true, // NotPrimaryNoSecondaryOk
+	13436: true, // NotPrimaryOrSecondary
+}
+
+// ErroEscrita é um erro de escrita já classificado, contendo a categoria, o código do servidor
+// (quando houver) e o motivo decodificado (ex: regras do $jsonSchema não satisfeitas).
+type ErroEscrita struct {
+	Categoria Categoria
+	Codigo    int
+	Motivo    string
+	Err       error
+}
+
+// Error implementa a interface error.
+func (e *ErroEscrita) Error() string {
+	return fmt.Sprintf("[%s] %s", e.Categoria, e.Motivo)
+}
+
+// Unwrap retorna o erro original do driver.
+func (e *ErroEscrita) Unwrap() error {
+	return e.Err
+}
+
+// Repetivel indica se vale a pena tentar a operação novamente.
+func (e *ErroEscrita) Repetivel() bool {
+	return e.Categoria == CategoriaTransitorio || e.Categoria == CategoriaTimeout
+}
+
+// ClassificarErro analisa um erro retornado pelo driver do MongoDB e o classifica.
+// Retorna nil quando err é nil.
+//
+// A ordem de verificação é: chave duplicada, validação de documento, timeout,
+// falhas transitórias (rede, labels RetryableWriteError/TransientTransactionError, códigos de eleição)
+// e, por fim, desconhecido.
+func ClassificarErro(err error) *ErroEscrita {
+	if err == nil {
+		return nil
+	}
+
+	var classificado *ErroEscrita
+	if errors.As(err, &classificado) {
+		return classificado
+	}
+
+	e := &ErroEscrita{Categoria: CategoriaDesconhecido, Motivo: err.Error(), Err: err}
+
+	var we mongo.WriteException
+	if errors.As(err, &we) {
+		for _, w := range we.WriteErrors {
+			e.Codigo = w.Code
+			if w.Code == codigoValidacao {
+				e.Categoria = CategoriaValidacao
+				e.Motivo = motivoValidacao(w.Message, w.Details)
+				return e
+			}
+		}
+		if we.WriteConcernError != nil && e.Codigo == 0 {
+			e.Codigo = we.WriteConcernError.Code
+		}
+	}
+
+	var se mongo.ServerError
+	switch {
+	case mongo.IsDuplicateKeyError(err):
+		e.Categoria = CategoriaDuplicado
+		e.Motivo = motivoDuplicado(err)
+	case mongo.IsTimeout(err) || errors.Is(err, context.DeadlineExceeded):
+		e.Categoria = CategoriaTimeout
+	case mongo.IsNetworkError(err):
+		e.Categoria = CategoriaTransitorio
+	case errors.As(err, &se):
+		if se.HasErrorLabel("RetryableWriteError") || se.HasErrorLabel("TransientTransactionError") {
+			e.Categoria = CategoriaTransitorio
+			break
+		}
+		for codigo := range codigosTransitorios {
+			if se.HasErrorCode(codigo) {
+				e.Categoria = CategoriaTransitorio
+				e.Codigo = codigo
+				break
+			}
+		}
+	}
+
+	return e
+}
+
+// motivoDuplicado extrai a parte relevante da mensagem E11000 (índice e chave duplicada).
+func motivoDuplicado(err error) string {
+	msg := err.Error()
+	if i := strings.Index(msg, "index:"); i >= 0 {
+		// Remove o fechamento "]" adicionado pelo driver ao agrupar os WriteErrors
+		return "chave duplicada " + strings.TrimSuffix(msg[i:], "]")
+	}
+	return msg
+}
+
+// motivoValidacao decodifica o errInfo de uma falha de validação (código 121),
+// listando as regras do $jsonSchema que não foram satisfeitas.
+func motivoValidacao(mensagem string, details bson.Raw) string {
+	if len(details) == 0 {
+		return mensagem
+	}
+
+	var info struct {
+		Details struct {
+			Regras []bson.Raw `bson:"schemaRulesNotSatisfied"`
+		} `bson:"details"`
+	}
+	if err := bson.Unmarshal(details, &info); err != nil || len(info.Details.Regras) == 0 {
+		return fmt.Sprintf("%s: %s", mensagem, details.String())
+	}
+
+	var motivos []string
+	for _, regra := range info.Details.Regras {
+		motivos = append(motivos, descreverRegras("", regra)...)
+	}
+	return "documento inválido: " + strings.Join(motivos, "; ")
+}
+
+// descreverRegras percorre recursivamente uma regra não satisfeita do errInfo,
+// descendo por "propertiesNotSatisfied" até chegar às regras de cada campo.
+func descreverRegras(caminho string, regra bson.Raw) []string {
+	var r struct {
+		Operador      string        `bson:"operatorName"`
+		Motivo        string        `bson:"reason"`
+		Faltando      []string      `bson:"missingProperties"`
+		ValorAvaliado bson.RawValue `bson:"consideredValue"`
+		TipoAvaliado  string        `bson:"consideredType"`
+		Propriedades  []struct {
+			Nome     string     `bson:"propertyName"`
+			Detalhes []bson.Raw `bson:"details"`
+		} `bson:"propertiesNotSatisfied"`
+	}
+	if err := bson.Unmarshal(regra, &r); err != nil {
+		return []string{regra.String()}
+	}
+
+	prefixo := ""
+	if caminho != "" {
+		prefixo = caminho + ": "
+	}
+
+	switch {
+	case len(r.Faltando) > 0:
+		return []string{fmt.Sprintf("%scampos obrigatórios ausentes %v", prefixo, r.Faltando)}
+	case len(r.Propriedades) > 0:
+		var motivos []string
+		for _, p := range r.Propriedades {
+			for _, d := range p.Detalhes {
+				motivos = append(motivos, descreverRegras(juntarCaminho(caminho, p.Nome), d)...)
+			}
+		}
+		return motivos
+	}
+
+	desc := fmt.Sprintf("%s%s", prefixo, r.Operador)
+	if r.Motivo != "" {
+		desc += " (" + r.Motivo + ")"
+	}
+	if r.ValorAvaliado.Type != 0 {
+		desc += " valor=" + r.ValorAvaliado.String()
+	}
+	if r.TipoAvaliado != "" {
+		desc += " tipo=" + r.TipoAvaliado
+	}
+	return []string{desc}
+}
+
+// juntarCaminho monta o caminho pontuado do campo (ex: endereco.cep).
+func juntarCaminho(caminho, campo string) string {
+	if caminho == "" {
+		return campo
+	}
+	return caminho + "." + campo
+}
@@ -0,0 +1,106 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestClassificarErro(t *testing.T) {
+	duplicado := mongo.WriteException{WriteErrors: mongo.WriteErrors{{
+		Code:    11000,
+		Message: `E11000 duplicate key error collection: igreja.membros index: name_1 dup key: { name: "JOÃO DA SILVA" }`,
+	}}}
+
+	casos := []struct {
+		nome      string
+		err       error
+		categoria Categoria
+		codigo    int
+		repetivel bool
+		motivo    string
+	}{
+		{"chave duplicada", duplicado, CategoriaDuplicado, 11000, false, `chave duplicada index: name_1 dup key: { name: "JOÃO DA SILVA" }`},
+		{"chave duplicada embrulhada", fmt.Errorf("erro ao inserir membro: %w", duplicado), CategoriaDuplicado, 11000, false, "chave duplicada index: name_1"},
+		{"validação sem errInfo", mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: 121, Message: "Document failed validation"}}}, CategoriaValidacao, 121, false, "Document failed validation"},
+		{"timeout do contexto", fmt.Errorf("erro ao inserir membro: %w", context.DeadlineExceeded), CategoriaTimeout, 0, true, ""},
+		{"timeout do servidor", mongo.CommandError{Code: 50, Name: "MaxTimeMSExpired", Message: "operation exceeded time limit"}, CategoriaTimeout, 0, true, ""},
+		{"label RetryableWriteError", mongo.CommandError{Code: 9999, Labels: []string{"RetryableWriteError"}}, CategoriaTransitorio, 0, true, ""},
+		{"label TransientTransactionError", mongo.CommandError{Code: 9999, Labels: []string{"TransientTransactionError"}}, CategoriaTransitorio, 0, true, ""},
+		{"erro de rede", mongo.CommandError{Labels: []string{"NetworkError"}, Message: "connection reset"}, CategoriaTransitorio, 0, true, ""},
+		{"troca de primário", mongo.CommandError{Code: 10107, Name: "NotWritablePrimary"}, CategoriaTransitorio, 10107, true, ""},
+		{"troca de primário na escrita", mongo.WriteException{WriteConcernError: &mongo.WriteConcernError{Code: 189, Name: "PrimarySteppedDown"}}, CategoriaTransitorio, 189, true, ""},
+		{"desconhecido", errors.New("falha inesperada"), CategoriaDesconhecido, 0, false, "falha inesperada"},
+	}
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			obtido := ClassificarErro(c.err)
+			if obtido.Categoria != c.categoria {
+				t.Errorf("categoria = %q, esperado %q", obtido.Categoria, c.categoria)
+			}
+			if obtido.Codigo != c.codigo {
+				t.Errorf("código = %d, esperado %d", obtido.Codigo, c.codigo)
+			}
+			if obtido.Repetivel() != c.repetivel {
+				t.Errorf("Repetivel() = %v, esperado %v", obtido.Repetivel(), c.repetivel)
+			}
+			if !strings.Contains(obtido.Motivo, c.motivo) {
+				t.Errorf("motivo = %q, esperado conter %q", obtido.Motivo, c.motivo)
+			}
+			if obtido.Unwrap() == nil {
+				t.Error("o erro classificado deve preservar o erro original")
+			}
+		})
+	}
+}
+
+func TestClassificarErroValidacaoComErrInfo(t *testing.T) {
+	regra := func(campo string, detalhes ...bson.M) bson.M {
+		return bson.M{"propertyName": campo, "details": detalhes}
+	}
+	details, err := bson.Marshal(bson.M{
+		"failingDocumentId": "64b7f0c2e4b0a1a2b3c4d5e6",
+		"details": bson.M{
+			"operatorName": "$jsonSchema",
+			"schemaRulesNotSatisfied": bson.A{
+				bson.M{"operatorName": "properties", "propertiesNotSatisfied": bson.A{
+					regra("endereco", bson.M{"operatorName": "properties", "propertiesNotSatisfied": bson.A{
+						regra("cep", bson.M{"operatorName": "pattern", "reason": "regular expression did not match", "consideredValue": "123"}),
+					}}),
+					regra("dataNascimento", bson.M{"operatorName": "bsonType", "specifiedAs": bson.M{"bsonType": "date"}, "reason": "type did not match", "consideredValue": "15/03/1985", "consideredType": "string"}),
+				}},
+				bson.M{"operatorName": "required", "specifiedAs": bson.M{"required": bson.A{"name"}}, "missingProperties": bson.A{"name"}},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	obtido := ClassificarErro(mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: 121, Message: "Document failed validation", Details: details}}})
+	if obtido.Categoria != CategoriaValidacao || obtido.Codigo != 121 {
+		t.Fatalf("ClassificarErro() = %q/%d, esperado %q/121", obtido.Categoria, obtido.Codigo, CategoriaValidacao)
+	}
+	esperado := `documento inválido: endereco.cep: pattern (regular expression did not match) valor="123"; ` +
+		`dataNascimento: bsonType (type did not match) valor="15/03/1985" tipo=string; ` +
+		`campos obrigatórios ausentes [name]`
+	if obtido.Motivo != esperado {
+		t.Errorf("motivo =\n%s\nesperado\n%s", obtido.Motivo, esperado)
+	}
+}
+
+func TestClassificarErroNilEJaClassificado(t *testing.T) {
+	if obtido := ClassificarErro(nil); obtido != nil {
+		t.Errorf("ClassificarErro(nil) = %v, esperado nil", obtido)
+	}
+
+	original := &ErroEscrita{Categoria: CategoriaValidacao, Motivo: "documento inválido"}
+	if obtido := ClassificarErro(fmt.Errorf("lote: %w", original)); obtido != original {
+		t.Errorf("ClassificarErro() = %v, esperado o erro já classificado", obtido)
+	}
+}
@@ -1,7 +1,11 @@
 package env
 
 import (
-	"log"
+	"etl-service/src/config/logger"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -17,6 +21,55 @@ import (
 func LoadEnv() {
 	err := godotenv.Load()
 	if err != nil {
-		log.Fatal("Erro ao carregar o arquivo .env")
+		logger.Fatal("erro ao carregar o arquivo .env", logger.Erro(err))
 	}
 }
+
+// GetString retorna o valor da variável de ambiente informada,
+// ou o valor padrão caso ela não esteja definida.
+func GetString(key, padrao string) string {
+	if v := strings.TrimSpace(os.Getenv(key)); v != "" {
+		return v
+	}
+	return padrao
+}
+
+// GetInt retorna a variável de ambiente convertida para inteiro.
+// Valores ausentes ou inválidos resultam no valor padrão.
+func GetInt(key string, padrao int) int {
+	v, err := strconv.Atoi(strings.TrimSpace(os.Getenv(key)))
+	if err != nil {
+		return padrao
+	}
+	return v
+}
+
+// GetFloat retorna a variável de ambiente convertida para float64.
+// Valores ausentes ou inválidos resultam no valor padrão.
+func GetFloat(key string, padrao float64) float64 {
+	v, err := strconv.ParseFloat(strings.TrimSpace(os.Getenv(key)), 64)
+	if err != nil {
+		return padrao
+	}
+	return v
+}
+
+// GetBool retorna a variável de ambiente convertida para bool (aceita os formatos de strconv.ParseBool).
+// Valores ausentes ou inválidos resultam no valor padrão.
+func GetBool(key string, padrao bool) bool {
+	v, err := strconv.ParseBool(strings.TrimSpace(os.Getenv(key)))
+	if err != nil {
+		return padrao
+	}
+	return v
+}
+
+// GetDuration retorna a variável de ambiente convertida para time.Duration (ex: "500ms", "2s").
+// Valores ausentes ou inválidos resultam no valor padrão.
+func GetDuration(key string, padrao time.Duration) time.Duration {
+	v, err := time.ParseDuration(strings.TrimSpace(os.Getenv(key)))
+	if err != nil {
+		return padrao
+	}
+	return v
+}
@@ -0,0 +1,133 @@
+package jobs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// parserCron interpreta expressões cron de 5 campos (minuto hora dia mês dia-da-semana),
+// descritores como @daily e @every 1h, e o prefixo CRON_TZ=America/Sao_Paulo.
+var parserCron = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+// Job é uma configuração nomeada de execução do ETL, disparada pela API de controle
+// ou, quando possui expressão cron, pelo agendador do daemon.
+type Job struct {
+	Nome        string `json:"nome"`                // Identificador do job (ex: completo, incremental)
+	Descricao   string `json:"descricao,omitempty"` // Texto livre exibido na listagem de jobs
+	Incremental bool   `json:"incremental"`         // Lê apenas membros modificados desde a última execução concluída do job
+	DryRun      bool   `json:"dryRun"`              // Executa sem gravar no banco final
+
+	// Agendamento (usado pelo daemon)
+	Cron            string  `json:"cron,omitempty"`   // Expressão cron; vazio deixa o job apenas sob demanda
+	Jitter          Duracao `json:"jitter,omitempty"` // Atraso aleatório máximo antes de cada disparo agendado (ex: "5m")
+	RecuperarAtraso bool    `json:"recuperarAtraso"`  // Executa uma vez ao iniciar se um disparo foi perdido com o daemon parado
+}
+
+// Duracao é um time.Duration representado em JSON como texto ("30s", "5m").
+type Duracao time.Duration
+
+// UnmarshalJSON interpreta a duração no formato de time.ParseDuration.
+func (d *Duracao) UnmarshalJSON(b []byte) error {
+	var texto string
+	if err := json.Unmarshal(b, &texto); err != nil {
+		return fmt.Errorf("duração deve ser um texto como \"5m\": %w", err)
+	}
+	v, err := time.ParseDuration(texto)
+	if err != nil {
+		return err
+	}
+	*d = Duracao(v)
+	return nil
+}
+
+// MarshalJSON serializa a duração como texto.
+func (d Duracao) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// Agendado indica se o job possui expressão cron.
+func (j Job) Agendado() bool {
+	return strings.TrimSpace(j.Cron) != ""
+}
+
+// Agenda interpreta a expressão cron do job.
+func (j Job) Agenda() (cron.Schedule, error) {
+	agenda, err := parserCron.Parse(j.Cron)
+	if err != nil {
+		return nil, fmt.Errorf("expressão cron inválida no job '%s': %w", j.Nome, err)
+	}
+	return agenda, nil
+}
+
+// Padrao retorna os jobs disponíveis quando nenhum arquivo de jobs é configurado.
+func Padrao() []Job {
+	return []Job{
+		{Nome: "completo", Descricao: "lê todo o banco inicial e insere os membros novos no banco final"},
+		{Nome: "incremental", Descricao: "lê apenas os membros modificados desde a última execução concluída", Incremental: true},
+	}
+}
+
+// Carregar lê os jobs de um arquivo JSON contendo uma lista de Job.
+// Quando caminho é vazio, retorna os jobs padrão.
+func Carregar(caminho string) ([]Job, error) {
+	if caminho == "" {
+		return Padrao(), nil
+	}
+
+	conteudo, err := os.ReadFile(caminho)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler arquivo de jobs '%s': %w", caminho, err)
+	}
+
+	var lista []Job
+	if err := json.Unmarshal(conteudo, &lista); err != nil {
+		return nil, fmt.Errorf("erro ao interpretar arquivo de jobs '%s': %w", caminho, err)
+	}
+	if err := validar(lista); err != nil {
+		return nil, fmt.Errorf("arquivo de jobs '%s' inválido: %w", caminho, err)
+	}
+	return lista, nil
+}
+
+// Buscar retorna o job com o nome informado.
+func Buscar(lista []Job, nome string) (Job, bool) {
+	for _, j := range lista {
+		if j.Nome == nome {
+			return j, true
+		}
+	}
+	return Job{}, false
+}
+
+// validar garante que todos os jobs têm nome, que os nomes não se repetem
+// e que as expressões cron são válidas.
+func validar(lista []Job) error {
+	if len(lista) == 0 {
+		return errors.New("nenhum job definido")
+	}
+	vistos := make(map[string]bool)
+	for i, j := range lista {
+		if strings.TrimSpace(j.Nome) == "" {
+			return fmt.Errorf("job na posição %d sem nome", i)
+		}
+		if vistos[j.Nome] {
+			return fmt.Errorf("job '%s' definido mais de uma vez", j.Nome)
+		}
+		vistos[j.Nome] = true
+		if j.Agendado() {
+			if _, err := j.Agenda(); err != nil {
+				return err
+			}
+		}
+		if j.Jitter < 0 {
+			return fmt.Errorf("jitter negativo no job '%s'", j.Nome)
+		}
+	}
+	return nil
+}
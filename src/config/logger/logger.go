@@ -0,0 +1,106 @@
+package logger
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// Chaves dos atributos padronizados, presentes em todas as linhas relacionadas a uma execução.
+const (
+	ChaveRunID     = "run_id"         // Identificador da execução do ETL
+	ChaveEtapa     = "stage"          // Etapa do pipeline (extracao, transformacao, deduplicacao, carga)
+	ChaveMembro    = "member_key"     // Chave de identidade do membro (name)
+	ChaveCategoria = "error_category" // Categoria do erro (ex: duplicado, validacao, timeout)
+	ChaveErro      = "error"          // Mensagem do erro
+)
+
+// Config define o nível e o formato dos logs.
+type Config struct {
+	Nivel   string    // debug, info, warn ou error (padrão info)
+	Formato string    // json ou text (padrão text)
+	Saida   io.Writer // Destino dos logs (padrão os.Stderr)
+}
+
+// Configurar cria o logger slog com redação automática de dados sensíveis
+// e o define como logger padrão da aplicação (slog.Default e pacote log).
+func Configurar(cfg Config) (*slog.Logger, error) {
+	var nivel slog.Level
+	if cfg.Nivel != "" {
+		if err := nivel.UnmarshalText([]byte(cfg.Nivel)); err != nil {
+			return nil, fmt.Errorf("nível de log inválido %q: %w", cfg.Nivel, err)
+		}
+	}
+
+	saida := cfg.Saida
+	if saida == nil {
+		saida = os.Stderr
+	}
+
+	opts := &slog.HandlerOptions{Level: nivel, ReplaceAttr: redigirAtributo}
+
+	var handler slog.Handler
+	switch strings.ToLower(cfg.Formato) {
+	case "", "text":
+		handler = slog.NewTextHandler(saida, opts)
+	case "json":
+		handler = slog.NewJSONHandler(saida, opts)
+	default:
+		return nil, fmt.Errorf("formato de log inválido %q (use json ou text)", cfg.Formato)
+	}
+
+	l := slog.New(handler)
+	slog.SetDefault(l)
+	return l, nil
+}
+
+// Fatal registra a mensagem no nível error e encerra a aplicação com código 1.
+// Substitui log.Fatal em erros de configuração que impedem a execução.
+func Fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
+
+// Erro retorna o atributo padronizado para um erro.
+func Erro(err error) slog.Attr {
+	if err == nil {
+		return slog.String(ChaveErro, "")
+	}
+	return slog.String(ChaveErro, err.Error())
+}
+
+// NovoRunID gera um identificador aleatório para a execução.
+func NovoRunID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "desconhecido"
+	}
+	return hex.EncodeToString(b)
+}
+
+// ComRunID retorna um contexto cujo logger inclui o run_id informado em todas as linhas.
+func ComRunID(ctx context.Context, runID string) context.Context {
+	return ComLogger(ctx, DoContexto(ctx).With(ChaveRunID, runID))
+}
+
+// chaveContexto é a chave usada para guardar o logger no contexto.
+type chaveContexto struct{}
+
+// ComLogger retorna um contexto carregando o logger informado.
+func ComLogger(ctx context.Context, l *slog.Logger) context.Context {
+	return context.WithValue(ctx, chaveContexto{}, l)
+}
+
+// DoContexto retorna o logger guardado no contexto (com os atributos da execução, como run_id),
+// ou o logger padrão quando não houver.
+func DoContexto(ctx context.Context) *slog.Logger {
+	if l, ok := ctx.Value(chaveContexto{}).(*slog.Logger); ok {
+		return l
+	}
+	return slog.Default()
+}
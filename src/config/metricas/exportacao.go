@@ -0,0 +1,51 @@
+package metricas
+
+import (
+	"errors"
+	"etl-service/src/config/logger"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/client_golang/prometheus/push"
+)
+
+// Servir inicia um servidor HTTP em addr expondo o endpoint /metrics.
+// O servidor roda em segundo plano; use Shutdown/Close no retorno para encerrá-lo.
+func Servir(addr string) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler())
+
+	srv := &http.Server{Addr: addr, Handler: mux}
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("erro no servidor de métricas", "addr", addr, logger.Erro(err))
+		}
+	}()
+	return srv
+}
+
+// Handler retorna o handler HTTP do endpoint de métricas, permitindo montá-lo em outro servidor.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registro, promhttp.HandlerOpts{Registry: Registro})
+}
+
+// Enviar publica as métricas em um Pushgateway (ou endpoint compatível) no agrupamento do job informado.
+// Utilizado no modo batch, em que o processo termina antes de ser coletado pelo Prometheus.
+func Enviar(url, job string) error {
+	if err := push.New(url, job).Gatherer(Registro).Push(); err != nil {
+		return fmt.Errorf("erro ao enviar métricas para '%s': %w", url, err)
+	}
+	return nil
+}
+
+// GravarArquivo grava as métricas no formato texto do Prometheus, para o textfile collector
+// do node_exporter. A escrita é atômica (arquivo temporário seguido de rename).
+func GravarArquivo(caminho string) error {
+	if err := prometheus.WriteToTextfile(caminho, Registro); err != nil {
+		return fmt.Errorf("erro ao gravar métricas em '%s': %w", caminho, err)
+	}
+	return nil
+}
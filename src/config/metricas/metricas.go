@@ -0,0 +1,117 @@
+package metricas
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+// namespace é o prefixo de todas as métricas expostas pelo serviço.
+const namespace = "etl"
+
+// Registro é o registro Prometheus utilizado pelo serviço. Um registro próprio (em vez do global)
+// mantém a saída enxuta e permite gravar o mesmo conteúdo no endpoint, no Pushgateway ou em arquivo.
+var Registro = prometheus.NewRegistry()
+
+var (
+	// Extraidos conta os membros lidos do banco inicial.
+	Extraidos = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace, Name: "membros_extraidos_total",
+		Help: "Membros lidos do banco inicial.",
+	})
+
+	// Transformados conta os membros convertidos com sucesso para o modelo final.
+	Transformados = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace, Name: "membros_transformados_total",
+		Help: "Membros convertidos com sucesso para o modelo final.",
+	})
+
+	// Inseridos conta os membros gravados no banco final.
+	Inseridos = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace, Name: "membros_inseridos_total",
+		Help: "Membros inseridos no banco final.",
+	})
+
+	// Atualizados conta os membros existentes cujo conteúdo mudou e foram regravados no banco final.
+	Atualizados = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace, Name: "membros_atualizados_total",
+		Help: "Membros existentes com conteúdo alterado, atualizados no banco final.",
+	})
+
+	// Inalterados conta os membros existentes com o mesmo hash de conteúdo, que não geraram escrita.
+	Inalterados = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace, Name: "membros_inalterados_total",
+		Help: "Membros existentes sem alteração de conteúdo (nenhuma escrita).",
+	})
+
+	// Duplicados conta os membros descartados por se repetirem na execução.
+	Duplicados = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace, Name: "membros_duplicados_total",
+		Help: "Membros descartados por se repetirem na execução.",
+	})
+
+	// Falhas conta os membros que falharam, por etapa e motivo (categoria do erro).
+	Falhas = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace, Name: "membros_falhas_total",
+		Help: "Membros que falharam, por etapa e motivo.",
+	}, []string{"etapa", "motivo"})
+
+	// Avisos conta os avisos de qualidade dos dados, por código e severidade.
+	Avisos = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace, Name: "avisos_qualidade_total",
+		Help: "Avisos de qualidade dos dados, por código e severidade.",
+	}, []string{"codigo", "severidade"})
+
+	// LatenciaEtapa mede o tempo de processamento de cada item em cada etapa do pipeline.
+	LatenciaEtapa = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace, Name: "etapa_item_duracao_segundos",
+		Help:    "Tempo de processamento de um item em cada etapa do pipeline.",
+		Buckets: prometheus.ExponentialBuckets(0.0001, 4, 10),
+	}, []string{"etapa"})
+
+	// DuracaoEtapa registra a duração total de cada etapa na última execução.
+	DuracaoEtapa = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace, Name: "etapa_duracao_segundos",
+		Help: "Duração total de cada etapa na última execução.",
+	}, []string{"etapa"})
+
+	// LatenciaMongo mede a latência dos comandos enviados ao MongoDB, por comando e resultado.
+	LatenciaMongo = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace, Name: "mongo_comando_duracao_segundos",
+		Help:    "Latência dos comandos enviados ao MongoDB.",
+		Buckets: prometheus.ExponentialBuckets(0.0005, 3, 10),
+	}, []string{"comando", "resultado"})
+
+	// WorkersAtivos indica quantos workers de cada etapa estão em execução.
+	WorkersAtivos = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace, Name: "workers_ativos",
+		Help: "Workers em execução por etapa do pipeline.",
+	}, []string{"etapa"})
+
+	// LimiteCarga indica o limite de concorrência atual da etapa de carga.
+	LimiteCarga = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace, Name: "carga_limite_concorrencia",
+		Help: "Limite de concorrência atual da etapa de carga.",
+	})
+
+	// UltimaExecucaoSucesso registra o horário (unix) da última execução concluída sem falhas.
+	UltimaExecucaoSucesso = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace, Name: "ultima_execucao_sucesso_timestamp_segundos",
+		Help: "Horário (unix) da última execução concluída sem falhas.",
+	})
+
+	// DuracaoExecucao registra a duração da última execução.
+	DuracaoExecucao = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace, Name: "execucao_duracao_segundos",
+		Help: "Duração da última execução.",
+	})
+)
+
+func init() {
+	Registro.MustRegister(
+		Extraidos, Transformados, Inseridos, Atualizados, Inalterados, Duplicados, Falhas, Avisos,
+		LatenciaEtapa, DuracaoEtapa, LatenciaMongo, WorkersAtivos, LimiteCarga,
+		UltimaExecucaoSucesso, DuracaoExecucao,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
@@ -0,0 +1,20 @@
+package metricas
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// MonitorMongo retorna um monitor de comandos do driver que registra a latência
+// de cada comando enviado ao MongoDB em LatenciaMongo.
+func MonitorMongo() *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Succeeded: func(_ context.Context, e *event.CommandSucceededEvent) {
+			LatenciaMongo.WithLabelValues(e.CommandName, "sucesso").Observe(e.Duration.Seconds())
+		},
+		Failed: func(_ context.Context, e *event.CommandFailedEvent) {
+			LatenciaMongo.WithLabelValues(e.CommandName, "falha").Observe(e.Duration.Seconds())
+		},
+	}
+}
@@ -1,15 +1,24 @@
 package bancofinal
 
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
 // Endereco representa os dados de endereço de um membro,
 // contendo informações como CEP, rua, número, bairro e complemento.
 //
-// O campo Complemento é opcional e pode estar ausente na estrutura BSON.
+// Os campos Complemento, Cidade e Uf são opcionais e podem estar ausentes na estrutura BSON;
+// Cidade e Uf são preenchidos pela base de CEPs, quando configurada.
 type Endereco struct {
-	Cep         string `bson:"cep"`                   // Código postal
+	Cep         string `bson:"cep"`                   // Código postal (8 dígitos, sem máscara)
 	Rua         string `bson:"rua"`                   // Nome da rua
 	Numero      string `bson:"numero"`                // Número da residência
 	Bairro      string `bson:"bairro"`                // Nome do bairro
 	Complemento string `bson:"complemento,omitempty"` // Complemento do endereço (opcional)
+	Cidade      string `bson:"cidade,omitempty"`      // Cidade do CEP (opcional)
+	Uf          string `bson:"uf,omitempty"`          // Sigla do estado do CEP (opcional)
 }
 
 // Membro representa as informações pessoais e de status de um membro da igreja.
@@ -17,21 +26,32 @@ type Endereco struct {
 // Os campos possuem tags BSON para mapear corretamente ao banco MongoDB.
 //
 // Alguns campos são opcionais (como DataCasamento e NomeConjuge) e podem estar ausentes.
+//
+// As datas são gravadas como datas BSON; as que não têm hora no banco inicial
+// correspondem à meia-noite no fuso America/Sao_Paulo.
 type Membro struct {
-	Name            string   `bson:"name"`                    // Nome completo do membro
-	DataNascimento  string   `bson:"dataNascimento"`          // Data de nascimento (formato string)
-	AnoBatismo      int      `bson:"anoBatismo"`              // Ano em que foi batizado
-	Sexo            string   `bson:"sexo"`                    // Sexo do membro
-	EstadoCivil     string   `bson:"estadoCivil"`             // Estado civil atual
-	DataCasamento   string   `bson:"dataCasamento,omitempty"` // Data do casamento (opcional)
-	NomeConjuge     string   `bson:"nomeConjuge,omitempty"`   // Nome do cônjuge (opcional)
-	Filho           bool     `bson:"filho"`                   // Indica se possui filhos
-	Email           string   `bson:"email"`                   // E-mail de contato
-	Telefone        string   `bson:"telefone"`                // Telefone de contato
-	Status          string   `bson:"status"`                  // Status do membro (ativo, inativo, etc)
-	DataStatus      string   `bson:"dataStatus"`              // Data da última alteração de status
-	Validado        bool     `bson:"validado"`                // Indica se o cadastro foi validado
-	Endereco        Endereco `bson:"endereco"`                // Endereço completo do membro
-	DataAniversario string   `bson:"dataAniversario"`         // Data do aniversário no formato string
-	DataModificacao string   `bson:"dataModificacao"`         // Data do aniversário no formato string
+	ID               primitive.ObjectID  `bson:"_id,omitempty"`                            // Identificador do documento, gerado na inserção
+	Name             string              `bson:"name"`                                     // Nome completo do membro
+	DataNascimento   time.Time           `bson:"dataNascimento"`                           // Data de nascimento
+	AnoBatismo       int                 `bson:"anoBatismo"`                               // Ano em que foi batizado
+	Idade            *int                `bson:"idade,omitempty"`                          // Idade em anos completos (calculada na carga e a cada execução)
+	FaixaEtaria      string              `bson:"faixaEtaria,omitempty"`                    // Faixa etária da idade (ex: "18-29")
+	AnosBatismo      *int                `bson:"anosBatismo,omitempty"`                    // Anos desde o batismo (ausente quando o ano não foi informado)
+	Sexo             string              `bson:"sexo,omitempty" enum:"sexo"`               // Sexo do membro (valor canônico da tabela de enums)
+	EstadoCivil      string              `bson:"estadoCivil,omitempty" enum:"estadoCivil"` // Estado civil atual (valor canônico da tabela de enums)
+	DataCasamento    *time.Time          `bson:"dataCasamento,omitempty"`                  // Data do casamento (opcional)
+	NomeConjuge      string              `bson:"nomeConjuge,omitempty"`                    // Nome do cônjuge (opcional)
+	ConjugeID        *primitive.ObjectID `bson:"conjugeId,omitempty"`                      // _id do cônjuge, quando ele também é membro (definido após a carga)
+	Filho            bool                `bson:"filho"`                                    // Indica se possui filhos
+	FamiliaID        *primitive.ObjectID `bson:"familiaId,omitempty"`                      // _id da família (domicílio) do membro (definido após a carga)
+	Email            string              `bson:"email,omitempty"`                          // E-mail de contato, normalizado em minúsculas
+	Telefone         string              `bson:"telefone,omitempty"`                       // Telefone de contato no formato E.164 (ex: +5511987654321)
+	TelefoneExibicao string              `bson:"telefoneExibicao,omitempty"`               // Telefone no formato de exibição (ex: (11) 98765-4321)
+	Status           string              `bson:"status,omitempty" enum:"status"`           // Status do membro (valor canônico da tabela de enums)
+	DataStatus       *time.Time          `bson:"dataStatus,omitempty"`                     // Data da última alteração de status (opcional)
+	Validado         bool                `bson:"validado"`                                 // Indica se o cadastro foi validado
+	Endereco         Endereco            `bson:"endereco"`                                 // Endereço completo do membro
+	DataAniversario  string              `bson:"dataAniversario"`                          // Dia e mês do aniversário ("DD/MM")
+	DataModificacao  time.Time           `bson:"dataModificacao"`                          // Instante da última alteração de conteúdo do membro
+	Hash             string              `bson:"hash"`                                     // Hash dos campos de negócio, usado para detectar alterações
 }
@@ -0,0 +1,34 @@
+package bancofinal
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Papéis de um membro dentro da família.
+const (
+	PapelResponsavel = "responsavel" // Responsável pela família
+	PapelConjuge     = "conjuge"     // Cônjuge do responsável
+	PapelMembro      = "membro"      // Demais membros do domicílio
+)
+
+// Familia representa um domicílio: os membros que moram no mesmo endereço, unidos também pelo vínculo entre cônjuges.
+//
+// Os documentos da coleção de famílias são recalculados a cada execução do ETL.
+type Familia struct {
+	ID              primitive.ObjectID `bson:"_id"`             // Identificador da família, mantido entre execuções sempre que possível
+	Endereco        Endereco           `bson:"endereco"`        // Endereço do domicílio (o do responsável)
+	ResponsavelID   primitive.ObjectID `bson:"responsavelId"`   // _id do membro responsável pela família
+	Responsavel     string             `bson:"responsavel"`     // Nome do responsável pela família
+	Membros         []MembroFamilia    `bson:"membros"`         // Membros do domicílio, começando pelo responsável
+	ComFilhos       bool               `bson:"comFilhos"`       // Indica se algum membro informou ter filhos
+	DataAtualizacao time.Time          `bson:"dataAtualizacao"` // Instante da execução que calculou a família
+}
+
+// MembroFamilia identifica um membro dentro da família.
+type MembroFamilia struct {
+	ID    primitive.ObjectID `bson:"id"`    // _id do documento do membro
+	Name  string             `bson:"name"`  // Nome do membro
+	Papel string             `bson:"papel"` // responsavel, conjuge ou membro
+}
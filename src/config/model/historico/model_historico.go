@@ -0,0 +1,60 @@
+package historico
+
+import "time"
+
+// Status possíveis de uma execução registrada no histórico.
+const (
+	StatusExecutando = "executando" // Execução em andamento
+	StatusConcluida  = "concluida"  // Concluída sem falhas de membros
+	StatusParcial    = "parcial"    // Concluída, porém com membros que falharam
+	StatusFalha      = "falha"      // Interrompida por um erro fatal
+	StatusCancelada  = "cancelada"  // Cancelada antes de terminar
+	StatusIgnorada   = "ignorada"   // Disparo agendado ignorado porque o job ainda estava em execução
+)
+
+// Tipos de ocorrência registrados para cada execução.
+const (
+	OcorrenciaDuplicado          = "duplicado"           // Membro repetido na execução
+	OcorrenciaAtualizado         = "atualizado"          // Membro existente com conteúdo alterado
+	OcorrenciaFalhaTransformacao = "falha_transformacao" // Membro que falhou na conversão para o modelo final
+	OcorrenciaFalhaInsercao      = "falha_insercao"      // Membro que falhou na inserção
+	OcorrenciaAviso              = "aviso"               // Valor aceito na conversão que merece revisão
+	OcorrenciaInconsistencia     = "inconsistencia"      // Casal cujos dados não conferem entre os dois membros
+)
+
+// Execucao representa uma execução do ETL registrada no histórico.
+//
+// Os campos possuem tags BSON para a coleção de histórico e tags JSON para a API de controle.
+type Execucao struct {
+	ID                 string         `bson:"_id" json:"id"`                                                    // Identificador da execução (run_id)
+	Job                string         `bson:"job" json:"job"`                                                   // Nome do job executado
+	Origem             string         `bson:"origem" json:"origem"`                                             // Quem disparou a execução (api, cli, agendador)
+	Status             string         `bson:"status" json:"status"`                                             // Status atual da execução
+	DryRun             bool           `bson:"dryRun" json:"dryRun"`                                             // Execução sem escrita no banco final
+	Incremental        bool           `bson:"incremental" json:"incremental"`                                   // Execução limitada aos membros novos
+	Desde              *time.Time     `bson:"desde,omitempty" json:"desde,omitempty"`                           // Início da janela incremental (opcional)
+	Inicio             time.Time      `bson:"inicio" json:"inicio"`                                             // Início da execução
+	Fim                *time.Time     `bson:"fim,omitempty" json:"fim,omitempty"`                               // Fim da execução (ausente enquanto executa)
+	Total              int            `bson:"total" json:"total"`                                               // Membros lidos do banco inicial
+	Inseridos          int            `bson:"inseridos" json:"inseridos"`                                       // Membros inseridos no banco final
+	Atualizados        int            `bson:"atualizados" json:"atualizados"`                                   // Membros existentes atualizados por mudança de conteúdo
+	Inalterados        int            `bson:"inalterados" json:"inalterados"`                                   // Membros existentes sem mudança (sem escrita)
+	Duplicados         int            `bson:"duplicados" json:"duplicados"`                                     // Membros repetidos na execução
+	Falhas             int            `bson:"falhas" json:"falhas"`                                             // Membros que falharam na transformação ou inserção
+	FalhasPorCategoria map[string]int `bson:"falhasPorCategoria,omitempty" json:"falhasPorCategoria,omitempty"` // Falhas de inserção por categoria
+	Avisos             int            `bson:"avisos" json:"avisos"`                                             // Avisos de qualidade dos dados
+	AvisosPorCodigo    map[string]int `bson:"avisosPorCodigo,omitempty" json:"avisosPorCodigo,omitempty"`       // Avisos de qualidade por código
+	Conjuges           int            `bson:"conjuges" json:"conjuges"`                                         // Membros vinculados ao documento do cônjuge
+	Inconsistencias    int            `bson:"inconsistencias" json:"inconsistencias"`                           // Casais cujos dados não conferem
+	Familias           int            `bson:"familias" json:"familias"`                                         // Famílias (domicílios) calculadas após a carga
+	Observacoes        []string       `bson:"observacoes,omitempty" json:"observacoes,omitempty"`               // Observações sobre o alcance da execução (opcional)
+	Erro               string         `bson:"erro,omitempty" json:"erro,omitempty"`                             // Erro fatal que interrompeu a execução (opcional)
+}
+
+// Ocorrencia é um duplicado, uma atualização, uma falha, um aviso ou uma inconsistência de membro registrado em uma execução.
+type Ocorrencia struct {
+	RunID     string `bson:"runId" json:"-"`             // Execução à qual a ocorrência pertence
+	Sequencia int    `bson:"seq" json:"seq"`             // Ordem da ocorrência dentro do tipo
+	Tipo      string `bson:"tipo" json:"tipo"`           // duplicado, atualizado, falha_transformacao, falha_insercao ou aviso
+	Descricao string `bson:"descricao" json:"descricao"` // Nome do membro e, nas falhas, o motivo
+}
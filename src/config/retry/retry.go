@@ -0,0 +1,67 @@
+package retry
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"etl-service/src/config/env"
+)
+
+// Politica define quantas vezes e com qual espera uma operação deve ser repetida.
+//
+// A espera entre tentativas segue backoff exponencial com jitter completo:
+// um valor aleatório entre zero e min(Max, Base * 2^tentativa).
+type Politica struct {
+	MaxTentativas int           // Número máximo de tentativas, incluindo a primeira
+	Base          time.Duration // Espera base da primeira repetição
+	Max           time.Duration // Limite superior de espera entre tentativas
+}
+
+// PoliticaPadrao lê a política de repetição das variáveis de ambiente
+// ETL_RETRY_MAX_TENTATIVAS (padrão 5), ETL_RETRY_BASE (padrão 100ms) e ETL_RETRY_MAX (padrão 5s).
+func PoliticaPadrao() Politica {
+	return Politica{
+		MaxTentativas: env.GetInt("ETL_RETRY_MAX_TENTATIVAS", 5),
+		Base:          env.GetDuration("ETL_RETRY_BASE", 100*time.Millisecond),
+		Max:           env.GetDuration("ETL_RETRY_MAX", 5*time.Second),
+	}
+}
+
+// Executar chama fn até que ela retorne sucesso, retorne um erro não repetível
+// (segundo a função repetivel), o número máximo de tentativas seja atingido ou o contexto seja cancelado.
+//
+// Retorna o número de tentativas realizadas e o último erro obtido.
+func Executar(ctx context.Context, p Politica, fn func() error, repetivel func(error) bool) (int, error) {
+	if p.MaxTentativas < 1 {
+		p.MaxTentativas = 1
+	}
+
+	var err error
+	for tentativa := 1; ; tentativa++ {
+		err = fn()
+		if err == nil || tentativa >= p.MaxTentativas || !repetivel(err) {
+			return tentativa, err
+		}
+
+		timer := time.NewTimer(p.espera(tentativa))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return tentativa, err
+		case <-timer.C:
+		}
+	}
+}
+
+// espera calcula o tempo de espera antes da próxima tentativa (jitter completo).
+func (p Politica) espera(tentativa int) time.Duration {
+	teto := p.Base << uint(tentativa-1)
+	if teto <= 0 || (p.Max > 0 && teto > p.Max) {
+		teto = p.Max
+	}
+	if teto <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(teto) + 1))
+}
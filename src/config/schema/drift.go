@@ -0,0 +1,112 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Diferencas compara o validador esperado com o validador atualmente aplicado na coleção
+// e retorna uma descrição legível de cada divergência encontrada (campo ausente, sobrando ou diferente).
+//
+// Os dois documentos são normalizados via Extended JSON antes da comparação, e listas de strings
+// (como "required" e "enum") são comparadas sem considerar a ordem dos itens.
+func Diferencas(esperado, atual interface{}) ([]string, error) {
+	e, err := normalizar(esperado)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao normalizar validador esperado: %w", err)
+	}
+	a, err := normalizar(atual)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao normalizar validador atual: %w", err)
+	}
+
+	var diffs []string
+	comparar("", e, a, &diffs)
+	sort.Strings(diffs)
+	return diffs, nil
+}
+
+// normalizar converte um documento BSON qualquer para tipos genéricos do encoding/json.
+func normalizar(doc interface{}) (interface{}, error) {
+	if doc == nil {
+		return nil, nil
+	}
+	raw, err := bson.MarshalExtJSON(doc, false, false)
+	if err != nil {
+		return nil, err
+	}
+	var v interface{}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// comparar percorre recursivamente os dois documentos acumulando as diferenças encontradas.
+func comparar(caminho string, esperado, atual interface{}, diffs *[]string) {
+	em, eOk := esperado.(map[string]interface{})
+	am, aOk := atual.(map[string]interface{})
+	if eOk && aOk {
+		for k, ev := range em {
+			av, existe := am[k]
+			if !existe {
+				*diffs = append(*diffs, fmt.Sprintf("%s: ausente no validador atual", juntar(caminho, k)))
+				continue
+			}
+			comparar(juntar(caminho, k), ev, av, diffs)
+		}
+		for k := range am {
+			if _, existe := em[k]; !existe {
+				*diffs = append(*diffs, fmt.Sprintf("%s: não esperado (presente apenas no validador atual)", juntar(caminho, k)))
+			}
+		}
+		return
+	}
+
+	if reflect.DeepEqual(ordenarStrings(esperado), ordenarStrings(atual)) {
+		return
+	}
+	*diffs = append(*diffs, fmt.Sprintf("%s: esperado %s, atual %s", caminho, formatar(esperado), formatar(atual)))
+}
+
+// ordenarStrings retorna uma cópia ordenada quando o valor é uma lista composta apenas de strings.
+func ordenarStrings(v interface{}) interface{} {
+	lista, ok := v.([]interface{})
+	if !ok {
+		return v
+	}
+	strs := make([]string, 0, len(lista))
+	for _, item := range lista {
+		s, ok := item.(string)
+		if !ok {
+			return v
+		}
+		strs = append(strs, s)
+	}
+	sort.Strings(strs)
+	return strs
+}
+
+// juntar monta o caminho pontuado do campo.
+func juntar(caminho, chave string) string {
+	if caminho == "" {
+		return chave
+	}
+	return caminho + "." + chave
+}
+
+// formatar serializa o valor em JSON compacto para exibição.
+func formatar(v interface{}) string {
+	if v == nil {
+		return "<ausente>"
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
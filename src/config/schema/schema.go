@@ -0,0 +1,127 @@
+package schema
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// tipoTime é usado para identificar campos time.Time, mapeados para o bsonType "date".
+var tipoTime = reflect.TypeOf(time.Time{})
+
+// tipoObjectID é usado para identificar campos primitive.ObjectID, mapeados para o bsonType "objectId".
+var tipoObjectID = reflect.TypeOf(primitive.ObjectID{})
+
+// Gerar cria o validador $jsonSchema a partir das tags BSON da struct informada.
+//
+// Regras de geração:
+// - O nome de cada propriedade é o nome definido na tag bson (campos com "-" são ignorados).
+// - Campos com "omitempty" ou do tipo ponteiro são opcionais; os demais entram em "required".
+// - Structs aninhadas geram subdocumentos com suas próprias propriedades e campos obrigatórios.
+// - Tipos Go são convertidos para bsonType (string, int, long, double, bool, date, objectId, array, object).
+// - Campos com a tag enum (ex: `enum:"sexo"`) recebem a restrição "enum" com os valores de enums para essa chave.
+//
+// O retorno está no formato esperado pela opção "validator" de create/collMod.
+func Gerar(modelo interface{}, enums map[string][]string) bson.M {
+	return bson.M{"$jsonSchema": objeto(reflect.TypeOf(modelo), enums)}
+}
+
+// objeto gera o schema de um subdocumento a partir de uma struct.
+func objeto(t reflect.Type, enums map[string][]string) bson.M {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	propriedades := bson.M{}
+	required := []string{}
+
+	for i := 0; i < t.NumField(); i++ {
+		campo := t.Field(i)
+		if !campo.IsExported() {
+			continue
+		}
+
+		nome, omitempty := parseTag(campo)
+		if nome == "-" {
+			continue
+		}
+
+		prop := propriedade(campo.Type, enums)
+		if valores := enums[campo.Tag.Get("enum")]; len(valores) > 0 {
+			prop["enum"] = valores
+		}
+		propriedades[nome] = prop
+		if !omitempty && campo.Type.Kind() != reflect.Ptr {
+			required = append(required, nome)
+		}
+	}
+
+	s := bson.M{
+		"bsonType":   "object",
+		"properties": propriedades,
+	}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	return s
+}
+
+// propriedade gera o schema de um campo de acordo com o seu tipo Go.
+func propriedade(t reflect.Type, enums map[string][]string) bson.M {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == tipoTime {
+		return bson.M{"bsonType": "date"}
+	}
+	if t == tipoObjectID {
+		return bson.M{"bsonType": "objectId"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return bson.M{"bsonType": "string"}
+	case reflect.Bool:
+		return bson.M{"bsonType": "bool"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint8, reflect.Uint16:
+		return bson.M{"bsonType": "int"}
+	case reflect.Int64, reflect.Uint32, reflect.Uint64, reflect.Uint:
+		return bson.M{"bsonType": "long"}
+	case reflect.Float32, reflect.Float64:
+		return bson.M{"bsonType": "double"}
+	case reflect.Slice, reflect.Array:
+		return bson.M{"bsonType": "array", "items": propriedade(t.Elem(), enums)}
+	case reflect.Struct:
+		return objeto(t, enums)
+	default:
+		return bson.M{}
+	}
+}
+
+// parseTag retorna o nome BSON do campo e se ele possui a opção omitempty.
+// Na ausência da tag, segue a convenção do driver (nome do campo em minúsculas).
+func parseTag(campo reflect.StructField) (string, bool) {
+	tag, ok := campo.Tag.Lookup("bson")
+	if !ok {
+		return strings.ToLower(campo.Name), false
+	}
+
+	partes := strings.Split(tag, ",")
+	nome := partes[0]
+	if nome == "" {
+		nome = strings.ToLower(campo.Name)
+	}
+
+	omitempty := false
+	for _, opt := range partes[1:] {
+		if opt == "omitempty" {
+			omitempty = true
+		}
+	}
+	return nome, omitempty
+}
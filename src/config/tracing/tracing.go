@@ -0,0 +1,117 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/contrib/instrumentation/go.mongodb.org/mongo-driver/mongo/otelmongo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// nomeServico identifica o serviço nos traces exportados.
+const nomeServico = "etl-service"
+
+// Exportadores suportados.
+const (
+	ExportadorNenhum = ""       // Tracing desabilitado (spans não são registrados)
+	ExportadorOTLP   = "otlp"   // OTLP/HTTP (ex: Jaeger em localhost:4318), configurado pelas variáveis OTEL_EXPORTER_OTLP_*
+	ExportadorStdout = "stdout" // Spans em JSON na saída padrão
+	ExportadorFile   = "file"   // Spans em JSON gravados em arquivo, para análise offline
+)
+
+// Config define como os spans são exportados.
+type Config struct {
+	Exportador string // Um dos Exportador*
+	Arquivo    string // Caminho do arquivo quando Exportador é "file"
+	Versao     string // Versão do serviço registrada no resource
+}
+
+// Iniciar configura o TracerProvider global de acordo com o exportador escolhido.
+// Retorna a função que deve ser chamada ao final da execução para exportar os spans pendentes.
+func Iniciar(ctx context.Context, cfg Config) (func(context.Context) error, error) {
+	var (
+		exporter sdktrace.SpanExporter
+		arquivo  io.Closer
+		err      error
+	)
+
+	switch cfg.Exportador {
+	case ExportadorNenhum:
+		return func(context.Context) error { return nil }, nil
+	case ExportadorOTLP:
+		exporter, err = otlptracehttp.New(ctx)
+	case ExportadorStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case ExportadorFile:
+		if cfg.Arquivo == "" {
+			return nil, errors.New("exportador 'file' requer o caminho do arquivo de traces")
+		}
+		f, errArquivo := os.Create(cfg.Arquivo)
+		if errArquivo != nil {
+			return nil, fmt.Errorf("erro ao criar arquivo de traces: %w", errArquivo)
+		}
+		arquivo = f
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(f))
+	default:
+		return nil, fmt.Errorf("exportador de traces desconhecido: %q (use otlp, stdout ou file)", cfg.Exportador)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar exportador de traces: %w", err)
+	}
+
+	res := resource.NewSchemaless(
+		semconv.ServiceName(nomeServico),
+		semconv.ServiceVersion(cfg.Versao),
+	)
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if arquivo != nil {
+			arquivo.Close()
+		}
+		return err
+	}, nil
+}
+
+// Tracer retorna o tracer do serviço, resolvido a partir do TracerProvider global.
+func Tracer() trace.Tracer {
+	return otel.Tracer(nomeServico)
+}
+
+// Span abre um span filho do span presente em ctx, com os atributos informados.
+func Span(ctx context.Context, nome string, atributos ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, nome, trace.WithAttributes(atributos...))
+}
+
+// Finalizar registra o erro (se houver) no span e o encerra.
+func Finalizar(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// MonitorMongo retorna o monitor de comandos do driver que cria um span para cada comando
+// enviado ao MongoDB. O conteúdo dos comandos não é registrado, pois pode conter dados pessoais.
+func MonitorMongo() *event.CommandMonitor {
+	return otelmongo.NewMonitor(otelmongo.WithCommandAttributeDisabled(true))
+}
@@ -0,0 +1,17 @@
+package agendador
+
+import "context"
+
+// Agendador define a interface do serviço que dispara os jobs nas datas definidas
+// por suas expressões cron, usando o gerenciador de execuções.
+//
+// Regras aplicadas a cada disparo:
+//   - Sobreposição: se o job ainda estiver executando, o disparo é ignorado e registrado no histórico.
+//   - Jitter: o disparo aguarda um atraso aleatório entre zero e o jitter do job.
+//   - Atraso: jobs com recuperação de atraso executam uma vez ao iniciar quando um disparo
+//     previsto desde a última execução foi perdido (ex: daemon parado).
+type Agendador interface {
+	// Executar agenda os jobs e bloqueia até o cancelamento de ctx.
+	// Disparos aguardando o jitter são abandonados no cancelamento.
+	Executar(ctx context.Context) error
+}
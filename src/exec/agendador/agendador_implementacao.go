@@ -0,0 +1,129 @@
+package agendador
+
+import (
+	"context"
+	"errors"
+	"etl-service/src/config/jobs"
+	"etl-service/src/config/logger"
+	"etl-service/src/config/model/historico"
+	"etl-service/src/exec/execucao"
+	historicorepository "etl-service/src/exec/repository/historico_repository"
+	"log/slog"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// agendador é a implementação concreta da interface Agendador.
+type agendador struct {
+	gerenciador execucao.Gerenciador
+	historico   historicorepository.HistoricoRepository
+	jobs        []jobs.Job
+}
+
+// NewAgendador cria o agendador para os jobs que possuem expressão cron.
+// Jobs sem cron continuam disponíveis apenas sob demanda (API de controle).
+func NewAgendador(g execucao.Gerenciador, historico historicorepository.HistoricoRepository, lista []jobs.Job) Agendador {
+	agendados := make([]jobs.Job, 0, len(lista))
+	for _, j := range lista {
+		if j.Agendado() {
+			agendados = append(agendados, j)
+		}
+	}
+	return &agendador{gerenciador: g, historico: historico, jobs: agendados}
+}
+
+// Executar registra os jobs no cron, dispara a recuperação de atrasos e aguarda o cancelamento de ctx.
+func (a *agendador) Executar(ctx context.Context) error {
+	if len(a.jobs) == 0 {
+		return errors.New("nenhum job com expressão cron configurado")
+	}
+
+	c := cron.New()
+	var disparos sync.WaitGroup
+
+	for _, job := range a.jobs {
+		agenda, err := job.Agenda()
+		if err != nil {
+			return err
+		}
+		c.Schedule(agenda, cron.FuncJob(func() {
+			disparos.Add(1)
+			defer disparos.Done()
+			a.disparar(ctx, job)
+		}))
+		slog.Info("job agendado", "job", job.Nome, "cron", job.Cron, "jitter", time.Duration(job.Jitter), "proximo", agenda.Next(time.Now()))
+
+		if job.RecuperarAtraso {
+			if perdido, err := a.disparoPerdido(ctx, job, agenda); err != nil {
+				slog.Warn("não foi possível verificar disparos perdidos", "job", job.Nome, logger.Erro(err))
+			} else if perdido {
+				slog.Info("disparo perdido detectado: executando recuperação", "job", job.Nome)
+				disparos.Add(1)
+				go func() {
+					defer disparos.Done()
+					a.disparar(ctx, job)
+				}()
+			}
+		}
+	}
+
+	c.Start()
+	<-ctx.Done()
+	<-c.Stop().Done()
+	disparos.Wait()
+	return nil
+}
+
+// disparoPerdido indica se a agenda previa algum disparo entre a última execução do job e agora.
+// Sem histórico, nenhum disparo é considerado perdido.
+func (a *agendador) disparoPerdido(ctx context.Context, job jobs.Job, agenda cron.Schedule) (bool, error) {
+	ultima, err := a.historico.UltimaExecucao(ctx, job.Nome)
+	if err != nil || ultima == nil {
+		return false, err
+	}
+	return agenda.Next(ultima.Inicio).Before(time.Now()), nil
+}
+
+// disparar aguarda o jitter e inicia a execução do job. Se outra execução ainda estiver carregando
+// o banco final, o disparo é ignorado e registrado no histórico com status ignorada.
+func (a *agendador) disparar(ctx context.Context, job jobs.Job) {
+	if job.Jitter > 0 {
+		atraso := rand.N(time.Duration(job.Jitter))
+		select {
+		case <-time.After(atraso):
+		case <-ctx.Done():
+			return
+		}
+	}
+
+	registro, err := a.gerenciador.Iniciar(ctx, job.Nome, execucao.Opcoes{Origem: execucao.OrigemAgendador})
+	switch {
+	case errors.Is(err, execucao.ErrJobEmExecucao):
+		slog.Warn("disparo agendado ignorado: outra execução em andamento", "job", job.Nome)
+		a.registrarIgnorado(ctx, job, err)
+	case err != nil:
+		slog.Error("erro ao iniciar execução agendada", "job", job.Nome, logger.Erro(err))
+	default:
+		slog.Info("execução agendada iniciada", "job", job.Nome, logger.ChaveRunID, registro.ID)
+	}
+}
+
+// registrarIgnorado grava no histórico o disparo que não foi executado por sobreposição.
+func (a *agendador) registrarIgnorado(ctx context.Context, job jobs.Job, motivo error) {
+	agora := time.Now()
+	registro := historico.Execucao{
+		ID:     logger.NovoRunID(),
+		Job:    job.Nome,
+		Origem: execucao.OrigemAgendador,
+		Status: historico.StatusIgnorada,
+		Inicio: agora,
+		Fim:    &agora,
+		Erro:   motivo.Error(),
+	}
+	if err := a.historico.Salvar(context.WithoutCancel(ctx), registro); err != nil {
+		slog.Error("erro ao registrar disparo ignorado", "job", job.Nome, logger.Erro(err))
+	}
+}
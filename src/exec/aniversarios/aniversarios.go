@@ -0,0 +1,50 @@
+package aniversarios
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Tipo identifica a data comemorada em um evento.
+type Tipo string
+
+// Tipos de evento.
+const (
+	TipoAniversario Tipo = "aniversario" // Aniversário de nascimento (dataNascimento)
+	TipoCasamento   Tipo = "casamento"   // Aniversário de casamento (dataCasamento)
+)
+
+// Agenda define a interface do serviço que consulta os aniversários de nascimento e de casamento
+// dos membros do banco final.
+//
+// Quem nasceu ou casou em 29/02 comemora em 28/02 nos anos não bissextos. Um casal em que os dois
+// são membros vinculados (conjugeId) gera um único aniversário de casamento.
+type Agenda interface {
+	// Proximos lista os eventos entre as datas inicio e fim (inclusive, no fuso de São Paulo), ordenados
+	// pela data. A janela pode atravessar a virada do ano (ex: 20/12 a 10/01).
+	Proximos(ctx context.Context, inicio, fim time.Time, filtro Filtro) ([]Evento, error)
+
+	// Calendario grava em w um calendário iCalendar (RFC 5545) com um evento anual recorrente para cada
+	// aniversário, próprio para assinatura. Retorna a quantidade de eventos gravados.
+	Calendario(ctx context.Context, w io.Writer, filtro Filtro) (int, error)
+}
+
+// Filtro restringe os membros e os tipos de evento consultados.
+type Filtro struct {
+	Status []string // Status dos membros incluídos (vazio inclui todos)
+	Tipos  []Tipo   // Tipos de evento incluídos (vazio inclui todos)
+}
+
+// Evento é a ocorrência de um aniversário em uma data.
+type Evento struct {
+	Tipo     Tipo               `json:"tipo"`
+	MembroID primitive.ObjectID `json:"membroId"`
+	Nome     string             `json:"nome"`              // Nome do membro
+	Conjuge  string             `json:"conjuge,omitempty"` // Nome do cônjuge, nos aniversários de casamento
+	Data     time.Time          `json:"data"`              // Dia da comemoração
+	Original time.Time          `json:"original"`          // Data de nascimento ou de casamento
+	Anos     int                `json:"anos"`              // Anos completados na data
+}
@@ -0,0 +1,165 @@
+package aniversarios
+
+import (
+	"context"
+	bancofinal "etl-service/src/config/model/banco_final"
+	"etl-service/src/exec/domain"
+	finalrepository "etl-service/src/exec/repository/final_repository"
+	"io"
+	"slices"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// camposAgenda são os campos lidos do banco final para montar os eventos.
+var camposAgenda = []string{"name", "dataNascimento", "dataCasamento", "nomeConjuge", "conjugeId", "status"}
+
+// agenda é a implementação concreta da interface Agenda.
+type agenda struct {
+	final finalrepository.FinalRepository
+}
+
+// NewAgenda cria o serviço de aniversários sobre o repositório do banco final.
+func NewAgenda(final finalrepository.FinalRepository) Agenda {
+	return &agenda{final: final}
+}
+
+// Proximos lê os membros do banco final e lista os eventos da janela.
+func (a *agenda) Proximos(ctx context.Context, inicio, fim time.Time, filtro Filtro) ([]Evento, error) {
+	membros, err := a.membros(ctx, filtro)
+	if err != nil {
+		return nil, err
+	}
+	return Listar(membros, inicio, fim, filtro.Tipos...), nil
+}
+
+// Calendario lê os membros do banco final e grava o calendário com todos os aniversários.
+func (a *agenda) Calendario(ctx context.Context, w io.Writer, filtro Filtro) (int, error) {
+	membros, err := a.membros(ctx, filtro)
+	if err != nil {
+		return 0, err
+	}
+	return EscreverICS(w, membros, time.Now(), filtro.Tipos...)
+}
+
+// membros lê os membros do banco final com os status do filtro.
+func (a *agenda) membros(ctx context.Context, filtro Filtro) ([]bancofinal.Membro, error) {
+	membros, err := a.final.ListarCampos(ctx, camposAgenda...)
+	if err != nil {
+		return nil, err
+	}
+	if len(filtro.Status) == 0 {
+		return membros, nil
+	}
+	status := make(map[string]bool, len(filtro.Status))
+	for _, s := range filtro.Status {
+		status[domain.ChaveTexto(s)] = true
+	}
+	return slices.DeleteFunc(membros, func(m bancofinal.Membro) bool {
+		return !status[domain.ChaveTexto(m.Status)]
+	}), nil
+}
+
+// Listar retorna os eventos dos membros entre as datas inicio e fim (inclusive, no fuso de São Paulo),
+// ordenados pela data, pelo tipo e pelo nome. Tipos vazios incluem aniversários e casamentos.
+func Listar(membros []bancofinal.Membro, inicio, fim time.Time, tipos ...Tipo) []Evento {
+	inicio, fim = dia(inicio), dia(fim)
+
+	var eventos []Evento
+	for _, d := range datas(membros, tipos) {
+		for ano := inicio.Year(); ano <= fim.Year(); ano++ {
+			data := Ocorrencia(d.original, ano)
+			anos := ano - d.original.Year()
+			if anos < 1 || data.Before(inicio) || data.After(fim) {
+				continue
+			}
+			eventos = append(eventos, Evento{
+				Tipo:     d.tipo,
+				MembroID: d.membroID,
+				Nome:     d.nome,
+				Conjuge:  d.conjuge,
+				Data:     data,
+				Original: d.original,
+				Anos:     anos,
+			})
+		}
+	}
+
+	sort.Slice(eventos, func(i, j int) bool {
+		a, b := eventos[i], eventos[j]
+		if !a.Data.Equal(b.Data) {
+			return a.Data.Before(b.Data)
+		}
+		if a.Tipo != b.Tipo {
+			return a.Tipo < b.Tipo
+		}
+		return a.Nome < b.Nome
+	})
+	return eventos
+}
+
+// Ocorrencia retorna o dia em que a data é comemorada no ano informado, à meia-noite no fuso de São Paulo.
+// 29/02 é comemorado em 28/02 nos anos não bissextos.
+func Ocorrencia(data time.Time, ano int) time.Time {
+	data = data.In(domain.FusoHorario)
+	mes, d := data.Month(), data.Day()
+	if mes == time.February && d == 29 && !bissexto(ano) {
+		d = 28
+	}
+	return time.Date(ano, mes, d, 0, 0, 0, 0, domain.FusoHorario)
+}
+
+// dataComemorada é uma data de nascimento ou de casamento a ser comemorada todo ano.
+type dataComemorada struct {
+	tipo     Tipo
+	membroID primitive.ObjectID
+	nome     string
+	conjuge  string
+	original time.Time // Meia-noite da data original no fuso de São Paulo
+}
+
+// datas extrai dos membros as datas comemoradas dos tipos informados (vazio inclui todos).
+// O casamento de um casal vinculado em que os dois estão na lista aparece uma única vez,
+// a partir do membro de menor nome que informou a data.
+func datas(membros []bancofinal.Membro, tipos []Tipo) []dataComemorada {
+	incluir := func(t Tipo) bool { return len(tipos) == 0 || slices.Contains(tipos, t) }
+	porID := make(map[primitive.ObjectID]*bancofinal.Membro, len(membros))
+	for i := range membros {
+		porID[membros[i].ID] = &membros[i]
+	}
+
+	var lista []dataComemorada
+	for _, m := range membros {
+		if incluir(TipoAniversario) && !m.DataNascimento.IsZero() {
+			lista = append(lista, dataComemorada{tipo: TipoAniversario, membroID: m.ID, nome: m.Name, original: dia(m.DataNascimento)})
+		}
+		if !incluir(TipoCasamento) || m.DataCasamento == nil {
+			continue
+		}
+		conjuge := m.NomeConjuge
+		if m.ConjugeID != nil {
+			if c, ok := porID[*m.ConjugeID]; ok {
+				if c.Name < m.Name && c.DataCasamento != nil {
+					// O casamento é listado a partir do cônjuge
+					continue
+				}
+				conjuge = c.Name
+			}
+		}
+		lista = append(lista, dataComemorada{tipo: TipoCasamento, membroID: m.ID, nome: m.Name, conjuge: conjuge, original: dia(*m.DataCasamento)})
+	}
+	return lista
+}
+
+// dia retorna a meia-noite do dia do instante no fuso de São Paulo.
+func dia(t time.Time) time.Time {
+	t = t.In(domain.FusoHorario)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, domain.FusoHorario)
+}
+
+// bissexto indica se o ano tem 29 de fevereiro.
+func bissexto(ano int) bool {
+	return ano%4 == 0 && (ano%100 != 0 || ano%400 == 0)
+}
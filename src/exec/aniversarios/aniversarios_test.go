@@ -0,0 +1,176 @@
+package aniversarios
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	bancofinal "etl-service/src/config/model/banco_final"
+	"etl-service/src/exec/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// data retorna a meia-noite do dia no fuso de São Paulo, como as datas gravadas no banco final.
+func data(ano int, mes time.Month, dia int) time.Time {
+	return time.Date(ano, mes, dia, 0, 0, 0, 0, domain.FusoHorario)
+}
+
+func TestOcorrencia(t *testing.T) {
+	casos := []struct {
+		original time.Time
+		ano      int
+		esperado time.Time
+	}{
+		{data(1990, time.March, 15), 2026, data(2026, time.March, 15)},
+		{data(1990, time.December, 31), 2026, data(2026, time.December, 31)},
+		{data(1992, time.February, 29), 2024, data(2024, time.February, 29)},
+		{data(1992, time.February, 29), 2026, data(2026, time.February, 28)},
+		{data(1992, time.February, 29), 2100, data(2100, time.February, 28)},
+		{data(1992, time.February, 29), 2000, data(2000, time.February, 29)},
+		{data(1992, time.February, 28), 2024, data(2024, time.February, 28)},
+		{data(1992, time.March, 1), 2026, data(2026, time.March, 1)},
+		// Instantes em UTC são convertidos para o dia correspondente em São Paulo
+		{time.Date(1990, time.March, 15, 3, 0, 0, 0, time.UTC), 2026, data(2026, time.March, 15)},
+		{time.Date(1990, time.March, 16, 2, 0, 0, 0, time.UTC), 2026, data(2026, time.March, 15)},
+	}
+	for _, c := range casos {
+		t.Run(fmt.Sprintf("%s em %d", c.original.Format(time.RFC3339), c.ano), func(t *testing.T) {
+			if obtido := Ocorrencia(c.original, c.ano); !obtido.Equal(c.esperado) {
+				t.Errorf("Ocorrencia() = %s, esperado %s", obtido.Format("02/01/2006"), c.esperado.Format("02/01/2006"))
+			}
+		})
+	}
+}
+
+// membro cria um membro com _id, nome e data de nascimento.
+func membro(nome string, nascimento time.Time) bancofinal.Membro {
+	return bancofinal.Membro{ID: primitive.NewObjectID(), Name: nome, DataNascimento: nascimento}
+}
+
+// resumir descreve os eventos como "DD/MM/AAAA tipo nome (anos)" para comparação.
+func resumir(eventos []Evento) []string {
+	linhas := make([]string, len(eventos))
+	for i, e := range eventos {
+		linhas[i] = fmt.Sprintf("%s %s %s (%d)", e.Data.Format("02/01/2006"), e.Tipo, e.Nome, e.Anos)
+		if e.Conjuge != "" {
+			linhas[i] += " com " + e.Conjuge
+		}
+	}
+	return linhas
+}
+
+func TestListar(t *testing.T) {
+	casamento := func(m bancofinal.Membro, d time.Time, conjuge string) bancofinal.Membro {
+		m.DataCasamento = &d
+		m.NomeConjuge = conjuge
+		return m
+	}
+	ana := membro("Ana", data(1985, time.January, 5))
+	bruno := membro("Bruno", data(1980, time.December, 25))
+	ana = casamento(ana, data(2010, time.December, 28), "Bruno")
+	bruno = casamento(bruno, data(2010, time.December, 28), "Ana")
+	ana.ConjugeID, bruno.ConjugeID = &bruno.ID, &ana.ID
+
+	membros := []bancofinal.Membro{
+		bruno,
+		ana,
+		membro("Carla", data(1992, time.February, 29)),
+		membro("Davi", data(2025, time.December, 22)),
+		membro("Eva", data(1970, time.June, 15)),
+		casamento(membro("Fábio", time.Time{}), data(2000, time.January, 1), "Gabriela"),
+	}
+
+	casos := []struct {
+		nome      string
+		inicio    time.Time
+		fim       time.Time
+		tipos     []Tipo
+		esperados []string
+	}{
+		{
+			nome:   "virada do ano",
+			inicio: data(2025, time.December, 20),
+			fim:    data(2026, time.January, 10),
+			esperados: []string{
+				"25/12/2025 aniversario Bruno (45)",
+				"28/12/2025 casamento Ana (15) com Bruno",
+				"01/01/2026 casamento Fábio (26) com Gabriela",
+				"05/01/2026 aniversario Ana (41)",
+			},
+		},
+		{
+			nome:      "somente casamentos",
+			inicio:    data(2025, time.December, 20),
+			fim:       data(2026, time.January, 10),
+			tipos:     []Tipo{TipoCasamento},
+			esperados: []string{"28/12/2025 casamento Ana (15) com Bruno", "01/01/2026 casamento Fábio (26) com Gabriela"},
+		},
+		{
+			nome:      "29/02 em ano não bissexto",
+			inicio:    data(2027, time.February, 27),
+			fim:       data(2027, time.March, 1),
+			esperados: []string{"28/02/2027 aniversario Carla (35)"},
+		},
+		{
+			nome:      "29/02 em ano bissexto",
+			inicio:    data(2028, time.February, 28),
+			fim:       data(2028, time.February, 28),
+			esperados: nil,
+		},
+		{
+			nome:      "29/02 no dia em ano bissexto",
+			inicio:    data(2028, time.February, 29),
+			fim:       data(2028, time.February, 29),
+			esperados: []string{"29/02/2028 aniversario Carla (36)"},
+		},
+		{
+			nome:      "primeiro aniversário",
+			inicio:    data(2026, time.December, 1),
+			fim:       data(2026, time.December, 31),
+			tipos:     []Tipo{TipoAniversario},
+			esperados: []string{"22/12/2026 aniversario Davi (1)", "25/12/2026 aniversario Bruno (46)"},
+		},
+		{
+			nome:      "janela com horas é considerada por dia",
+			inicio:    time.Date(2026, time.June, 15, 23, 59, 0, 0, domain.FusoHorario),
+			fim:       time.Date(2026, time.June, 15, 0, 1, 0, 0, domain.FusoHorario),
+			esperados: []string{"15/06/2026 aniversario Eva (56)"},
+		},
+		{
+			nome:   "janela de vários anos",
+			inicio: data(2026, time.June, 1),
+			fim:    data(2028, time.June, 30),
+			tipos:  []Tipo{TipoAniversario},
+			esperados: []string{
+				"15/06/2026 aniversario Eva (56)", "22/12/2026 aniversario Davi (1)", "25/12/2026 aniversario Bruno (46)",
+				"05/01/2027 aniversario Ana (42)", "28/02/2027 aniversario Carla (35)", "15/06/2027 aniversario Eva (57)",
+				"22/12/2027 aniversario Davi (2)", "25/12/2027 aniversario Bruno (47)", "05/01/2028 aniversario Ana (43)",
+				"29/02/2028 aniversario Carla (36)", "15/06/2028 aniversario Eva (58)",
+			},
+		},
+	}
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			obtidos := resumir(Listar(membros, c.inicio, c.fim, c.tipos...))
+			if strings.Join(obtidos, "\n") != strings.Join(c.esperados, "\n") {
+				t.Errorf("Listar() =\n%s\nesperado\n%s", strings.Join(obtidos, "\n"), strings.Join(c.esperados, "\n"))
+			}
+		})
+	}
+}
+
+func TestListarCasalSemDataNoPrimeiro(t *testing.T) {
+	ana := membro("Ana", data(1985, time.January, 5))
+	bruno := membro("Bruno", data(1980, time.December, 25))
+	d := data(2010, time.May, 20)
+	bruno.DataCasamento = &d
+	ana.ConjugeID, bruno.ConjugeID = &bruno.ID, &ana.ID
+
+	obtidos := resumir(Listar([]bancofinal.Membro{ana, bruno}, data(2026, time.May, 1), data(2026, time.May, 31), TipoCasamento))
+	esperados := []string{"20/05/2026 casamento Bruno (16) com Ana"}
+	if strings.Join(obtidos, "\n") != strings.Join(esperados, "\n") {
+		t.Errorf("Listar() = %v, esperado %v", obtidos, esperados)
+	}
+}
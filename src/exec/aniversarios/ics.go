@@ -0,0 +1,63 @@
+package aniversarios
+
+import (
+	"bufio"
+	bancofinal "etl-service/src/config/model/banco_final"
+	"etl-service/src/exec/linhaconteudo"
+	"fmt"
+	"io"
+	"time"
+)
+
+// EscreverICS grava em w um calendário iCalendar com um evento de dia inteiro, repetido todo ano, para cada
+// data comemorada dos membros (tipos vazios incluem aniversários e casamentos). Quem nasceu ou casou em 29/02
+// tem a regra ajustada para o último dia de fevereiro. Retorna a quantidade de eventos gravados.
+func EscreverICS(w io.Writer, membros []bancofinal.Membro, geradoEm time.Time, tipos ...Tipo) (int, error) {
+	buf := bufio.NewWriter(w)
+	linha := func(texto string) {
+		linhaconteudo.Escrever(buf, texto)
+	}
+
+	linha("BEGIN:VCALENDAR")
+	linha("VERSION:2.0")
+	linha("PRODID:-//etl-service//aniversarios//PT-BR")
+	linha("CALSCALE:GREGORIAN")
+	linha("METHOD:PUBLISH")
+	linha("X-WR-CALNAME:Aniversários")
+	linha("X-WR-TIMEZONE:America/Sao_Paulo")
+
+	lista := datas(membros, tipos)
+	carimbo := geradoEm.UTC().Format("20060102T150405Z")
+	for _, d := range lista {
+		regra := "FREQ=YEARLY"
+		if d.original.Month() == time.February && d.original.Day() == 29 {
+			regra = "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=-1"
+		}
+		resumo := "Aniversário: " + d.nome
+		descricao := "Nascimento em " + d.original.Format("02/01/2006")
+		if d.tipo == TipoCasamento {
+			resumo = "Aniversário de casamento: " + d.nome
+			if d.conjuge != "" {
+				resumo += " e " + d.conjuge
+			}
+			descricao = "Casamento em " + d.original.Format("02/01/2006")
+		}
+
+		linha("BEGIN:VEVENT")
+		linha(fmt.Sprintf("UID:%s-%s@etl-service", d.tipo, d.membroID.Hex()))
+		linha("DTSTAMP:" + carimbo)
+		linha("DTSTART;VALUE=DATE:" + d.original.Format("20060102"))
+		linha("RRULE:" + regra)
+		linha("SUMMARY:" + linhaconteudo.Escapar(resumo))
+		linha("DESCRIPTION:" + linhaconteudo.Escapar(descricao))
+		linha("CATEGORIES:" + string(d.tipo))
+		linha("TRANSP:TRANSPARENT")
+		linha("END:VEVENT")
+	}
+	linha("END:VCALENDAR")
+
+	if err := buf.Flush(); err != nil {
+		return 0, fmt.Errorf("erro ao gravar calendário: %w", err)
+	}
+	return len(lista), nil
+}
@@ -0,0 +1,33 @@
+package cep
+
+import (
+	bancofinal "etl-service/src/config/model/banco_final"
+	"etl-service/src/exec/domain"
+)
+
+// Localidade é o endereço correspondente a um CEP na base de referência.
+// CEPs gerais de cidades pequenas não têm logradouro nem bairro.
+type Localidade struct {
+	Cep        string // 8 dígitos, sem máscara
+	Logradouro string // Rua com o tipo (ex: Rua das Flores)
+	Bairro     string
+	Cidade     string
+	Uf         string
+}
+
+// Base define a interface do índice em memória da base de CEPs, usado para validar
+// e completar os endereços dos membros sem acesso à rede.
+type Base interface {
+	// Buscar retorna a localidade do CEP (8 dígitos, sem máscara).
+	Buscar(cep string) (Localidade, bool)
+
+	// Enriquecer confere o endereço com a localidade do seu CEP e o altera no lugar:
+	//   - cidade e UF são sempre preenchidos;
+	//   - rua e bairro vazios são preenchidos, e grafias diferentes da mesma rua ou bairro são corrigidas;
+	//   - rua diferente da rua do CEP gera o aviso cep_rua_divergente e o endereço é mantido como informado.
+	// Retorna os avisos com o que foi alterado ou não pôde ser conferido.
+	Enriquecer(end *bancofinal.Endereco) []domain.Aviso
+
+	// Total retorna quantos CEPs foram carregados.
+	Total() int
+}
@@ -0,0 +1,216 @@
+package cep
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	bancofinal "etl-service/src/config/model/banco_final"
+	"etl-service/src/exec/domain"
+)
+
+// colunas são os nomes aceitos no cabeçalho do CSV para cada campo, comparados com domain.ChaveTexto.
+var colunas = map[string][]string{
+	"cep":        {"cep"},
+	"tipo":       {"tipo", "tipo logradouro", "tipo_logradouro"},
+	"logradouro": {"logradouro", "rua", "endereco", "nome logradouro"},
+	"bairro":     {"bairro"},
+	"cidade":     {"cidade", "localidade", "municipio"},
+	"uf":         {"uf", "estado"},
+}
+
+// base é a implementação concreta da interface Base.
+type base struct {
+	porCep map[string]Localidade
+}
+
+// Carregar lê a base de CEPs de um arquivo CSV (veja NewBaseCSV).
+func Carregar(caminho string) (Base, error) {
+	arquivo, err := os.Open(caminho)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao abrir base de CEPs '%s': %w", caminho, err)
+	}
+	defer arquivo.Close()
+
+	b, err := NewBaseCSV(arquivo)
+	if err != nil {
+		return nil, fmt.Errorf("base de CEPs '%s' inválida: %w", caminho, err)
+	}
+	return b, nil
+}
+
+// NewBaseCSV monta o índice a partir de um CSV com cabeçalho, separado por vírgula, ponto e vírgula ou tabulação,
+// em UTF-8 ou Latin-1 (formato comum nas exportações da base postal).
+//
+// O cabeçalho precisa ter as colunas cep, logradouro (ou rua), bairro, cidade (ou localidade, municipio)
+// e uf (ou estado); uma coluna tipo (ex: "Rua") é prefixada ao logradouro quando presente.
+// Os nomes são padronizados como os endereços dos membros (domain.NormalizarLogradouro e domain.NormalizarNome).
+// Linhas com CEP inválido são ignoradas; um CEP repetido mantém a primeira ocorrência.
+func NewBaseCSV(r io.Reader) (Base, error) {
+	conteudo, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if !utf8.Valid(conteudo) {
+		conteudo = latin1ParaUTF8(conteudo)
+	}
+	conteudo = bytes.TrimPrefix(conteudo, []byte("\xef\xbb\xbf"))
+
+	leitor := csv.NewReader(bytes.NewReader(conteudo))
+	leitor.Comma = separador(conteudo)
+	leitor.FieldsPerRecord = -1
+	leitor.LazyQuotes = true
+	leitor.ReuseRecord = true
+
+	cabecalho, err := leitor.Read()
+	if err != nil {
+		return nil, fmt.Errorf("cabeçalho ausente: %w", err)
+	}
+	indices, err := mapearColunas(cabecalho)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &base{porCep: make(map[string]Localidade)}
+	for {
+		linha, err := leitor.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		campo := func(nome string) string {
+			i, ok := indices[nome]
+			if !ok || i >= len(linha) {
+				return ""
+			}
+			return strings.TrimSpace(linha[i])
+		}
+
+		cep, err := domain.ParseCep(campo("cep"))
+		if err != nil {
+			continue
+		}
+		if _, existe := b.porCep[cep]; existe {
+			continue
+		}
+		logradouro := campo("logradouro")
+		if tipo := campo("tipo"); tipo != "" && logradouro != "" && domain.TipoLogradouro(logradouro) == "" {
+			logradouro = tipo + " " + logradouro
+		}
+		b.porCep[cep] = Localidade{
+			Cep:        cep,
+			Logradouro: domain.NormalizarLogradouro(logradouro),
+			Bairro:     domain.NormalizarNome(campo("bairro")),
+			Cidade:     domain.NormalizarNome(campo("cidade")),
+			Uf:         strings.ToUpper(campo("uf")),
+		}
+	}
+	if len(b.porCep) == 0 {
+		return nil, errors.New("nenhum CEP válido encontrado")
+	}
+	return b, nil
+}
+
+// Buscar retorna a localidade do CEP.
+func (b *base) Buscar(cep string) (Localidade, bool) {
+	loc, ok := b.porCep[cep]
+	return loc, ok
+}
+
+// Total retorna quantos CEPs foram carregados.
+func (b *base) Total() int {
+	return len(b.porCep)
+}
+
+// Enriquecer confere e completa o endereço com a localidade do CEP.
+func (b *base) Enriquecer(end *bancofinal.Endereco) []domain.Aviso {
+	if end.Cep == "" {
+		return nil
+	}
+	loc, ok := b.porCep[end.Cep]
+	if !ok {
+		return []domain.Aviso{{Codigo: domain.AvisoCepDesconhecido, Campo: "endereco.cep", Valor: end.Cep, Mensagem: "CEP não encontrado na base de referência"}}
+	}
+
+	var avisos []domain.Aviso
+	corrigir := func(campo string, atual *string, oficial string) {
+		if oficial == "" || *atual == oficial {
+			return
+		}
+		mensagem := "corrigido para '" + oficial + "' pelo CEP " + end.Cep
+		if *atual == "" {
+			mensagem = "preenchido com '" + oficial + "' pelo CEP " + end.Cep
+		}
+		avisos = append(avisos, domain.Aviso{Codigo: domain.AvisoEnderecoCorrigido, Campo: campo, Valor: *atual, Mensagem: mensagem})
+		*atual = oficial
+	}
+
+	end.Cidade, end.Uf = loc.Cidade, loc.Uf
+
+	// Rua de outro logradouro: o CEP ou a rua está errado, e nada é corrigido além da cidade
+	if end.Rua != "" && loc.Logradouro != "" && domain.ChaveLogradouro(end.Rua) != domain.ChaveLogradouro(loc.Logradouro) {
+		return append(avisos, domain.Aviso{
+			Codigo:   domain.AvisoCepRuaDivergente,
+			Campo:    "endereco.rua",
+			Valor:    end.Rua,
+			Mensagem: fmt.Sprintf("o CEP %s corresponde a '%s'", end.Cep, loc.Logradouro),
+		})
+	}
+	corrigir("endereco.rua", &end.Rua, loc.Logradouro)
+	corrigir("endereco.bairro", &end.Bairro, loc.Bairro)
+	return avisos
+}
+
+// mapearColunas localiza no cabeçalho a posição de cada campo; apenas tipo é opcional.
+func mapearColunas(cabecalho []string) (map[string]int, error) {
+	indices := make(map[string]int)
+	for i, nome := range cabecalho {
+		chave := domain.ChaveTexto(nome)
+		for campo, aceitos := range colunas {
+			for _, aceito := range aceitos {
+				if _, ok := indices[campo]; !ok && chave == domain.ChaveTexto(aceito) {
+					indices[campo] = i
+				}
+			}
+		}
+	}
+	var faltando []string
+	for _, campo := range []string{"cep", "logradouro", "bairro", "cidade", "uf"} {
+		if _, ok := indices[campo]; !ok {
+			faltando = append(faltando, campo)
+		}
+	}
+	if len(faltando) > 0 {
+		return nil, fmt.Errorf("colunas ausentes no cabeçalho: %s", strings.Join(faltando, ", "))
+	}
+	return indices, nil
+}
+
+// separador escolhe o separador mais frequente na primeira linha.
+func separador(conteudo []byte) rune {
+	primeira, _, _ := bytes.Cut(conteudo, []byte("\n"))
+	melhor, maior := ',', bytes.Count(primeira, []byte(","))
+	for _, c := range []rune{';', '\t', '|'} {
+		if n := bytes.Count(primeira, []byte(string(c))); n > maior {
+			melhor, maior = c, n
+		}
+	}
+	return melhor
+}
+
+// latin1ParaUTF8 converte um texto em ISO-8859-1, em que cada byte é um ponto de código Unicode.
+func latin1ParaUTF8(conteudo []byte) []byte {
+	var b bytes.Buffer
+	b.Grow(len(conteudo) + len(conteudo)/10)
+	for _, c := range conteudo {
+		b.WriteRune(rune(c))
+	}
+	return b.Bytes()
+}
@@ -0,0 +1,201 @@
+package cep
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	bancofinal "etl-service/src/config/model/banco_final"
+	"etl-service/src/exec/domain"
+)
+
+// paulista é a localidade esperada para o CEP 01310-100 em todos os formatos de CSV.
+var paulista = Localidade{Cep: "01310100", Logradouro: "Avenida Paulista", Bairro: "Bela Vista", Cidade: "São Paulo", Uf: "SP"}
+
+// carregar monta a base a partir do CSV em texto.
+func carregar(t *testing.T, csv string) Base {
+	t.Helper()
+	b, err := NewBaseCSV(strings.NewReader(csv))
+	if err != nil {
+		t.Fatalf("NewBaseCSV() = %v", err)
+	}
+	return b
+}
+
+func TestNewBaseCSVSeparador(t *testing.T) {
+	for nome, sep := range map[string]string{"vírgula": ",", "ponto e vírgula": ";", "tabulação": "\t", "barra vertical": "|"} {
+		t.Run(nome, func(t *testing.T) {
+			linhas := [][]string{
+				{"cep", "logradouro", "bairro", "cidade", "uf"},
+				{"01310-100", "Av. Paulista", "BELA VISTA", "SÃO PAULO", "sp"},
+			}
+			var csv strings.Builder
+			for _, l := range linhas {
+				csv.WriteString(strings.Join(l, sep) + "\r\n")
+			}
+			if obtido, ok := carregar(t, csv.String()).Buscar("01310100"); !ok || obtido != paulista {
+				t.Errorf("Buscar() = %+v, %v, esperado %+v", obtido, ok, paulista)
+			}
+		})
+	}
+}
+
+func TestNewBaseCSVCodificacao(t *testing.T) {
+	casos := []struct {
+		nome string
+		csv  string
+	}{
+		{"UTF-8", "cep;logradouro;bairro;cidade;uf\n01310100;Avenida Paulista;Bela Vista;São Paulo;SP\n"},
+		{"UTF-8 com BOM", "\xef\xbb\xbfcep;logradouro;bairro;cidade;uf\n01310100;Avenida Paulista;Bela Vista;São Paulo;SP\n"},
+		{"Latin-1", "cep;logradouro;bairro;munic\xedpio;uf\n01310100;Avenida Paulista;Bela Vista;S\xe3o Paulo;SP\n"},
+	}
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			if obtido, ok := carregar(t, c.csv).Buscar("01310100"); !ok || obtido != paulista {
+				t.Errorf("Buscar() = %+v, %v, esperado %+v", obtido, ok, paulista)
+			}
+		})
+	}
+}
+
+func TestNewBaseCSVCabecalho(t *testing.T) {
+	casos := []struct {
+		nome string
+		csv  string
+	}{
+		{"nomes alternativos", "CEP;Rua;Bairro;Localidade;Estado\n01310100;Avenida Paulista;Bela Vista;São Paulo;SP\n"},
+		{"colunas em outra ordem", "uf,cidade,bairro,logradouro,cep\nSP,São Paulo,Bela Vista,Avenida Paulista,01310100\n"},
+		{"coluna tipo prefixada", "cep;tipo_logradouro;nome logradouro;bairro;município;uf\n01310100;Avenida;Paulista;Bela Vista;São Paulo;SP\n"},
+		{"tipo já presente no logradouro", "cep;tipo;logradouro;bairro;cidade;uf\n01310100;Avenida;Av. Paulista;Bela Vista;São Paulo;SP\n"},
+	}
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			if obtido, ok := carregar(t, c.csv).Buscar("01310100"); !ok || obtido != paulista {
+				t.Errorf("Buscar() = %+v, %v, esperado %+v", obtido, ok, paulista)
+			}
+		})
+	}
+}
+
+func TestNewBaseCSVLinhas(t *testing.T) {
+	b := carregar(t, "cep;logradouro;bairro;cidade;uf\n"+
+		"123;Rua Inválida;Centro;São Paulo;SP\n"+
+		"01310100;Avenida Paulista;Bela Vista;São Paulo;SP\n"+
+		"01310-100;Rua Repetida;Centro;São Paulo;SP\n"+
+		"13990000;;;Espírito Santo do Pinhal;SP\n")
+
+	if b.Total() != 2 {
+		t.Errorf("Total() = %d, esperado 2", b.Total())
+	}
+	if obtido, _ := b.Buscar("01310100"); obtido != paulista {
+		t.Errorf("CEP repetido: Buscar() = %+v, esperado a primeira ocorrência", obtido)
+	}
+	geral := Localidade{Cep: "13990000", Cidade: "Espírito Santo do Pinhal", Uf: "SP"}
+	if obtido, ok := b.Buscar("13990000"); !ok || obtido != geral {
+		t.Errorf("CEP geral: Buscar() = %+v, %v, esperado %+v", obtido, ok, geral)
+	}
+}
+
+func TestNewBaseCSVInvalida(t *testing.T) {
+	casos := []struct {
+		nome string
+		csv  string
+		erro string
+	}{
+		{"vazio", "", "cabeçalho ausente"},
+		{"colunas ausentes", "cep;logradouro;cidade\n01310100;Avenida Paulista;São Paulo\n", "colunas ausentes no cabeçalho: bairro, uf"},
+		{"sem CEP válido", "cep;logradouro;bairro;cidade;uf\n123;Avenida Paulista;Bela Vista;São Paulo;SP\n", "nenhum CEP válido"},
+	}
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			_, err := NewBaseCSV(strings.NewReader(c.csv))
+			if err == nil || !strings.Contains(err.Error(), c.erro) {
+				t.Errorf("NewBaseCSV() = %v, esperado erro com %q", err, c.erro)
+			}
+		})
+	}
+}
+
+func TestEnriquecer(t *testing.T) {
+	b := carregar(t, "cep;logradouro;bairro;cidade;uf\n"+
+		"01310100;Avenida Paulista;Bela Vista;São Paulo;SP\n"+
+		"13990000;;;Espírito Santo do Pinhal;SP\n")
+
+	casos := []struct {
+		nome     string
+		endereco bancofinal.Endereco
+		esperado bancofinal.Endereco
+		avisos   []string // "código campo"
+	}{
+		{
+			nome:     "sem CEP",
+			endereco: bancofinal.Endereco{Rua: "Rua Augusta", Numero: "10"},
+			esperado: bancofinal.Endereco{Rua: "Rua Augusta", Numero: "10"},
+		},
+		{
+			nome:     "CEP desconhecido",
+			endereco: bancofinal.Endereco{Cep: "99999999", Rua: "Rua Augusta"},
+			esperado: bancofinal.Endereco{Cep: "99999999", Rua: "Rua Augusta"},
+			avisos:   []string{domain.AvisoCepDesconhecido + " endereco.cep"},
+		},
+		{
+			nome:     "preenche rua, bairro, cidade e UF",
+			endereco: bancofinal.Endereco{Cep: "01310100", Numero: "1000"},
+			esperado: bancofinal.Endereco{Cep: "01310100", Rua: "Avenida Paulista", Numero: "1000", Bairro: "Bela Vista", Cidade: "São Paulo", Uf: "SP"},
+			avisos:   []string{domain.AvisoEnderecoCorrigido + " endereco.rua", domain.AvisoEnderecoCorrigido + " endereco.bairro"},
+		},
+		{
+			nome:     "corrige a grafia da mesma rua e do mesmo bairro",
+			endereco: bancofinal.Endereco{Cep: "01310100", Rua: "Paulista", Bairro: "Bela Vista ", Cidade: "Sao Paulo", Uf: "sp"},
+			esperado: bancofinal.Endereco{Cep: "01310100", Rua: "Avenida Paulista", Bairro: "Bela Vista", Cidade: "São Paulo", Uf: "SP"},
+			avisos:   []string{domain.AvisoEnderecoCorrigido + " endereco.rua", domain.AvisoEnderecoCorrigido + " endereco.bairro"},
+		},
+		{
+			nome:     "endereço igual ao do CEP",
+			endereco: bancofinal.Endereco{Cep: "01310100", Rua: "Avenida Paulista", Bairro: "Bela Vista", Cidade: "São Paulo", Uf: "SP"},
+			esperado: bancofinal.Endereco{Cep: "01310100", Rua: "Avenida Paulista", Bairro: "Bela Vista", Cidade: "São Paulo", Uf: "SP"},
+		},
+		{
+			nome:     "rua divergente mantém o endereço informado",
+			endereco: bancofinal.Endereco{Cep: "01310100", Rua: "Rua Augusta", Bairro: "Consolação"},
+			esperado: bancofinal.Endereco{Cep: "01310100", Rua: "Rua Augusta", Bairro: "Consolação", Cidade: "São Paulo", Uf: "SP"},
+			avisos:   []string{domain.AvisoCepRuaDivergente + " endereco.rua"},
+		},
+		{
+			nome:     "CEP geral só define cidade e UF",
+			endereco: bancofinal.Endereco{Cep: "13990000", Rua: "Rua das Flores", Bairro: "Centro"},
+			esperado: bancofinal.Endereco{Cep: "13990000", Rua: "Rua das Flores", Bairro: "Centro", Cidade: "Espírito Santo do Pinhal", Uf: "SP"},
+		},
+	}
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			end := c.endereco
+			var avisos []string
+			for _, a := range b.Enriquecer(&end) {
+				avisos = append(avisos, a.Codigo+" "+a.Campo)
+				if a.Valor != campoOriginal(c.endereco, a.Campo) {
+					t.Errorf("aviso %s com valor %q, esperado o valor informado %q", a.Codigo, a.Valor, campoOriginal(c.endereco, a.Campo))
+				}
+			}
+			if end != c.esperado {
+				t.Errorf("endereço = %+v, esperado %+v", end, c.esperado)
+			}
+			if !reflect.DeepEqual(avisos, c.avisos) {
+				t.Errorf("avisos = %q, esperado %q", avisos, c.avisos)
+			}
+		})
+	}
+}
+
+// campoOriginal retorna o valor do campo do endereço antes do enriquecimento.
+func campoOriginal(e bancofinal.Endereco, campo string) string {
+	switch campo {
+	case "endereco.cep":
+		return e.Cep
+	case "endereco.rua":
+		return e.Rua
+	case "endereco.bairro":
+		return e.Bairro
+	}
+	return ""
+}
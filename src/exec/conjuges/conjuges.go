@@ -0,0 +1,58 @@
+package conjuges
+
+import (
+	"context"
+	"etl-service/src/config/env"
+	"fmt"
+	"strings"
+)
+
+// Vinculador define a interface do serviço que liga cada membro ao documento do seu cônjuge,
+// quando o cônjuge também é membro, e confere a reciprocidade do casamento.
+//
+// Regras conferidas para cada casal vinculado:
+//   - Reciprocidade: o cônjuge aponta de volta para o membro.
+//   - Estado civil: os dois têm um estado civil de casado (Config.EstadosCasado).
+//   - Data de casamento: os dois informam a mesma data.
+type Vinculador interface {
+	// Vincular lê os membros do banco final, resolve o nomeConjuge de cada um para o _id do cônjuge
+	// e grava conjugeId nos documentos que mudaram (nada é gravado quando simular é true).
+	// Retorna o relatório de consistência dos casais.
+	Vincular(ctx context.Context, simular bool) (Relatorio, error)
+}
+
+// Relatorio resume a vinculação dos cônjuges.
+type Relatorio struct {
+	Vinculados      int              // Membros com o cônjuge encontrado entre os membros
+	Alterados       int              // Membros cujo conjugeId mudou (no dry-run, os que mudariam)
+	Inconsistencias []Inconsistencia // Casais com dados que não conferem, ordenados pelo nome do membro
+}
+
+// Inconsistencia descreve um problema encontrado entre um membro e o cônjuge informado por ele.
+type Inconsistencia struct {
+	Membro  string // Nome do membro
+	Conjuge string // Nome do cônjuge informado pelo membro
+	Motivo  string // O que não confere
+}
+
+// String formata a inconsistência como "membro <-> cônjuge: motivo".
+func (i Inconsistencia) String() string {
+	return fmt.Sprintf("%s <-> %s: %s", i.Membro, i.Conjuge, i.Motivo)
+}
+
+// Config define quais estados civis indicam casamento.
+type Config struct {
+	EstadosCasado []string // Estados civis (valores canônicos) considerados casados
+}
+
+// ConfigPadrao lê de ETL_ESTADOS_CASADO os estados civis de casado, separados por vírgula
+// (padrão "Casado,União Estável").
+func ConfigPadrao() Config {
+	var estados []string
+	for _, e := range strings.Split(env.GetString("ETL_ESTADOS_CASADO", "Casado,União Estável"), ",") {
+		if e = strings.TrimSpace(e); e != "" {
+			estados = append(estados, e)
+		}
+	}
+	return Config{EstadosCasado: estados}
+}
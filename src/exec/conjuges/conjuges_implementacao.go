@@ -0,0 +1,161 @@
+package conjuges
+
+import (
+	"context"
+	bancofinal "etl-service/src/config/model/banco_final"
+	"etl-service/src/exec/domain"
+	finalrepository "etl-service/src/exec/repository/final_repository"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// camposVinculo são os campos lidos do banco final para vincular e conferir os casais.
+var camposVinculo = []string{"name", "nomeConjuge", "conjugeId", "estadoCivil", "dataCasamento"}
+
+// vinculador é a implementação concreta da interface Vinculador.
+type vinculador struct {
+	final   finalrepository.FinalRepository
+	casados map[string]bool // Chaves (domain.ChaveTexto) dos estados civis de casado
+}
+
+// NewVinculador cria o serviço de vinculação de cônjuges sobre o repositório do banco final.
+func NewVinculador(final finalrepository.FinalRepository, cfg Config) Vinculador {
+	casados := make(map[string]bool, len(cfg.EstadosCasado))
+	for _, e := range cfg.EstadosCasado {
+		casados[domain.ChaveTexto(e)] = true
+	}
+	return &vinculador{final: final, casados: casados}
+}
+
+// Vincular resolve os cônjuges de todos os membros e grava apenas os conjugeId que mudaram.
+func (v *vinculador) Vincular(ctx context.Context, simular bool) (Relatorio, error) {
+	membros, err := v.final.ListarCampos(ctx, camposVinculo...)
+	if err != nil {
+		return Relatorio{}, err
+	}
+
+	conjuges, relatorio := v.resolver(membros)
+
+	alteracoes := make(map[primitive.ObjectID]*primitive.ObjectID)
+	for _, m := range membros {
+		if novo := conjuges[m.ID]; !mesmaReferencia(m.ConjugeID, novo) {
+			alteracoes[m.ID] = novo
+		}
+	}
+	relatorio.Alterados = len(alteracoes)
+
+	if simular || len(alteracoes) == 0 {
+		return relatorio, nil
+	}
+	if _, err := v.final.DefinirReferencias(ctx, "conjugeId", alteracoes); err != nil {
+		return relatorio, err
+	}
+	return relatorio, nil
+}
+
+// resolver encontra o cônjuge de cada membro pelo nome (comparado com domain.ChaveTexto)
+// e confere cada casal uma única vez. Retorna o _id do cônjuge de cada membro vinculado.
+func (v *vinculador) resolver(membros []bancofinal.Membro) (map[primitive.ObjectID]*primitive.ObjectID, Relatorio) {
+	porNome := make(map[string][]*bancofinal.Membro, len(membros))
+	for i := range membros {
+		chave := domain.ChaveTexto(membros[i].Name)
+		porNome[chave] = append(porNome[chave], &membros[i])
+	}
+
+	var relatorio Relatorio
+	conjuges := make(map[primitive.ObjectID]*primitive.ObjectID)
+	conferidos := make(map[[2]primitive.ObjectID]bool)
+	inconsistente := func(m *bancofinal.Membro, motivo string, args ...any) {
+		relatorio.Inconsistencias = append(relatorio.Inconsistencias, Inconsistencia{
+			Membro:  m.Name,
+			Conjuge: m.NomeConjuge,
+			Motivo:  fmt.Sprintf(motivo, args...),
+		})
+	}
+
+	for i := range membros {
+		m := &membros[i]
+		if m.NomeConjuge == "" {
+			continue
+		}
+		chave := domain.ChaveTexto(m.NomeConjuge)
+		if chave == domain.ChaveTexto(m.Name) {
+			inconsistente(m, "informa a si mesmo como cônjuge")
+			continue
+		}
+		candidatos := porNome[chave]
+		switch len(candidatos) {
+		case 0:
+			// O cônjuge não é membro: nada a vincular nem conferir
+			continue
+		case 1:
+		default:
+			inconsistente(m, "o nome do cônjuge corresponde a %d membros", len(candidatos))
+			continue
+		}
+
+		c := candidatos[0]
+		id := c.ID
+		conjuges[m.ID] = &id
+		relatorio.Vinculados++
+
+		par := [2]primitive.ObjectID{m.ID, c.ID}
+		if c.ID.Hex() < m.ID.Hex() {
+			par = [2]primitive.ObjectID{c.ID, m.ID}
+		}
+		if conferidos[par] {
+			continue
+		}
+		conferidos[par] = true
+
+		switch {
+		case c.NomeConjuge == "":
+			inconsistente(m, "%s não informa cônjuge", c.Name)
+		case domain.ChaveTexto(c.NomeConjuge) != domain.ChaveTexto(m.Name):
+			inconsistente(m, "%s informa outro cônjuge: %s", c.Name, c.NomeConjuge)
+		}
+		for _, pessoa := range []*bancofinal.Membro{m, c} {
+			if !v.casados[domain.ChaveTexto(pessoa.EstadoCivil)] {
+				inconsistente(m, "%s com estado civil '%s'", pessoa.Name, pessoa.EstadoCivil)
+			}
+		}
+		switch {
+		case m.DataCasamento == nil && c.DataCasamento == nil:
+			inconsistente(m, "data de casamento não informada por nenhum dos dois")
+		case m.DataCasamento == nil || c.DataCasamento == nil:
+			inconsistente(m, "data de casamento informada apenas por %s", informante(m, c).Name)
+		case !m.DataCasamento.Equal(*c.DataCasamento):
+			inconsistente(m, "datas de casamento diferentes: %s (%s) e %s (%s)",
+				formatarData(*m.DataCasamento), m.Name, formatarData(*c.DataCasamento), c.Name)
+		}
+	}
+
+	sort.SliceStable(relatorio.Inconsistencias, func(i, j int) bool {
+		return relatorio.Inconsistencias[i].Membro < relatorio.Inconsistencias[j].Membro
+	})
+	return conjuges, relatorio
+}
+
+// informante retorna, entre os dois membros, o que informou a data de casamento.
+func informante(a, b *bancofinal.Membro) *bancofinal.Membro {
+	if a.DataCasamento != nil {
+		return a
+	}
+	return b
+}
+
+// formatarData formata a data no fuso de São Paulo como "DD/MM/AAAA".
+func formatarData(t time.Time) string {
+	return t.In(domain.FusoHorario).Format("02/01/2006")
+}
+
+// mesmaReferencia compara duas referências opcionais.
+func mesmaReferencia(a, b *primitive.ObjectID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
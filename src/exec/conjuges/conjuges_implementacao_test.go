@@ -0,0 +1,157 @@
+package conjuges
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	bancofinal "etl-service/src/config/model/banco_final"
+	"etl-service/src/exec/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// data retorna a data de casamento no fuso de São Paulo.
+func data(ano int, mes time.Month, dia int) *time.Time {
+	d := time.Date(ano, mes, dia, 0, 0, 0, 0, domain.FusoHorario)
+	return &d
+}
+
+// membro cria um membro do banco final com os campos usados na vinculação.
+func membro(nome, conjuge, estadoCivil string, casamento *time.Time) bancofinal.Membro {
+	return bancofinal.Membro{
+		ID:            primitive.NewObjectID(),
+		Name:          nome,
+		NomeConjuge:   conjuge,
+		EstadoCivil:   estadoCivil,
+		DataCasamento: casamento,
+	}
+}
+
+func TestResolver(t *testing.T) {
+	casamento := data(2010, time.June, 12)
+
+	casos := []struct {
+		nome            string
+		membros         []bancofinal.Membro
+		vinculos        map[string]string // Nome do membro -> nome do cônjuge vinculado
+		inconsistencias []string
+	}{
+		{
+			nome: "casal recíproco e consistente",
+			membros: []bancofinal.Membro{
+				membro("JOÃO DA SILVA", "Maria da Silva", "Casado", casamento),
+				membro("MARIA DA SILVA", "joão da silva", "União Estável", data(2010, time.June, 12)),
+			},
+			vinculos: map[string]string{"JOÃO DA SILVA": "MARIA DA SILVA", "MARIA DA SILVA": "JOÃO DA SILVA"},
+		},
+		{
+			nome:    "cônjuge que não é membro",
+			membros: []bancofinal.Membro{membro("JOÃO DA SILVA", "ANA SOUZA", "Casado", casamento)},
+		},
+		{
+			nome: "cônjuge não informa ninguém",
+			membros: []bancofinal.Membro{
+				membro("JOÃO DA SILVA", "MARIA DA SILVA", "Casado", casamento),
+				membro("MARIA DA SILVA", "", "Casado", casamento),
+			},
+			vinculos:        map[string]string{"JOÃO DA SILVA": "MARIA DA SILVA"},
+			inconsistencias: []string{"JOÃO DA SILVA <-> MARIA DA SILVA: MARIA DA SILVA não informa cônjuge"},
+		},
+		{
+			nome: "cônjuge informa outra pessoa",
+			membros: []bancofinal.Membro{
+				membro("JOÃO DA SILVA", "MARIA DA SILVA", "Casado", casamento),
+				membro("MARIA DA SILVA", "CARLOS PEREIRA", "Casado", casamento),
+			},
+			vinculos:        map[string]string{"JOÃO DA SILVA": "MARIA DA SILVA"},
+			inconsistencias: []string{"JOÃO DA SILVA <-> MARIA DA SILVA: MARIA DA SILVA informa outro cônjuge: CARLOS PEREIRA"},
+		},
+		{
+			nome: "nome do cônjuge ambíguo",
+			membros: []bancofinal.Membro{
+				membro("JOÃO DA SILVA", "MARIA SOUZA", "Casado", casamento),
+				membro("MARIA SOUZA", "", "Solteiro", nil),
+				membro("MARIA  SOUZA", "", "Solteiro", nil),
+			},
+			inconsistencias: []string{"JOÃO DA SILVA <-> MARIA SOUZA: o nome do cônjuge corresponde a 2 membros"},
+		},
+		{
+			nome:            "membro informa a si mesmo",
+			membros:         []bancofinal.Membro{membro("JOÃO DA SILVA", "Joao da Silva", "Casado", casamento)},
+			inconsistencias: []string{"JOÃO DA SILVA <-> Joao da Silva: informa a si mesmo como cônjuge"},
+		},
+		{
+			nome: "estado civil de quem não é casado",
+			membros: []bancofinal.Membro{
+				membro("JOÃO DA SILVA", "MARIA DA SILVA", "Solteiro", casamento),
+				membro("MARIA DA SILVA", "JOÃO DA SILVA", "Viúvo", casamento),
+			},
+			vinculos: map[string]string{"JOÃO DA SILVA": "MARIA DA SILVA", "MARIA DA SILVA": "JOÃO DA SILVA"},
+			inconsistencias: []string{
+				"JOÃO DA SILVA <-> MARIA DA SILVA: JOÃO DA SILVA com estado civil 'Solteiro'",
+				"JOÃO DA SILVA <-> MARIA DA SILVA: MARIA DA SILVA com estado civil 'Viúvo'",
+			},
+		},
+		{
+			nome: "data de casamento não informada",
+			membros: []bancofinal.Membro{
+				membro("JOÃO DA SILVA", "MARIA DA SILVA", "Casado", nil),
+				membro("MARIA DA SILVA", "JOÃO DA SILVA", "Casado", nil),
+			},
+			vinculos:        map[string]string{"JOÃO DA SILVA": "MARIA DA SILVA", "MARIA DA SILVA": "JOÃO DA SILVA"},
+			inconsistencias: []string{"JOÃO DA SILVA <-> MARIA DA SILVA: data de casamento não informada por nenhum dos dois"},
+		},
+		{
+			nome: "data de casamento informada por um só",
+			membros: []bancofinal.Membro{
+				membro("JOÃO DA SILVA", "MARIA DA SILVA", "Casado", nil),
+				membro("MARIA DA SILVA", "JOÃO DA SILVA", "Casado", casamento),
+			},
+			vinculos:        map[string]string{"JOÃO DA SILVA": "MARIA DA SILVA", "MARIA DA SILVA": "JOÃO DA SILVA"},
+			inconsistencias: []string{"JOÃO DA SILVA <-> MARIA DA SILVA: data de casamento informada apenas por MARIA DA SILVA"},
+		},
+		{
+			nome: "datas de casamento diferentes",
+			membros: []bancofinal.Membro{
+				membro("JOÃO DA SILVA", "MARIA DA SILVA", "Casado", casamento),
+				membro("MARIA DA SILVA", "JOÃO DA SILVA", "Casado", data(2010, time.June, 13)),
+			},
+			vinculos: map[string]string{"JOÃO DA SILVA": "MARIA DA SILVA", "MARIA DA SILVA": "JOÃO DA SILVA"},
+			inconsistencias: []string{
+				"JOÃO DA SILVA <-> MARIA DA SILVA: datas de casamento diferentes: 12/06/2010 (JOÃO DA SILVA) e 13/06/2010 (MARIA DA SILVA)",
+			},
+		},
+	}
+
+	v := NewVinculador(nil, Config{EstadosCasado: []string{"Casado", "União Estável"}}).(*vinculador)
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			nomes := make(map[primitive.ObjectID]string, len(c.membros))
+			for _, m := range c.membros {
+				nomes[m.ID] = m.Name
+			}
+
+			conjuges, relatorio := v.resolver(c.membros)
+
+			vinculos := make(map[string]string, len(conjuges))
+			for id, conjuge := range conjuges {
+				vinculos[nomes[id]] = nomes[*conjuge]
+			}
+			if len(vinculos) != len(c.vinculos) || (len(vinculos) > 0 && !reflect.DeepEqual(vinculos, c.vinculos)) {
+				t.Errorf("vínculos = %v, esperado %v", vinculos, c.vinculos)
+			}
+			if relatorio.Vinculados != len(c.vinculos) {
+				t.Errorf("Vinculados = %d, esperado %d", relatorio.Vinculados, len(c.vinculos))
+			}
+
+			var obtido []string
+			for _, i := range relatorio.Inconsistencias {
+				obtido = append(obtido, i.String())
+			}
+			if !reflect.DeepEqual(obtido, c.inconsistencias) {
+				t.Errorf("inconsistências =\n%q\nesperado\n%q", obtido, c.inconsistencias)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package controlecarga
+
+import (
+	"context"
+	"time"
+)
+
+// ControleCarga define a interface que regula o ritmo de escrita da etapa de carga
+// no cluster de destino, combinando limite de operações por segundo e limite de concorrência.
+//
+// Cada operação de escrita deve chamar Adquirir antes de ser executada e Liberar ao terminar,
+// informando a latência observada e se o erro retornado indica sobrecarga do cluster.
+type ControleCarga interface {
+	// Adquirir bloqueia até que a operação possa ser executada, respeitando a taxa
+	// e o limite de concorrência atual. Retorna erro se o contexto for cancelado.
+	Adquirir(ctx context.Context) error
+
+	// Liberar devolve a vaga ocupada pela operação. No modo adaptativo, a latência e
+	// o indicador de sobrecarga alimentam o ajuste do limite de concorrência (AIMD).
+	Liberar(latencia time.Duration, sobrecarga bool)
+
+	// Limite retorna o limite de concorrência atual.
+	Limite() int
+
+	// Ajustes retorna quantas vezes o limite de concorrência foi alterado.
+	Ajustes() int
+}
+
+// Config define os parâmetros do controle de carga.
+type Config struct {
+	OpsPorSegundo float64       // Limite de operações por segundo (0 = sem limite)
+	Rajada        int           // Tamanho do balde de tokens (rajada máxima permitida)
+	Adaptativo    bool          // Habilita o ajuste automático da concorrência (AIMD)
+	Workers       int           // Concorrência fixa quando o modo adaptativo está desligado
+	MinWorkers    int           // Limite mínimo de concorrência no modo adaptativo
+	MaxWorkers    int           // Limite máximo de concorrência no modo adaptativo
+	LatenciaAlvo  time.Duration // Latência média acima da qual a concorrência é reduzida
+	TaxaErroMax   float64       // Proporção de erros de sobrecarga acima da qual a concorrência é reduzida
+}
@@ -0,0 +1,165 @@
+package controlecarga
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// fatorReducao é o fator multiplicativo aplicado ao limite quando o cluster mostra sinais de sobrecarga.
+const fatorReducao = 0.7
+
+// controleCarga é a implementação concreta da interface ControleCarga.
+//
+// O limite de operações por segundo usa um balde de tokens (golang.org/x/time/rate).
+// A concorrência é controlada por um semáforo cujo limite, no modo adaptativo, segue AIMD:
+// a cada janela de operações concluídas (do tamanho do limite atual), se a latência média
+// ultrapassar o alvo ou a taxa de erros de sobrecarga ultrapassar o máximo, o limite é reduzido
+// multiplicativamente; caso contrário, aumenta em uma unidade.
+type controleCarga struct {
+	cfg       Config
+	limitador *rate.Limiter // nil quando não há limite de taxa
+
+	mu       sync.Mutex
+	limite   int
+	emVoo    int
+	liberado chan struct{} // fechado e recriado sempre que uma vaga é liberada ou o limite muda
+	ajustes  int
+
+	// Janela de observação do modo adaptativo
+	janelaOps      int
+	janelaErros    int
+	janelaLatencia time.Duration
+}
+
+// NewControleCarga cria o controle de carga a partir da configuração informada.
+// No modo adaptativo o limite inicial é o mínimo configurado, crescendo conforme o cluster responde bem.
+func NewControleCarga(cfg Config) ControleCarga {
+	cfg = normalizar(cfg)
+
+	c := &controleCarga{
+		cfg:      cfg,
+		limite:   cfg.Workers,
+		liberado: make(chan struct{}),
+	}
+	if cfg.Adaptativo {
+		c.limite = cfg.MinWorkers
+	}
+	if cfg.OpsPorSegundo > 0 {
+		c.limitador = rate.NewLimiter(rate.Limit(cfg.OpsPorSegundo), cfg.Rajada)
+	}
+	return c
+}
+
+// normalizar garante valores mínimos válidos para a configuração.
+func normalizar(cfg Config) Config {
+	if cfg.Workers < 1 {
+		cfg.Workers = 1
+	}
+	if cfg.MinWorkers < 1 {
+		cfg.MinWorkers = 1
+	}
+	if cfg.MaxWorkers < cfg.MinWorkers {
+		cfg.MaxWorkers = cfg.MinWorkers
+	}
+	if cfg.Rajada < 1 {
+		cfg.Rajada = int(math.Max(1, math.Ceil(cfg.OpsPorSegundo)))
+	}
+	if cfg.TaxaErroMax <= 0 {
+		cfg.TaxaErroMax = 0.05
+	}
+	return cfg
+}
+
+// Adquirir aguarda um token do balde (se houver limite de taxa) e uma vaga de concorrência.
+func (c *controleCarga) Adquirir(ctx context.Context) error {
+	if c.limitador != nil {
+		if err := c.limitador.Wait(ctx); err != nil {
+			return err
+		}
+	}
+
+	for {
+		c.mu.Lock()
+		if c.emVoo < c.limite {
+			c.emVoo++
+			c.mu.Unlock()
+			return nil
+		}
+		aguardo := c.liberado
+		c.mu.Unlock()
+
+		select {
+		case <-aguardo:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Liberar devolve a vaga e, no modo adaptativo, registra a operação na janela de observação.
+func (c *controleCarga) Liberar(latencia time.Duration, sobrecarga bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.emVoo--
+	if c.cfg.Adaptativo {
+		c.observar(latencia, sobrecarga)
+	}
+	c.notificar()
+}
+
+// observar acumula a operação na janela e, ao completá-la, ajusta o limite (AIMD).
+// Deve ser chamado com o mutex travado.
+func (c *controleCarga) observar(latencia time.Duration, sobrecarga bool) {
+	c.janelaOps++
+	c.janelaLatencia += latencia
+	if sobrecarga {
+		c.janelaErros++
+	}
+
+	// Erros de sobrecarga reduzem imediatamente, sem esperar a janela completar
+	if c.janelaOps < c.limite && !sobrecarga {
+		return
+	}
+
+	media := c.janelaLatencia / time.Duration(c.janelaOps)
+	taxaErro := float64(c.janelaErros) / float64(c.janelaOps)
+
+	novo := c.limite
+	if taxaErro > c.cfg.TaxaErroMax || (c.cfg.LatenciaAlvo > 0 && media > c.cfg.LatenciaAlvo) {
+		novo = int(math.Floor(float64(c.limite) * fatorReducao))
+	} else if c.janelaOps >= c.limite {
+		novo = c.limite + 1
+	}
+	novo = max(c.cfg.MinWorkers, min(c.cfg.MaxWorkers, novo))
+
+	if novo != c.limite {
+		c.limite = novo
+		c.ajustes++
+	}
+	c.janelaOps, c.janelaErros, c.janelaLatencia = 0, 0, 0
+}
+
+// notificar acorda as goroutines aguardando vaga. Deve ser chamado com o mutex travado.
+func (c *controleCarga) notificar() {
+	close(c.liberado)
+	c.liberado = make(chan struct{})
+}
+
+// Limite retorna o limite de concorrência atual.
+func (c *controleCarga) Limite() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.limite
+}
+
+// Ajustes retorna quantas vezes o limite de concorrência foi alterado.
+func (c *controleCarga) Ajustes() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ajustes
+}
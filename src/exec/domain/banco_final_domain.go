@@ -1,6 +1,9 @@
 package domain
 
-import bancofinal "etl-service/src/config/model/banco_final"
+import (
+	bancofinal "etl-service/src/config/model/banco_final"
+	"fmt"
+)
 
 // BancoFinalMembroDomain define a interface para entidades de membro
 // que podem ser convertidas para o modelo bancofinal.Membro.
@@ -8,4 +11,21 @@ type BancoFinalMembroDomain interface {
 	// ToModel converte a entidade de domínio para o modelo bancofinal.Membro,
 	// que pode ser utilizado na camada de persistência ou outras camadas.
 	ToModel() bancofinal.Membro
+
+	// Avisos retorna os problemas de qualidade encontrados na conversão que não impediram
+	// o membro de ser convertido (ex: data ambígua).
+	Avisos() []Aviso
+}
+
+// Aviso descreve um valor do banco inicial aceito na conversão, mas que merece revisão.
+type Aviso struct {
+	Codigo   string // Tipo do aviso (ex: email_invalido), usado para configurar a severidade
+	Campo    string // Campo do banco inicial (ex: data_casamento)
+	Valor    string // Valor original
+	Mensagem string // O que há de errado ou como o valor foi interpretado
+}
+
+// String formata o aviso como "campo 'valor': mensagem".
+func (a Aviso) String() string {
+	return fmt.Sprintf("%s '%s': %s", a.Campo, a.Valor, a.Mensagem)
 }
@@ -14,21 +14,23 @@ import (
 // Contém os dados necessários para converter do modelo inicial para o modelo final.
 type membroDomain struct {
 	name            string          // Nome do membro
-	dataNascimento  string          // Data de nascimento (string no formato original)
+	dataNascimento  time.Time       // Data de nascimento
 	anoBatismo      int             // Ano do batismo (convertido para int)
 	sexo            string          // Sexo do membro
 	estadoCivil     string          // Estado civil do membro
-	dataCasamento   string          // Data do casamento (string)
+	dataCasamento   *time.Time      // Data do casamento (nil se não houver)
 	nomeConjuge     string          // Nome do cônjuge (string, vazio se não houver)
 	filho           bool            // Indica se o membro tem filhos (true/false)
 	email           string          // E-mail do membro
-	telefone        string          // Telefone do membro
+	telefone        string          // Telefone do membro no formato E.164 (vazio se ausente ou inválido)
+	telefoneExib    string          // Telefone do membro no formato de exibição
 	status          string          // Status atual do membro
-	dataStatus      string          // Data da última alteração do status
+	dataStatus      *time.Time      // Data da última alteração do status (nil se não informada)
 	endereco        enderecoRequest // Endereço do membro no formato interno do domínio
 	validado        bool            // Indica se o cadastro foi validado
 	dataAniversario string          // Data do aniversário no formato dia/mês (ex: "31/03")
-	dataModificacao string          // Data da modificacao no formato dia/mês (ex: "31/03")
+	dataModificacao time.Time       // Instante da conversão, usado pelo sistema de backup
+	avisos          []Aviso         // Valores aceitos que merecem revisão
 }
 
 // enderecoRequest representa o endereço usado internamente no domínio,
@@ -43,89 +45,108 @@ type enderecoRequest struct {
 
 // NewBancoFinalMembroDomain cria uma instância de membroDomain a partir de um membro do modelo inicial.
 // Converte e trata campos específicos, como data de nascimento, ano de batismo e complementos.
+// Sexo, estado civil e status são convertidos para os valores canônicos de enums (nil usa EnumsPadrao).
 // Retorna erro caso algum campo esteja em formato inválido.
-func NewBancoFinalMembroDomain(m bancoinicial.Membro) (BancoFinalMembroDomain, error) {
-	end := enderecoRequest{
-		cep:         m.Endereco.Cep,
-		rua:         m.Endereco.Rua,
-		numero:      m.Endereco.Numero,
-		bairro:      m.Endereco.Bairro,
-		complemento: "",
+func NewBancoFinalMembroDomain(m bancoinicial.Membro, enums *Enums) (BancoFinalMembroDomain, error) {
+	if enums == nil {
+		enums = EnumsPadrao()
 	}
+	var avisos []Aviso
 
-	if m.Endereco.Complemento != nil {
-		end.complemento = *m.Endereco.Complemento
-	}
+	end := getEndereco(m.Endereco, &avisos)
 
 	nomeConjuge := ""
 	if m.NomeConjuge != nil {
 		nomeConjuge = *m.NomeConjuge
 	}
 
-	// Formata a data de nascimento para o padrão dia/mês
-	dataNascimentoFormatada, err := getAniversario(m.DataNascimento)
+	// Interpreta as datas em qualquer dos formatos do banco inicial; a de nascimento é obrigatória
+	dataNascimento, err := getData("data_nascimento", m.DataNascimento, &avisos)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao formatar dataNascimento '%s': %w", m.DataNascimento, err)
 	}
+	if dataNascimento.Vazia() {
+		return nil, fmt.Errorf("erro ao formatar dataNascimento '%s': %w", m.DataNascimento, errors.New("data de nascimento não informada"))
+	}
+	dataCasamento, err := getData("data_casamento", m.DataCasamento, &avisos)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao formatar dataCasamento '%s': %w", m.DataCasamento, err)
+	}
+	dataStatus, err := getData("data_status", m.DataStatus, &avisos)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao formatar dataStatus '%s': %w", m.DataStatus, err)
+	}
 
 	// Converte o ano de batismo para inteiro, se informado
-	var dataBatismoFormatada int
-	if m.AnoBatismo != "" {
-		dataBatismoFormatada, err = getBatismo(m.AnoBatismo)
-		if err != nil {
-			return nil, fmt.Errorf("erro ao formatar dataBatismo '%s': %w", m.AnoBatismo, err)
-		}
-	} else {
-		dataBatismoFormatada = 0
+	dataBatismoFormatada, err := ParseAnoBatismo(m.AnoBatismo)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao formatar dataBatismo '%s': %w", m.AnoBatismo, err)
 	}
 
+	// Normaliza o telefone; números inválidos viram aviso e não são carregados
+	telefone := getTelefone(m.Telefone, &avisos)
+
+	// Normaliza o e-mail; endereços inválidos viram aviso e não são carregados
+	email := getEmail(m.Email, &avisos)
+
+	// Converte os campos enumerados para os valores canônicos; valores fora da tabela viram aviso e não são carregados
+	sexo := getEnum(enums, EnumSexo, "sexo", m.Sexo, &avisos)
+	estadoCivil := getEnum(enums, EnumEstadoCivil, "estado_civil", m.EstadoCivil, &avisos)
+	status := getEnum(enums, EnumStatus, "status", m.Status, &avisos)
+	filho := getFilho(m.Filho, &avisos)
+
 	// Formata o nome para mantermos um padrão a ser seguido
-	nameFormatado, err := getName(m.Name)
+	nameFormatado, err := ParseNome(m.Name)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao formatar name '%s': %w", m.Name, err)
 	}
 
-	// Captura a data atual (sempre hoje) para indicar alteração no banco de dados e facilitar o backup
+	// Captura o instante atual; ele só chega ao banco final se o conteúdo do membro mudou (veja HashConteudo)
 	dataModificacao := getDataModificacao()
 
 	return &membroDomain{
 		name:            nameFormatado,
-		dataNascimento:  m.DataNascimento,
+		dataNascimento:  dataNascimento.Valor,
 		anoBatismo:      dataBatismoFormatada,
-		sexo:            m.Sexo,
-		estadoCivil:     m.EstadoCivil,
-		dataCasamento:   m.DataCasamento,
+		sexo:            sexo,
+		estadoCivil:     estadoCivil,
+		dataCasamento:   dataCasamento.Ponteiro(),
 		nomeConjuge:     nomeConjuge,
-		filho:           m.Filho == "Sim",
-		email:           m.Email,
-		telefone:        m.Telefone,
-		status:          m.Status,
-		dataStatus:      m.DataStatus,
+		filho:           filho,
+		email:           email,
+		telefone:        telefone.e164,
+		telefoneExib:    telefone.exibicao,
+		status:          status,
+		dataStatus:      dataStatus.Ponteiro(),
 		endereco:        end,
 		validado:        m.Validado,
-		dataAniversario: dataNascimentoFormatada,
+		dataAniversario: getAniversario(dataNascimento.Valor),
 		dataModificacao: dataModificacao,
+		avisos:          avisos,
 	}, nil
 }
 
 // ToModel converte o membroDomain para o modelo final bancofinal.Membro,
 // pronto para ser utilizado na camada de repositório ou persistência.
+// O Hash fica vazio: ele deve ser calculado com HashConteudo depois de todas as alterações do membro
+// (como o enriquecimento do endereço pela base de CEPs).
 func (m *membroDomain) ToModel() bancofinal.Membro {
 	return bancofinal.Membro{
-		Name:            m.name,
-		DataNascimento:  m.dataNascimento,
-		AnoBatismo:      m.anoBatismo,
-		Sexo:            m.sexo,
-		EstadoCivil:     m.estadoCivil,
-		DataCasamento:   m.dataCasamento,
-		NomeConjuge:     m.nomeConjuge,
-		Filho:           m.filho,
-		Email:           m.email,
-		Telefone:        m.telefone,
-		Status:          m.status,
-		DataStatus:      m.dataStatus,
-		Validado:        m.validado,
-		DataModificacao: m.dataModificacao,
+		Name:             m.name,
+		DataNascimento:   m.dataNascimento,
+		AnoBatismo:       m.anoBatismo,
+		Sexo:             m.sexo,
+		EstadoCivil:      m.estadoCivil,
+		DataCasamento:    m.dataCasamento,
+		NomeConjuge:      m.nomeConjuge,
+		Filho:            m.filho,
+		Email:            m.email,
+		Telefone:         m.telefone,
+		TelefoneExibicao: m.telefoneExib,
+		Status:           m.status,
+		DataStatus:       m.dataStatus,
+		Validado:         m.validado,
+		DataModificacao:  m.dataModificacao,
 		Endereco: bancofinal.Endereco{
 			Cep:         m.endereco.cep,
 			Rua:         m.endereco.rua,
@@ -137,36 +158,161 @@ func (m *membroDomain) ToModel() bancofinal.Membro {
 	}
 }
 
-// getAniversario converte a string da data de nascimento no formato "YYYY-MM-DD"
-// para uma string formatada "DD/MM". Retorna erro se o formato for inválido.
-func getAniversario(dataNascimento string) (string, error) {
-	t, err := time.Parse("2006-01-02", dataNascimento)
+// Avisos retorna os valores aceitos na conversão que merecem revisão.
+func (m *membroDomain) Avisos() []Aviso {
+	return m.avisos
+}
+
+// getData interpreta uma data do banco inicial com ParseData e, quando a interpretação
+// é ambígua, registra um aviso com o campo e o valor original.
+func getData(campo, valor string, avisos *[]Aviso) (Data, error) {
+	data, err := ParseData(valor)
+	if err != nil {
+		return Data{}, err
+	}
+	if data.Ambigua != "" {
+		*avisos = append(*avisos, Aviso{
+			Codigo:   AvisoDataAmbigua,
+			Campo:    campo,
+			Valor:    valor,
+			Mensagem: fmt.Sprintf("data ambígua, interpretada como %s: %s", data.Valor.Format("02/01/2006"), data.Ambigua),
+		})
+	}
+	return data, nil
+}
+
+// telefoneNormalizado guarda as duas formas do telefone gravadas no modelo final.
+type telefoneNormalizado struct {
+	e164     string
+	exibicao string
+}
+
+// getTelefone normaliza o telefone com ParseTelefone. Valores inválidos são descartados
+// com um aviso; correções (como o nono dígito) também geram aviso.
+func getTelefone(valor string, avisos *[]Aviso) telefoneNormalizado {
+	if strings.TrimSpace(valor) == "" {
+		return telefoneNormalizado{}
+	}
+	t, err := ParseTelefone(valor)
+	if err != nil {
+		*avisos = append(*avisos, Aviso{Codigo: AvisoTelefoneInvalido, Campo: "telefone", Valor: valor, Mensagem: err.Error() + "; valor não carregado"})
+		return telefoneNormalizado{}
+	}
+	if t.Corrigido != "" {
+		*avisos = append(*avisos, Aviso{Codigo: AvisoTelefoneCorrigido, Campo: "telefone", Valor: valor, Mensagem: t.Corrigido})
+	}
+	return telefoneNormalizado{e164: t.E164(), exibicao: t.Exibicao()}
+}
+
+// getEmail normaliza o e-mail com NormalizarEmail. Endereços inválidos são descartados
+// com um aviso; domínios parecidos com provedores conhecidos geram aviso com a sugestão de correção.
+func getEmail(valor string, avisos *[]Aviso) string {
+	if strings.TrimSpace(valor) == "" {
+		return ""
+	}
+	e, err := NormalizarEmail(valor)
+	if err != nil {
+		*avisos = append(*avisos, Aviso{Codigo: AvisoEmailInvalido, Campo: "email", Valor: valor, Mensagem: err.Error() + "; valor não carregado"})
+		return ""
+	}
+	if e.Sugestao != "" {
+		*avisos = append(*avisos, Aviso{Codigo: AvisoEmailDominioSuspeito, Campo: "email", Valor: valor, Mensagem: "domínio parece digitado errado; você quis dizer " + e.Sugestao + "?"})
+	}
+	return e.Endereco
+}
+
+// getEnum converte o valor para o valor canônico do campo enumerado. Valores fora da tabela
+// são descartados com um aviso que lista os valores aceitos.
+func getEnum(enums *Enums, enum, campo, valor string, avisos *[]Aviso) string {
+	if strings.TrimSpace(valor) == "" {
+		return ""
+	}
+	canonico, ok := enums.Normalizar(enum, valor)
+	if !ok {
+		*avisos = append(*avisos, Aviso{
+			Codigo:   AvisoEnumNaoMapeado,
+			Campo:    campo,
+			Valor:    valor,
+			Mensagem: fmt.Sprintf("valor fora da tabela (%s); valor não carregado", strings.Join(enums.Valores(enum), ", ")),
+		})
+		return ""
+	}
+	return canonico
+}
+
+// getFilho interpreta o campo filho com ParseFilho. Valores não reconhecidos são tratados como false, com um aviso.
+func getFilho(valor string, avisos *[]Aviso) bool {
+	filho, ok := ParseFilho(valor)
+	if !ok {
+		*avisos = append(*avisos, Aviso{Codigo: AvisoEnumNaoMapeado, Campo: "filho", Valor: valor, Mensagem: "valor não reconhecido (use sim ou não); considerado não"})
+	}
+	return filho
+}
+
+// getEndereco padroniza o endereço: valida o CEP, escreve o tipo da rua e as abreviações por extenso,
+// ajusta a caixa de rua e bairro e move para o complemento o que não é número (ex: "123 apto 4").
+func getEndereco(e bancoinicial.Endereco, avisos *[]Aviso) enderecoRequest {
+	complemento := ""
+	if e.Complemento != nil {
+		complemento = NormalizarComplemento(*e.Complemento)
+	}
+	numero, extraido := ParseNumero(e.Numero)
+	return enderecoRequest{
+		cep:         getCep(e.Cep, avisos),
+		rua:         NormalizarLogradouro(e.Rua),
+		numero:      numero,
+		bairro:      NormalizarNome(e.Bairro),
+		complemento: JuntarComplementos(extraido, complemento),
+	}
+}
+
+// getCep valida o CEP com ParseCep e retorna seus 8 dígitos. CEPs inválidos são descartados com um aviso.
+func getCep(valor string, avisos *[]Aviso) string {
+	if strings.TrimSpace(valor) == "" {
+		return ""
+	}
+	cep, err := ParseCep(valor)
 	if err != nil {
-		return "", errors.New("Erro ao formatar dataNascimento (dentro da func getAniversario)")
+		*avisos = append(*avisos, Aviso{Codigo: AvisoCepInvalido, Campo: "endereco.cep", Valor: valor, Mensagem: err.Error() + "; valor não carregado"})
+		return ""
 	}
-	return t.Format("02/01"), nil
+	return cep
+}
+
+// getAniversario formata a data de nascimento como "DD/MM".
+func getAniversario(dataNascimento time.Time) string {
+	return dataNascimento.Format("02/01")
 }
 
-// getBatismo converte o ano de batismo de string para inteiro.
-// Retorna erro caso a conversão falhe.
-func getBatismo(dataBatismo string) (int, error) {
+// ErrNomeVazio indica que o membro não tem nome, o que impede a conversão.
+var ErrNomeVazio = errors.New("nome vazio")
+
+// ErrAnoBatismoInvalido indica que o ano de batismo informado não é um número inteiro.
+var ErrAnoBatismoInvalido = errors.New("erro ao converter ano de batismo para inteiro")
+
+// ParseAnoBatismo converte o ano de batismo de string para inteiro; o valor "" (não informado) vira 0.
+// O valor não é aparado: espaços em volta do número retornam ErrAnoBatismoInvalido.
+func ParseAnoBatismo(dataBatismo string) (int, error) {
+	if dataBatismo == "" {
+		return 0, nil
+	}
 	ano, err := strconv.Atoi(dataBatismo)
 	if err != nil {
-		return 0, errors.New("erro ao converter ano de batismo para inteiro")
+		return 0, ErrAnoBatismoInvalido
 	}
 	return ano, nil
 }
 
-// getName converte o name para uppercase.
-// Retorna erro caso a conversão falhe.
-func getName(name string) (string, error) {
+// ParseNome converte o name para uppercase.
+// Retorna ErrNomeVazio somente para o valor ""; um nome só com espaços é aceito como está.
+func ParseNome(name string) (string, error) {
 	if name == "" {
-		return "", errors.New("nome vazio")
+		return "", ErrNomeVazio
 	}
 	return strings.ToUpper(name), nil
 }
 
-// getDataModificacao pega a data atual para facilitar o sistema de backup e indicar de houve alteração naquele usuario
-func getDataModificacao() string {
-	return time.Now().Format("02/01/2006")
+// getDataModificacao pega o instante atual para facilitar o sistema de backup e indicar se houve alteração naquele usuario
+func getDataModificacao() time.Time {
+	return time.Now().In(FusoHorario)
 }
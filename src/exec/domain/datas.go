@@ -0,0 +1,197 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	// Embute a base de fusos horários: America/Sao_Paulo precisa existir mesmo em imagens sem tzdata
+	_ "time/tzdata"
+)
+
+// FusoHorario é o fuso em que as datas sem hora (ou sem fuso) do banco inicial são interpretadas.
+var FusoHorario = carregarFuso()
+
+// carregarFuso carrega America/Sao_Paulo da base embutida.
+func carregarFuso() *time.Location {
+	loc, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		panic(fmt.Sprintf("fuso America/Sao_Paulo indisponível: %v", err))
+	}
+	return loc
+}
+
+// FormatoData identifica em qual formato uma data do banco inicial foi encontrada.
+type FormatoData string
+
+// Formatos reconhecidos por ParseData.
+const (
+	FormatoVazio       FormatoData = "vazio"            // Valor ausente ("", "N/A", "-", "00/00/0000"...)
+	FormatoBrasileiro  FormatoData = "DD/MM/AAAA"       // Também aceita "." e "-" como separadores
+	FormatoAnoCurto    FormatoData = "DD/MM/AA"         // Ano com dois dígitos, com os mesmos separadores
+	FormatoISO         FormatoData = "AAAA-MM-DD"       // Data ISO 8601 sem hora
+	FormatoISOHora     FormatoData = "ISO 8601 c/ hora" // Data e hora ISO 8601, com ou sem fuso (a hora é descartada)
+	FormatoSerialExcel FormatoData = "serial Excel"     // Dias desde 30/12/1899, como exportado por planilhas
+)
+
+// ErrDataInvalida indica que o valor não está em nenhum formato reconhecido ou não é uma data de calendário.
+var ErrDataInvalida = errors.New("data inválida")
+
+// Data é o resultado da interpretação de uma data do banco inicial.
+type Data struct {
+	Valor   time.Time   // Data no fuso FusoHorario (zero quando o valor é vazio)
+	Formato FormatoData // Formato em que o valor foi reconhecido
+	Ambigua string      // Quando não vazio, explica por que a interpretação pode estar errada
+}
+
+// Vazia indica se o valor original representa uma data ausente.
+func (d Data) Vazia() bool {
+	return d.Formato == FormatoVazio
+}
+
+// Ponteiro retorna nil para datas vazias e um ponteiro para o valor nas demais,
+// para campos opcionais do modelo final.
+func (d Data) Ponteiro() *time.Time {
+	if d.Vazia() {
+		return nil
+	}
+	v := d.Valor
+	return &v
+}
+
+// Limites do serial do Excel: 1 é 01/01/1900 e 2958465 é 31/12/9999.
+const (
+	serialExcelMin = 1
+	serialExcelMax = 2958465
+)
+
+// epocaExcel é o dia zero do serial do Excel. Usar 30/12/1899 (e não 31/12) compensa o
+// 29/02/1900 inexistente que o Excel considera, acertando todos os seriais a partir de 01/03/1900.
+var epocaExcel = time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC)
+
+var (
+	regexDataBrasileira = regexp.MustCompile(`^(\d{1,2})[/.-](\d{1,2})[/.-](\d{4})$`)
+	regexDataAnoCurto   = regexp.MustCompile(`^(\d{1,2})[/.-](\d{1,2})[/.-](\d{2})$`)
+	regexDataISO        = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+	regexSerialExcel    = regexp.MustCompile(`^\d{1,7}(\.\d+)?$`)
+)
+
+// valoresVazios são os marcadores de data ausente encontrados no banco inicial (comparados em minúsculas).
+var valoresVazios = map[string]bool{
+	"": true, "n/a": true, "na": true, "n/d": true, "-": true, "null": true, "nil": true,
+	"00/00/0000": true, "0000-00-00": true, "0": true,
+}
+
+// layoutsISOHora são os formatos de data e hora aceitos. Somente o dia do calendário é aproveitado.
+var layoutsISOHora = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+}
+
+// ParseData interpreta uma data em qualquer dos formatos encontrados no banco inicial:
+// DD/MM/AAAA, DD/MM/AA, AAAA-MM-DD, ISO 8601 com hora, serial do Excel e marcadores de vazio.
+// Datas ISO 8601 com hora resultam na meia-noite (em FusoHorario) do dia escrito no valor.
+//
+// Valores aceitos com interpretação duvidosa são retornados com Ambigua preenchido:
+// ano com dois dígitos, dia e mês aparentemente invertidos (MM/DD/AAAA), seriais do Excel
+// que parecem um ano e seriais anteriores a 01/03/1900.
+func ParseData(valor string) (Data, error) {
+	v := strings.TrimSpace(valor)
+	if valoresVazios[strings.ToLower(v)] {
+		return Data{Formato: FormatoVazio}, nil
+	}
+
+	if p := regexDataBrasileira.FindStringSubmatch(v); p != nil {
+		return dataDiaMes(p[1], p[2], p[3], FormatoBrasileiro, "")
+	}
+
+	if p := regexDataAnoCurto.FindStringSubmatch(v); p != nil {
+		ano, _ := strconv.Atoi(p[3])
+		// Janela deslizante: anos até o ano corrente pertencem a este século, os demais ao anterior
+		if ano <= time.Now().Year()%100 {
+			ano += 2000
+		} else {
+			ano += 1900
+		}
+		return dataDiaMes(p[1], p[2], strconv.Itoa(ano), FormatoAnoCurto, fmt.Sprintf("ano com dois dígitos interpretado como %d", ano))
+	}
+
+	if regexDataISO.MatchString(v) {
+		t, err := time.ParseInLocation("2006-01-02", v, FusoHorario)
+		if err != nil {
+			return Data{}, fmt.Errorf("%w: '%s' não é uma data de calendário", ErrDataInvalida, valor)
+		}
+		return Data{Valor: t, Formato: FormatoISO}, nil
+	}
+
+	// O dia vale como escrito, no fuso do próprio valor: converter o instante para FusoHorario
+	// mudaria a data ("1990-05-10T00:00:00Z" viraria 09/05 às 21h)
+	for _, layout := range layoutsISOHora {
+		if t, err := time.ParseInLocation(layout, v, FusoHorario); err == nil {
+			return Data{Valor: time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, FusoHorario), Formato: FormatoISOHora}, nil
+		}
+	}
+
+	if regexSerialExcel.MatchString(v) {
+		return dataSerialExcel(v, valor)
+	}
+
+	return Data{}, fmt.Errorf("%w: formato não reconhecido em '%s'", ErrDataInvalida, valor)
+}
+
+// dataDiaMes monta a data a partir de dia, mês e ano. Quando o mês é maior que 12 e o dia não,
+// o valor foi provavelmente digitado como MM/DD/AAAA: dia e mês são trocados e a data é marcada como ambígua.
+func dataDiaMes(diaTexto, mesTexto, anoTexto string, formato FormatoData, ambigua string) (Data, error) {
+	dia, _ := strconv.Atoi(diaTexto)
+	mes, _ := strconv.Atoi(mesTexto)
+	ano, _ := strconv.Atoi(anoTexto)
+
+	if mes > 12 && dia <= 12 {
+		dia, mes = mes, dia
+		ambigua = juntarMotivos(ambigua, "dia e mês invertidos (MM/DD/AAAA)")
+	}
+
+	t := time.Date(ano, time.Month(mes), dia, 0, 0, 0, 0, FusoHorario)
+	// time.Date normaliza valores fora do intervalo (31/02 vira 03/03); nesse caso a data não existe
+	if t.Day() != dia || int(t.Month()) != mes || t.Year() != ano {
+		return Data{}, fmt.Errorf("%w: %02d/%02d/%04d não é uma data de calendário", ErrDataInvalida, dia, mes, ano)
+	}
+	return Data{Valor: t, Formato: formato, Ambigua: ambigua}, nil
+}
+
+// dataSerialExcel converte o número de dias do Excel (a parte fracionária é a hora do dia).
+func dataSerialExcel(v, original string) (Data, error) {
+	serial, err := strconv.ParseFloat(v, 64)
+	if err != nil || serial < serialExcelMin || serial > serialExcelMax {
+		return Data{}, fmt.Errorf("%w: '%s' fora do intervalo do serial do Excel", ErrDataInvalida, original)
+	}
+
+	dias := int(serial)
+	segundos := int((serial - float64(dias)) * 86400)
+	base := epocaExcel.AddDate(0, 0, dias).Add(time.Duration(segundos) * time.Second)
+	t := time.Date(base.Year(), base.Month(), base.Day(), base.Hour(), base.Minute(), base.Second(), 0, FusoHorario)
+
+	ambigua := ""
+	if serial < 61 {
+		ambigua = "serial do Excel anterior a 01/03/1900 (o Excel considera 29/02/1900)"
+	}
+	if dias >= 1900 && dias <= 2100 && !strings.Contains(v, ".") {
+		ambigua = juntarMotivos(ambigua, fmt.Sprintf("valor pode ser apenas o ano %d", dias))
+	}
+	return Data{Valor: t, Formato: FormatoSerialExcel, Ambigua: ambigua}, nil
+}
+
+// juntarMotivos concatena os motivos de ambiguidade não vazios.
+func juntarMotivos(a, b string) string {
+	if a == "" {
+		return b
+	}
+	return a + "; " + b
+}
@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseData(t *testing.T) {
+	data := func(ano int, mes time.Month, dia int) time.Time {
+		return time.Date(ano, mes, dia, 0, 0, 0, 0, FusoHorario)
+	}
+	casos := []struct {
+		valor    string
+		esperado time.Time
+		formato  FormatoData
+		ambigua  string // trecho esperado em Ambigua; vazio exige Ambigua vazio
+	}{
+		{"", time.Time{}, FormatoVazio, ""},
+		{"   ", time.Time{}, FormatoVazio, ""},
+		{"N/A", time.Time{}, FormatoVazio, ""},
+		{"null", time.Time{}, FormatoVazio, ""},
+		{"-", time.Time{}, FormatoVazio, ""},
+		{"00/00/0000", time.Time{}, FormatoVazio, ""},
+		{"0000-00-00", time.Time{}, FormatoVazio, ""},
+		{"0", time.Time{}, FormatoVazio, ""},
+
+		{"15/03/1985", data(1985, time.March, 15), FormatoBrasileiro, ""},
+		{" 5/3/1985 ", data(1985, time.March, 5), FormatoBrasileiro, ""},
+		{"15.03.1985", data(1985, time.March, 15), FormatoBrasileiro, ""},
+		{"15-03-1985", data(1985, time.March, 15), FormatoBrasileiro, ""},
+		{"29/02/2024", data(2024, time.February, 29), FormatoBrasileiro, ""},
+		{"03/25/1990", data(1990, time.March, 25), FormatoBrasileiro, "dia e mês invertidos"},
+		{"03/04/1990", data(1990, time.April, 3), FormatoBrasileiro, ""},
+
+		{"15/03/85", data(1985, time.March, 15), FormatoAnoCurto, "interpretado como 1985"},
+		{"15.03.85", data(1985, time.March, 15), FormatoAnoCurto, "interpretado como 1985"},
+		{"15-03-85", data(1985, time.March, 15), FormatoAnoCurto, "interpretado como 1985"},
+		{"01/01/05", data(2005, time.January, 1), FormatoAnoCurto, "interpretado como 2005"},
+		{"12/31/85", data(1985, time.December, 31), FormatoAnoCurto, "dia e mês invertidos"},
+
+		{"1985-03-15", data(1985, time.March, 15), FormatoISO, ""},
+
+		{"1985-03-15T10:30:00", data(1985, time.March, 15), FormatoISOHora, ""},
+		{"1985-03-15 10:30", data(1985, time.March, 15), FormatoISOHora, ""},
+		{"1985-03-15T13:30:00Z", data(1985, time.March, 15), FormatoISOHora, ""},
+		{"1990-05-10T00:00:00Z", data(1990, time.May, 10), FormatoISOHora, ""},
+		{"1990-05-10T23:30:00-05:00", data(1990, time.May, 10), FormatoISOHora, ""},
+
+		{"45000", data(2023, time.March, 15), FormatoSerialExcel, ""},
+		{"45000.5", time.Date(2023, time.March, 15, 12, 0, 0, 0, FusoHorario), FormatoSerialExcel, ""},
+		{"61", data(1900, time.March, 1), FormatoSerialExcel, ""},
+		{"60", data(1900, time.February, 28), FormatoSerialExcel, "anterior a 01/03/1900"},
+		{"1985", data(1905, time.June, 7), FormatoSerialExcel, "apenas o ano 1985"},
+	}
+	for _, c := range casos {
+		t.Run(c.valor, func(t *testing.T) {
+			d, err := ParseData(c.valor)
+			if err != nil {
+				t.Fatalf("ParseData(%q) = erro %v", c.valor, err)
+			}
+			if d.Formato != c.formato {
+				t.Errorf("formato = %q, esperado %q", d.Formato, c.formato)
+			}
+			if !d.Valor.Equal(c.esperado) {
+				t.Errorf("valor = %v, esperado %v", d.Valor, c.esperado)
+			}
+			if c.ambigua == "" && d.Ambigua != "" {
+				t.Errorf("ambígua = %q, esperado vazio", d.Ambigua)
+			}
+			if c.ambigua != "" && !strings.Contains(d.Ambigua, c.ambigua) {
+				t.Errorf("ambígua = %q, esperado conter %q", d.Ambigua, c.ambigua)
+			}
+			if d.Vazia() != (c.formato == FormatoVazio) || (d.Ponteiro() == nil) != d.Vazia() {
+				t.Errorf("Vazia() = %v e Ponteiro() = %v inconsistentes com o formato %q", d.Vazia(), d.Ponteiro(), d.Formato)
+			}
+		})
+	}
+}
+
+func TestParseDataInvalida(t *testing.T) {
+	for _, valor := range []string{
+		"31/02/2020",
+		"29/02/2023",
+		"32/01/2020",
+		"13/13/2020",
+		"2020-02-30",
+		"2020-13-01",
+		"15 de março de 1985",
+		"1985/03/15",
+		"abc",
+		"0.5",
+		"9999999",
+	} {
+		t.Run(valor, func(t *testing.T) {
+			d, err := ParseData(valor)
+			if !errors.Is(err, ErrDataInvalida) {
+				t.Errorf("ParseData(%q) = %+v, %v; esperado ErrDataInvalida", valor, d, err)
+			}
+		})
+	}
+}
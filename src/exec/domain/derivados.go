@@ -0,0 +1,81 @@
+package domain
+
+import (
+	bancofinal "etl-service/src/config/model/banco_final"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FaixasEtarias são as idades em que começa cada faixa etária a partir da segunda, em ordem crescente.
+// A primeira faixa começa em 0 e a última não tem limite superior (ex: [12 18] gera "0-11", "12-17" e "18+").
+type FaixasEtarias []int
+
+// FaixasEtariasPadrao retorna as faixas "0-11", "12-17", "18-29", "30-59" e "60+".
+func FaixasEtariasPadrao() FaixasEtarias {
+	return FaixasEtarias{12, 18, 30, 60}
+}
+
+// ParseFaixasEtarias interpreta as idades de início das faixas separadas por vírgula (ex: "12,18,30,60").
+// As idades devem ser positivas e crescentes; texto vazio retorna as faixas padrão.
+func ParseFaixasEtarias(texto string) (FaixasEtarias, error) {
+	if strings.TrimSpace(texto) == "" {
+		return FaixasEtariasPadrao(), nil
+	}
+	var faixas FaixasEtarias
+	for _, item := range strings.Split(texto, ",") {
+		idade, err := strconv.Atoi(strings.TrimSpace(item))
+		if err != nil || idade < 1 {
+			return nil, fmt.Errorf("idade '%s' inválida: use inteiros positivos", strings.TrimSpace(item))
+		}
+		if len(faixas) > 0 && idade <= faixas[len(faixas)-1] {
+			return nil, fmt.Errorf("as idades devem ser crescentes: %d depois de %d", idade, faixas[len(faixas)-1])
+		}
+		faixas = append(faixas, idade)
+	}
+	return faixas, nil
+}
+
+// Faixa retorna o rótulo da faixa etária da idade (ex: "18-29" ou "60+").
+func (f FaixasEtarias) Faixa(idade int) string {
+	inicio := 0
+	for _, limite := range f {
+		if idade < limite {
+			return fmt.Sprintf("%d-%d", inicio, limite-1)
+		}
+		inicio = limite
+	}
+	return fmt.Sprintf("%d+", inicio)
+}
+
+// Idade retorna a idade em anos completos na data de referência, comparando dia e mês no fuso de São Paulo.
+// Quem nasceu em 29/02 completa anos em 01/03 nos anos não bissextos.
+func Idade(nascimento, referencia time.Time) int {
+	nascimento, referencia = nascimento.In(FusoHorario), referencia.In(FusoHorario)
+	idade := referencia.Year() - nascimento.Year()
+	if referencia.Month() < nascimento.Month() ||
+		(referencia.Month() == nascimento.Month() && referencia.Day() < nascimento.Day()) {
+		idade--
+	}
+	return idade
+}
+
+// AplicarDerivados preenche no membro os campos calculados a partir de DataNascimento e AnoBatismo
+// (Idade, FaixaEtaria e AnosBatismo) na data de referência. Datas de nascimento futuras
+// e batismos ausentes (ano 0) ou futuros deixam os respectivos campos vazios.
+func AplicarDerivados(m *bancofinal.Membro, referencia time.Time, faixas FaixasEtarias) {
+	m.Idade, m.FaixaEtaria, m.AnosBatismo = nil, "", nil
+
+	if !m.DataNascimento.IsZero() {
+		if idade := Idade(m.DataNascimento, referencia); idade >= 0 {
+			m.Idade = &idade
+			m.FaixaEtaria = faixas.Faixa(idade)
+		}
+	}
+	if m.AnoBatismo > 0 {
+		if anos := referencia.In(FusoHorario).Year() - m.AnoBatismo; anos >= 0 {
+			m.AnosBatismo = &anos
+		}
+	}
+}
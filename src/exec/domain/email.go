@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+)
+
+// ErrEmailInvalido indica que o valor não é um endereço de e-mail válido.
+var ErrEmailInvalido = errors.New("e-mail inválido")
+
+// Email é um endereço de e-mail normalizado.
+type Email struct {
+	Endereco string // Endereço sem espaços e em minúsculas
+	Sugestao string // Quando não vazio, endereço com o domínio provavelmente pretendido (ex: gmial.com -> gmail.com)
+}
+
+// dominiosConhecidos são os provedores mais comuns, usados para sugerir correções de digitação.
+var dominiosConhecidos = []string{
+	"gmail.com", "hotmail.com", "hotmail.com.br", "outlook.com", "outlook.com.br", "live.com",
+	"yahoo.com", "yahoo.com.br", "icloud.com", "uol.com.br", "bol.com.br", "terra.com.br", "globo.com",
+}
+
+// dominiosLegitimos são domínios reais próximos de um provedor conhecido, que não devem gerar sugestão.
+var dominiosLegitimos = map[string]bool{
+	"mail.com": true, "email.com": true, "ig.com.br": true, "msn.com": true, "me.com": true, "r7.com": true,
+}
+
+// NormalizarEmail remove espaços, converte para minúsculas e valida a sintaxe do endereço
+// (addr-spec da RFC 5322, via net/mail), exigindo um domínio com ao menos um ponto.
+//
+// Quando o domínio está a uma ou duas letras de um provedor conhecido (gmial.com, hotmial.com,
+// gmail.con), o endereço é aceito como está e Sugestao traz a correção provável.
+func NormalizarEmail(valor string) (Email, error) {
+	endereco := strings.ToLower(strings.TrimSpace(valor))
+
+	parsed, err := mail.ParseAddress(endereco)
+	if err != nil || parsed.Name != "" || parsed.Address != endereco {
+		return Email{}, fmt.Errorf("%w: sintaxe não segue a RFC 5322", ErrEmailInvalido)
+	}
+
+	local, dominio, _ := strings.Cut(endereco, "@")
+	if !strings.Contains(dominio, ".") || strings.HasPrefix(dominio, ".") || strings.HasSuffix(dominio, ".") || strings.Contains(dominio, "..") {
+		return Email{}, fmt.Errorf("%w: domínio '%s' incompleto", ErrEmailInvalido, dominio)
+	}
+
+	e := Email{Endereco: endereco}
+	if sugestao := sugerirDominio(dominio); sugestao != "" {
+		e.Sugestao = local + "@" + sugestao
+	}
+	return e, nil
+}
+
+// sugerirDominio retorna o provedor conhecido mais próximo do domínio, se a distância de edição
+// for pequena (1 para domínios curtos, 2 para os demais); retorna vazio para domínios conhecidos ou legítimos.
+func sugerirDominio(dominio string) string {
+	if dominiosLegitimos[dominio] {
+		return ""
+	}
+	melhor, menor := "", 3
+	for _, conhecido := range dominiosConhecidos {
+		if dominio == conhecido {
+			return ""
+		}
+		if d := distanciaEdicao(dominio, conhecido); d < menor {
+			melhor, menor = conhecido, d
+		}
+	}
+	if menor == 2 && len(dominio) < 10 {
+		return ""
+	}
+	return melhor
+}
+
+// distanciaEdicao calcula a distância de Damerau-Levenshtein (inserção, remoção, troca e
+// transposição de letras adjacentes) entre dois textos ASCII.
+func distanciaEdicao(a, b string) int {
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			custo := 1
+			if a[i-1] == b[j-1] {
+				custo = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+custo)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(a)][len(b)]
+}
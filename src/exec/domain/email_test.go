@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalizarEmail(t *testing.T) {
+	casos := []struct {
+		valor    string
+		endereco string
+		sugestao string
+	}{
+		{"fulano@gmail.com", "fulano@gmail.com", ""},
+		{"  Fulano.Silva@Gmail.COM ", "fulano.silva@gmail.com", ""},
+		{"fulano+igreja@empresa.com.br", "fulano+igreja@empresa.com.br", ""},
+		{"fulano@gmial.com", "fulano@gmial.com", "fulano@gmail.com"},
+		{"fulano@gmail.con", "fulano@gmail.con", "fulano@gmail.com"},
+		{"fulano@gmaill.com", "fulano@gmaill.com", "fulano@gmail.com"},
+		{"fulano@hotmial.com", "fulano@hotmial.com", "fulano@hotmail.com"},
+		{"fulano@hotmal.com.br", "fulano@hotmal.com.br", "fulano@hotmail.com.br"},
+		{"fulano@yahoo.com.bt", "fulano@yahoo.com.bt", "fulano@yahoo.com.br"},
+		{"fulano@outlok.com", "fulano@outlok.com", "fulano@outlook.com"},
+		{"fulano@hotmail.com.br", "fulano@hotmail.com.br", ""},
+		{"fulano@mail.com", "fulano@mail.com", ""},
+		{"fulano@ig.com.br", "fulano@ig.com.br", ""},
+		{"fulano@uol.com", "fulano@uol.com", ""},
+	}
+	for _, c := range casos {
+		t.Run(c.valor, func(t *testing.T) {
+			e, err := NormalizarEmail(c.valor)
+			if err != nil {
+				t.Fatalf("NormalizarEmail(%q) = erro %v", c.valor, err)
+			}
+			if e.Endereco != c.endereco {
+				t.Errorf("endereço = %q, esperado %q", e.Endereco, c.endereco)
+			}
+			if e.Sugestao != c.sugestao {
+				t.Errorf("sugestão = %q, esperado %q", e.Sugestao, c.sugestao)
+			}
+		})
+	}
+}
+
+func TestNormalizarEmailInvalido(t *testing.T) {
+	for _, valor := range []string{
+		"",
+		"fulano",
+		"fulano@",
+		"@gmail.com",
+		"fulano@gmail",
+		"fulano@@gmail.com",
+		"fulano silva@gmail.com",
+		"Fulano <fulano@gmail.com>",
+		"fulano@.gmail.com",
+		"fulano@gmail..com",
+		"fulano@gmail.com.",
+	} {
+		t.Run(valor, func(t *testing.T) {
+			e, err := NormalizarEmail(valor)
+			if !errors.Is(err, ErrEmailInvalido) {
+				t.Errorf("NormalizarEmail(%q) = %+v, %v; esperado ErrEmailInvalido", valor, e, err)
+			}
+		})
+	}
+}
+
+func TestSugerirDominio(t *testing.T) {
+	casos := []struct {
+		dominio  string
+		esperado string
+	}{
+		{"gmail.com", ""},
+		{"gmial.com", "gmail.com"},
+		{"gnail.com", "gmail.com"},
+		{"hotmaill.com", "hotmail.com"},
+		{"hotmali.com.br", "hotmail.com.br"},
+		{"yaho.com.br", "yahoo.com.br"},
+		{"iclod.com", "icloud.com"},
+		{"bol.com", ""},
+		{"email.com", ""},
+		{"msn.com", ""},
+		{"gamil.co", ""},                // distância 2 em domínio curto
+		{"hotmaiil.con", "hotmail.com"}, // distância 2 em domínio longo
+		{"empresa.com.br", ""},
+		{"igreja.org.br", ""},
+	}
+	for _, c := range casos {
+		if obtido := sugerirDominio(c.dominio); obtido != c.esperado {
+			t.Errorf("sugerirDominio(%q) = %q, esperado %q", c.dominio, obtido, c.esperado)
+		}
+	}
+}
+
+func TestDistanciaEdicao(t *testing.T) {
+	casos := []struct {
+		a, b     string
+		esperado int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"gmail.com", "gmail.com", 0},
+		{"gmial.com", "gmail.com", 1},
+		{"gmail.con", "gmail.com", 1},
+		{"gmal.com", "gmail.com", 1},
+		{"kitten", "sitting", 3},
+	}
+	for _, c := range casos {
+		if obtido := distanciaEdicao(c.a, c.b); obtido != c.esperado {
+			t.Errorf("distanciaEdicao(%q, %q) = %d, esperado %d", c.a, c.b, obtido, c.esperado)
+		}
+		if obtido := distanciaEdicao(c.b, c.a); obtido != c.esperado {
+			t.Errorf("distanciaEdicao(%q, %q) = %d, esperado %d", c.b, c.a, obtido, c.esperado)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ErrCepInvalido indica que o valor não é um CEP válido.
+var ErrCepInvalido = errors.New("CEP inválido")
+
+// ParseCep valida um CEP com ou sem máscara ("01310-100", "01310100", "01.310-100")
+// e retorna seus 8 dígitos.
+func ParseCep(valor string) (string, error) {
+	for _, r := range strings.TrimSpace(valor) {
+		if (r < '0' || r > '9') && r != '-' && r != '.' && r != ' ' {
+			return "", fmt.Errorf("%w: caractere '%c' não permitido", ErrCepInvalido, r)
+		}
+	}
+	digitos := apenasDigitos(valor)
+	if len(digitos) != 8 {
+		return "", fmt.Errorf("%w: %d dígitos (esperado 8)", ErrCepInvalido, len(digitos))
+	}
+	if digitos == "00000000" {
+		return "", fmt.Errorf("%w: CEP zerado", ErrCepInvalido)
+	}
+	return digitos, nil
+}
+
+// semAcento troca as letras acentuadas do português pelas letras sem acento.
+var semAcento = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "õ", "o", "ö", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ç", "c", "ñ", "n",
+)
+
+// ChaveTexto reduz um texto a uma chave de comparação: minúsculas, sem acentos,
+// sem pontuação e com um único espaço entre as palavras.
+func ChaveTexto(s string) string {
+	s = semAcento.Replace(strings.ToLower(s))
+	var b strings.Builder
+	for _, r := range s {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune(' ')
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// ChaveLogradouro reduz o nome de uma rua a uma chave de comparação: o nome é padronizado com
+// NormalizarLogradouro, reduzido com ChaveTexto e o tipo do logradouro é ignorado.
+// "R. das Flores", "Rua das flores" e "DAS FLORES" têm a mesma chave, assim como "Rua Dr. Arnaldo" e "Rua Doutor Arnaldo".
+func ChaveLogradouro(s string) string {
+	chave := ChaveTexto(NormalizarLogradouro(s))
+	if tipo, resto, ok := strings.Cut(chave, " "); ok && tiposLogradouro[tipo] != "" {
+		return resto
+	}
+	return chave
+}
+
+// tiposLogradouro mapeia o tipo de logradouro, por extenso ou abreviado (na forma de ChaveTexto), para o nome por extenso.
+var tiposLogradouro = map[string]string{
+	"rua": "Rua", "r": "Rua",
+	"avenida": "Avenida", "av": "Avenida", "avda": "Avenida",
+	"travessa": "Travessa", "trav": "Travessa", "tv": "Travessa",
+	"praca": "Praça", "pca": "Praça", "pc": "Praça",
+	"alameda": "Alameda", "al": "Alameda",
+	"estrada": "Estrada", "est": "Estrada", "estr": "Estrada",
+	"rodovia": "Rodovia", "rod": "Rodovia",
+	"largo": "Largo", "lgo": "Largo",
+	"viela": "Viela", "beco": "Beco", "ladeira": "Ladeira", "lad": "Ladeira",
+}
+
+// abreviacao é a forma por extenso de uma palavra abreviada em nomes de ruas e bairros.
+type abreviacao struct {
+	extenso    string
+	exigePonto bool // Só expande quando escrita com ponto, pois a forma sem ponto também é uma palavra
+}
+
+// abreviacoes mapeia títulos e prefixos de bairro abreviados (na forma de ChaveTexto) para a forma por extenso.
+var abreviacoes = map[string]abreviacao{
+	"dr": {"Doutor", false}, "dra": {"Doutora", false}, "prof": {"Professor", false}, "profa": {"Professora", false},
+	"eng": {"Engenheiro", false}, "cel": {"Coronel", false}, "gen": {"General", false}, "mal": {"Marechal", true},
+	"cap": {"Capitão", true}, "ten": {"Tenente", true}, "sgt": {"Sargento", false}, "pres": {"Presidente", false},
+	"gov": {"Governador", false}, "des": {"Desembargador", true}, "com": {"Comendador", true}, "pe": {"Padre", true},
+	"sta": {"Santa", false}, "sto": {"Santo", false}, "sra": {"Senhora", false}, "n": {"Nossa", true},
+	"jd": {"Jardim", false}, "jdm": {"Jardim", false}, "vl": {"Vila", false}, "pq": {"Parque", false},
+	"res": {"Residencial", true}, "cj": {"Conjunto", false}, "conj": {"Conjunto", false}, "cid": {"Cidade", true},
+}
+
+// preposicoes ficam em minúsculas no meio de nomes próprios.
+var preposicoes = map[string]bool{
+	"a": true, "o": true, "as": true, "os": true, "e": true, "em": true, "com": true,
+	"da": true, "de": true, "do": true, "das": true, "dos": true,
+	"na": true, "no": true, "nas": true, "nos": true,
+}
+
+// numeraisRomanos ficam em maiúsculas (ex: Rua Pio XII).
+var numeraisRomanos = map[string]bool{
+	"i": true, "ii": true, "iii": true, "iv": true, "v": true, "vi": true, "vii": true, "viii": true, "ix": true, "x": true,
+	"xi": true, "xii": true, "xiii": true, "xiv": true, "xv": true, "xx": true, "xxi": true, "xxiii": true,
+}
+
+// NormalizarLogradouro padroniza o nome de uma rua: o tipo abreviado é escrito por extenso
+// ("R." → "Rua", "Av." → "Avenida", "Trav." → "Travessa", "Pça." → "Praça"), títulos abreviados
+// também ("Dr." → "Doutor") e as palavras ficam com a primeira letra maiúscula, exceto preposições.
+// "R. das Flores", "Rua das flores" e "RUA DAS FLORES" resultam em "Rua das Flores".
+func NormalizarLogradouro(s string) string {
+	palavras := strings.Fields(s)
+	if len(palavras) == 0 {
+		return ""
+	}
+	if tipo := TipoLogradouro(s); tipo != "" {
+		return tipo + " " + normalizarPalavras(palavras[1:], false)
+	}
+	return normalizarPalavras(palavras, true)
+}
+
+// TipoLogradouro retorna, por extenso, o tipo com que o nome da rua começa (ex: "Av. Paulista" → "Avenida"),
+// ou vazio quando o nome não começa por um tipo de logradouro.
+func TipoLogradouro(s string) string {
+	palavras := strings.Fields(s)
+	if len(palavras) < 2 {
+		return ""
+	}
+	return tiposLogradouro[ChaveTexto(palavras[0])]
+}
+
+// NormalizarNome padroniza nomes de bairros e cidades: abreviações por extenso ("Jd." → "Jardim",
+// "Vl." → "Vila") e a primeira letra de cada palavra maiúscula, exceto preposições.
+func NormalizarNome(s string) string {
+	return normalizarPalavras(strings.Fields(s), true)
+}
+
+// normalizarPalavras expande as abreviações e ajusta a caixa de cada palavra.
+// Quando inicio é true, a primeira palavra nunca é tratada como preposição.
+func normalizarPalavras(palavras []string, inicio bool) string {
+	resultado := make([]string, 0, len(palavras))
+	for i, p := range palavras {
+		chave := ChaveTexto(p)
+		if a, ok := abreviacoes[chave]; ok && (!a.exigePonto || strings.HasSuffix(p, ".")) {
+			resultado = append(resultado, a.extenso)
+			continue
+		}
+		switch {
+		case preposicoes[chave] && (i > 0 || !inicio):
+			resultado = append(resultado, strings.ToLower(p))
+		case numeraisRomanos[chave] && len(palavras) > 1:
+			resultado = append(resultado, strings.ToUpper(p))
+		default:
+			resultado = append(resultado, capitalizar(p))
+		}
+	}
+	return strings.Join(resultado, " ")
+}
+
+// capitalizar deixa maiúscula a primeira letra de cada parte de uma palavra (separadas por hífen) e minúsculas as demais.
+func capitalizar(p string) string {
+	partes := strings.Split(strings.ToLower(p), "-")
+	for i, parte := range partes {
+		runas := []rune(parte)
+		if len(runas) > 0 {
+			runas[0] = unicode.ToUpper(runas[0])
+		}
+		partes[i] = string(runas)
+	}
+	return strings.Join(partes, "-")
+}
+
+// SemNumero é o número gravado para endereços sem número ("s/n", "sn", "sem número").
+const SemNumero = "S/N"
+
+// tiposComplemento mapeia abreviações de complemento (na forma de ChaveTexto) para a forma padronizada.
+var tiposComplemento = map[string]string{
+	"ap": "Apto", "apt": "Apto", "apto": "Apto", "apartamento": "Apto",
+	"bl": "Bloco", "bloco": "Bloco", "cs": "Casa", "casa": "Casa",
+	"lj": "Loja", "loja": "Loja", "sl": "Sala", "sala": "Sala",
+	"cj": "Conjunto", "conj": "Conjunto", "fds": "Fundos", "fundos": "Fundos",
+	"lt": "Lote", "lote": "Lote", "qd": "Quadra", "quadra": "Quadra", "andar": "Andar",
+}
+
+// ParseNumero separa o número do endereço do que pertence ao complemento:
+// "123" → ("123", ""), "123A" e "123-A" → ("123", "A"), "123 apto 4" → ("123", "Apto 4"),
+// "s/n" → ("S/N", ""). Sem dígitos no início, o valor inteiro vai para o complemento.
+func ParseNumero(valor string) (numero, complemento string) {
+	valor = strings.TrimSpace(valor)
+	switch ChaveTexto(valor) {
+	case "":
+		return "", ""
+	case "s n", "sn", "sem numero", "sem n":
+		return SemNumero, ""
+	}
+
+	fim := 0
+	for fim < len(valor) && (valor[fim] >= '0' && valor[fim] <= '9' || valor[fim] == '.' && fim > 0) {
+		fim++
+	}
+	numero = strings.ReplaceAll(strings.TrimRight(valor[:fim], "."), ".", "")
+	resto := strings.TrimLeft(valor[fim:], " -,/")
+	return numero, NormalizarComplemento(resto)
+}
+
+// NormalizarComplemento padroniza o complemento: abreviações como "ap", "apt", "bl" e "cs" viram
+// "Apto", "Bloco" e "Casa", e as demais palavras ficam com a primeira letra maiúscula.
+func NormalizarComplemento(s string) string {
+	palavras := strings.Fields(strings.ReplaceAll(s, ",", " "))
+	for i, p := range palavras {
+		chave := ChaveTexto(p)
+		switch tipo, ok := tiposComplemento[chave]; {
+		case ok:
+			palavras[i] = tipo
+		case preposicoes[chave] && i > 0:
+			palavras[i] = strings.ToLower(p)
+		default:
+			palavras[i] = capitalizar(p)
+			if len([]rune(p)) == 1 || strings.ContainsAny(p, "0123456789") {
+				// Identificadores como "B" e "4b" ficam em maiúsculas
+				palavras[i] = strings.ToUpper(p)
+			}
+		}
+	}
+	return strings.Join(palavras, " ")
+}
+
+// JuntarComplementos combina o complemento extraído do número com o informado no campo complemento,
+// sem repetir o mesmo texto.
+func JuntarComplementos(extraido, informado string) string {
+	switch {
+	case extraido == "":
+		return informado
+	case informado == "" || ChaveTexto(extraido) == ChaveTexto(informado):
+		return extraido
+	default:
+		return extraido + ", " + informado
+	}
+}
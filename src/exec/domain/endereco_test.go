@@ -0,0 +1,106 @@
+package domain
+
+import "testing"
+
+func TestParseNumero(t *testing.T) {
+	casos := []struct {
+		valor       string
+		numero      string
+		complemento string
+	}{
+		{"123", "123", ""},
+		{" 123 ", "123", ""},
+		{"123A", "123", "A"},
+		{"123-A", "123", "A"},
+		{"123 b", "123", "B"},
+		{"123/4", "123", "4"},
+		{"1.234", "1234", ""},
+		{"12.", "12", ""},
+		{"123 apto 4", "123", "Apto 4"},
+		{"123, ap 42 bl B", "123", "Apto 42 Bloco B"},
+		{"45 cs 2 fundos", "45", "Casa 2 Fundos"},
+		{"45 4b", "45", "4B"},
+		{"10 sala 1 do térreo", "10", "Sala 1 do Térreo"},
+		{"s/n", SemNumero, ""},
+		{"S/N", SemNumero, ""},
+		{"sn", SemNumero, ""},
+		{"S.N.", SemNumero, ""},
+		{"sem número", SemNumero, ""},
+		{"Sem Nº", SemNumero, ""},
+		{"", "", ""},
+		{"   ", "", ""},
+		{"casa 2", "", "Casa 2"},
+		{"lote 5 quadra 3", "", "Lote 5 Quadra 3"},
+	}
+	for _, c := range casos {
+		t.Run(c.valor, func(t *testing.T) {
+			numero, complemento := ParseNumero(c.valor)
+			if numero != c.numero || complemento != c.complemento {
+				t.Errorf("ParseNumero(%q) = (%q, %q), esperado (%q, %q)", c.valor, numero, complemento, c.numero, c.complemento)
+			}
+		})
+	}
+}
+
+func TestNormalizarLogradouro(t *testing.T) {
+	casos := []struct {
+		valor    string
+		esperado string
+	}{
+		{"Rua das Flores", "Rua das Flores"},
+		{"R. das Flores", "Rua das Flores"},
+		{"rua das flores", "Rua das Flores"},
+		{"RUA DAS FLORES", "Rua das Flores"},
+		{"  r   das   flores ", "Rua das Flores"},
+		{"Av. Paulista", "Avenida Paulista"},
+		{"av paulista", "Avenida Paulista"},
+		{"Trav. São José", "Travessa São José"},
+		{"Pça. da Sé", "Praça da Sé"},
+		{"Al. Santos", "Alameda Santos"},
+		{"Estr. do Campo Limpo", "Estrada do Campo Limpo"},
+		{"Rua Dr. Arnaldo", "Rua Doutor Arnaldo"},
+		{"Rua Dr Arnaldo", "Rua Doutor Arnaldo"},
+		{"Av. N. Sra. de Fátima", "Avenida Nossa Senhora de Fátima"},
+		{"Rua Sto. Antônio", "Rua Santo Antônio"},
+		{"Rua Mal. Deodoro", "Rua Marechal Deodoro"},
+		{"Rua Mal Deodoro", "Rua Mal Deodoro"},
+		{"Rua Com. Souza", "Rua Comendador Souza"},
+		{"Rua com Souza", "Rua com Souza"},
+		{"Rua Pio XII", "Rua Pio XII"},
+		{"rua pio xii", "Rua Pio XII"},
+		{"Rua 7 de Setembro", "Rua 7 de Setembro"},
+		{"Rua Santa-rita", "Rua Santa-Rita"},
+		{"Das Flores", "Das Flores"},
+		{"do Campo", "Do Campo"},
+		{"Rua", "Rua"},
+		{"", ""},
+	}
+	for _, c := range casos {
+		t.Run(c.valor, func(t *testing.T) {
+			if obtido := NormalizarLogradouro(c.valor); obtido != c.esperado {
+				t.Errorf("NormalizarLogradouro(%q) = %q, esperado %q", c.valor, obtido, c.esperado)
+			}
+		})
+	}
+}
+
+func TestChaveLogradouro(t *testing.T) {
+	grupos := [][]string{
+		{"R. das Flores", "Rua das flores", "DAS FLORES", "rua das flôres"},
+		{"Rua Dr. Arnaldo", "Rua Doutor Arnaldo", "R Dr Arnaldo"},
+		{"Av. São João", "Avenida Sao Joao", "São João"},
+	}
+	chaves := make(map[string]int)
+	for i, grupo := range grupos {
+		esperada := ChaveLogradouro(grupo[0])
+		for _, valor := range grupo[1:] {
+			if obtida := ChaveLogradouro(valor); obtida != esperada {
+				t.Errorf("ChaveLogradouro(%q) = %q, esperado %q (mesma chave de %q)", valor, obtida, esperada, grupo[0])
+			}
+		}
+		if j, ok := chaves[esperada]; ok {
+			t.Errorf("os grupos %d e %d não deveriam ter a mesma chave %q", j, i, esperada)
+		}
+		chaves[esperada] = i
+	}
+}
@@ -0,0 +1,145 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Campos enumerados do modelo final, usados como chave da tabela de enums e na tag enum do modelo.
+const (
+	EnumSexo        = "sexo"
+	EnumEstadoCivil = "estadoCivil"
+	EnumStatus      = "status"
+)
+
+// sinonimosPadrao são os valores canônicos de cada campo enumerado e as grafias aceitas para cada um.
+// O próprio valor canônico é sempre aceito; a comparação usa ChaveTexto (sem acentos, caixa e pontuação).
+var sinonimosPadrao = map[string]map[string][]string{
+	EnumSexo: {
+		"Masculino": {"M", "Masc", "Homem", "H"},
+		"Feminino":  {"F", "Fem", "Mulher"},
+	},
+	EnumEstadoCivil: {
+		"Solteiro":      {"Solteira", "Solteiro(a)", "Solt"},
+		"Casado":        {"Casada", "Casado(a)", "Cas"},
+		"Divorciado":    {"Divorciada", "Divorciado(a)", "Div"},
+		"Separado":      {"Separada", "Separado(a)", "Separado Judicialmente", "Separada Judicialmente"},
+		"Viúvo":         {"Viúva", "Viúvo(a)"},
+		"União Estável": {"Uniao Estavel", "Amasiado", "Amasiada", "Convivente"},
+	},
+	EnumStatus: {
+		"Ativo":       {"Ativa", "A"},
+		"Inativo":     {"Inativa", "I"},
+		"Afastado":    {"Afastada"},
+		"Transferido": {"Transferida", "Transf"},
+		"Falecido":    {"Falecida", "Óbito"},
+	},
+}
+
+// sinonimosFilho são as grafias aceitas para o campo Filho do banco inicial.
+var sinonimosFilho = map[string]bool{
+	"sim": true, "s": true, "yes": true, "y": true, "true": true, "1": true, "x": true,
+	"nao": false, "n": false, "no": false, "false": false, "0": false,
+}
+
+// Enums guarda os valores canônicos de cada campo enumerado e o índice dos sinônimos.
+type Enums struct {
+	valores map[string][]string          // Campo -> valores canônicos, ordenados
+	indice  map[string]map[string]string // Campo -> chave do sinônimo -> valor canônico
+}
+
+// enumsPadrao é a tabela usada quando nenhuma é informada.
+var enumsPadrao = mustEnums(sinonimosPadrao)
+
+// EnumsPadrao retorna a tabela de enums embutida.
+func EnumsPadrao() *Enums {
+	return enumsPadrao
+}
+
+// ParseEnums interpreta uma tabela de enums em JSON, no formato
+// {"sexo": {"Masculino": ["M", "Masc"], "Feminino": ["F"]}, "status": {...}}.
+// Cada campo informado substitui por completo a tabela padrão dele; os demais mantêm a padrão.
+// Retorna erro para campos desconhecidos, valores canônicos vazios e sinônimos que levam a dois valores.
+func ParseEnums(dados []byte) (*Enums, error) {
+	var configurado map[string]map[string][]string
+	if err := json.Unmarshal(dados, &configurado); err != nil {
+		return nil, fmt.Errorf("tabela de enums inválida: %w", err)
+	}
+	tabela := make(map[string]map[string][]string, len(sinonimosPadrao))
+	for campo, valores := range sinonimosPadrao {
+		tabela[campo] = valores
+	}
+	for campo, valores := range configurado {
+		if _, ok := sinonimosPadrao[campo]; !ok {
+			return nil, fmt.Errorf("campo enumerado desconhecido: '%s' (use %s, %s ou %s)", campo, EnumSexo, EnumEstadoCivil, EnumStatus)
+		}
+		if len(valores) == 0 {
+			return nil, fmt.Errorf("campo '%s' sem valores", campo)
+		}
+		tabela[campo] = valores
+	}
+	return novoEnums(tabela)
+}
+
+// novoEnums monta o índice de sinônimos de cada campo.
+func novoEnums(tabela map[string]map[string][]string) (*Enums, error) {
+	e := &Enums{valores: make(map[string][]string), indice: make(map[string]map[string]string)}
+	for campo, valores := range tabela {
+		e.indice[campo] = make(map[string]string)
+		for canonico, sinonimos := range valores {
+			if strings.TrimSpace(canonico) == "" {
+				return nil, fmt.Errorf("campo '%s' com valor canônico vazio", campo)
+			}
+			e.valores[campo] = append(e.valores[campo], canonico)
+			for _, s := range append([]string{canonico}, sinonimos...) {
+				chave := ChaveTexto(s)
+				if outro, ok := e.indice[campo][chave]; ok && outro != canonico {
+					return nil, fmt.Errorf("campo '%s': '%s' é sinônimo de '%s' e de '%s'", campo, s, outro, canonico)
+				}
+				e.indice[campo][chave] = canonico
+			}
+		}
+		slices.Sort(e.valores[campo])
+	}
+	return e, nil
+}
+
+// mustEnums monta a tabela embutida, que é sempre válida.
+func mustEnums(tabela map[string]map[string][]string) *Enums {
+	e, err := novoEnums(tabela)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+// Normalizar retorna o valor canônico do campo correspondente ao valor informado,
+// ou false quando o valor não está na tabela.
+func (e *Enums) Normalizar(campo, valor string) (string, bool) {
+	canonico, ok := e.indice[campo][ChaveTexto(valor)]
+	return canonico, ok
+}
+
+// Valores retorna os valores canônicos do campo, em ordem alfabética.
+func (e *Enums) Valores(campo string) []string {
+	return e.valores[campo]
+}
+
+// Restricoes retorna os valores canônicos de todos os campos, no formato usado
+// pela geração do $jsonSchema (schema.Gerar).
+func (e *Enums) Restricoes() map[string][]string {
+	return e.valores
+}
+
+// ParseFilho interpreta o campo Filho do banco inicial ("Sim", "sim", "S", "Não", "N"...).
+// Vazio é false; retorna false e ok false quando o valor não é reconhecido.
+func ParseFilho(valor string) (filho bool, ok bool) {
+	chave := ChaveTexto(valor)
+	if chave == "" {
+		return false, true
+	}
+	filho, ok = sinonimosFilho[chave]
+	return filho, ok
+}
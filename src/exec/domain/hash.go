@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	bancofinal "etl-service/src/config/model/banco_final"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// HashConteudo calcula o hash SHA-256 (hexadecimal) dos campos de negócio do membro.
+//
+// DataModificacao e o próprio Hash ficam de fora, de modo que o mesmo conteúdo gera sempre o
+// mesmo hash, independentemente de quando foi convertido. O _id e as referências definidas após a
+// carga (ConjugeID e FamiliaID) também ficam de fora, pois não vêm do banco inicial, assim como os
+// campos derivados da data da execução (Idade, FaixaEtaria e AnosBatismo), recalculados a cada execução.
+// O documento é serializado em BSON, na ordem dos campos da struct, com as datas na precisão de
+// milissegundos do MongoDB.
+func HashConteudo(m bancofinal.Membro) string {
+	m.Hash = ""
+	m.DataModificacao = time.Time{}
+	m.ID = primitive.NilObjectID
+	m.ConjugeID = nil
+	m.FamiliaID = nil
+	m.Idade, m.FaixaEtaria, m.AnosBatismo = nil, "", nil
+
+	doc, err := bson.Marshal(m)
+	if err != nil {
+		// Membro é composto apenas de tipos suportados pelo BSON; um erro aqui é falha de programação
+		panic("erro ao serializar membro para o hash: " + err.Error())
+	}
+	soma := sha256.Sum256(doc)
+	return hex.EncodeToString(soma[:])
+}
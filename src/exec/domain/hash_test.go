@@ -0,0 +1,154 @@
+package domain
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	bancofinal "etl-service/src/config/model/banco_final"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// membroHash retorna um membro com todos os campos preenchidos.
+func membroHash() bancofinal.Membro {
+	casamento := time.Date(2010, time.June, 12, 0, 0, 0, 0, FusoHorario)
+	status := time.Date(2020, time.January, 5, 0, 0, 0, 0, FusoHorario)
+	return bancofinal.Membro{
+		Name:             "JOÃO DA SILVA",
+		DataNascimento:   time.Date(1985, time.March, 15, 0, 0, 0, 0, FusoHorario),
+		AnoBatismo:       2001,
+		Sexo:             "Masculino",
+		EstadoCivil:      "Casado",
+		DataCasamento:    &casamento,
+		NomeConjuge:      "MARIA DA SILVA",
+		Filho:            true,
+		Email:            "joao@exemplo.com.br",
+		Telefone:         "+5511987654321",
+		TelefoneExibicao: "(11) 98765-4321",
+		Status:           "Ativo",
+		DataStatus:       &status,
+		Validado:         true,
+		Endereco: bancofinal.Endereco{
+			Cep:         "01310100",
+			Rua:         "Avenida Paulista",
+			Numero:      "1000",
+			Bairro:      "Bela Vista",
+			Complemento: "Apto 12",
+			Cidade:      "São Paulo",
+			Uf:          "SP",
+		},
+		DataAniversario: "15/03",
+	}
+}
+
+// camposForaDoHash são os campos que não vêm do banco inicial ou dependem da data da execução.
+var camposForaDoHash = map[string]func(m *bancofinal.Membro){
+	"ID":              func(m *bancofinal.Membro) { m.ID = primitive.NewObjectID() },
+	"DataModificacao": func(m *bancofinal.Membro) { m.DataModificacao = time.Now() },
+	"Hash":            func(m *bancofinal.Membro) { m.Hash = "outro" },
+	"Idade":           func(m *bancofinal.Membro) { idade := 40; m.Idade = &idade },
+	"FaixaEtaria":     func(m *bancofinal.Membro) { m.FaixaEtaria = "30-59" },
+	"AnosBatismo":     func(m *bancofinal.Membro) { anos := 24; m.AnosBatismo = &anos },
+	"ConjugeID":       func(m *bancofinal.Membro) { id := primitive.NewObjectID(); m.ConjugeID = &id },
+	"FamiliaID":       func(m *bancofinal.Membro) { id := primitive.NewObjectID(); m.FamiliaID = &id },
+}
+
+// camposDeNegocio alteram um campo de negócio cada um; qualquer um deles muda o hash.
+var camposDeNegocio = map[string]func(m *bancofinal.Membro){
+	"Name":                 func(m *bancofinal.Membro) { m.Name = "JOÃO DA SILVA SANTOS" },
+	"DataNascimento":       func(m *bancofinal.Membro) { m.DataNascimento = m.DataNascimento.AddDate(0, 0, 1) },
+	"AnoBatismo":           func(m *bancofinal.Membro) { m.AnoBatismo = 2002 },
+	"Sexo":                 func(m *bancofinal.Membro) { m.Sexo = "Feminino" },
+	"EstadoCivil":          func(m *bancofinal.Membro) { m.EstadoCivil = "Viúvo" },
+	"DataCasamento":        func(m *bancofinal.Membro) { m.DataCasamento = nil },
+	"NomeConjuge":          func(m *bancofinal.Membro) { m.NomeConjuge = "" },
+	"Filho":                func(m *bancofinal.Membro) { m.Filho = false },
+	"Email":                func(m *bancofinal.Membro) { m.Email = "joao.silva@exemplo.com.br" },
+	"Telefone":             func(m *bancofinal.Membro) { m.Telefone = "+5511987654322" },
+	"TelefoneExibicao":     func(m *bancofinal.Membro) { m.TelefoneExibicao = "(11) 98765-4322" },
+	"Status":               func(m *bancofinal.Membro) { m.Status = "Inativo" },
+	"DataStatus":           func(m *bancofinal.Membro) { outra := m.DataStatus.AddDate(1, 0, 0); m.DataStatus = &outra },
+	"Validado":             func(m *bancofinal.Membro) { m.Validado = false },
+	"Endereco.Cep":         func(m *bancofinal.Membro) { m.Endereco.Cep = "01310200" },
+	"Endereco.Rua":         func(m *bancofinal.Membro) { m.Endereco.Rua = "Rua Augusta" },
+	"Endereco.Numero":      func(m *bancofinal.Membro) { m.Endereco.Numero = "S/N" },
+	"Endereco.Bairro":      func(m *bancofinal.Membro) { m.Endereco.Bairro = "Consolação" },
+	"Endereco.Complemento": func(m *bancofinal.Membro) { m.Endereco.Complemento = "" },
+	"Endereco.Cidade":      func(m *bancofinal.Membro) { m.Endereco.Cidade = "" },
+	"Endereco.Uf":          func(m *bancofinal.Membro) { m.Endereco.Uf = "" },
+	"DataAniversario":      func(m *bancofinal.Membro) { m.DataAniversario = "16/03" },
+}
+
+func TestHashConteudoIgnoraCamposForaDoHash(t *testing.T) {
+	esperado := HashConteudo(membroHash())
+	for campo, alterar := range camposForaDoHash {
+		t.Run(campo, func(t *testing.T) {
+			m := membroHash()
+			alterar(&m)
+			if obtido := HashConteudo(m); obtido != esperado {
+				t.Errorf("alterar %s mudou o hash", campo)
+			}
+		})
+	}
+}
+
+func TestHashConteudoMudaComCamposDeNegocio(t *testing.T) {
+	original := HashConteudo(membroHash())
+	for campo, alterar := range camposDeNegocio {
+		t.Run(campo, func(t *testing.T) {
+			m := membroHash()
+			alterar(&m)
+			if HashConteudo(m) == original {
+				t.Errorf("alterar %s não mudou o hash", campo)
+			}
+		})
+	}
+}
+
+func TestHashConteudoEstavel(t *testing.T) {
+	m := membroHash()
+	primeiro := HashConteudo(m)
+	if len(primeiro) != 64 {
+		t.Errorf("hash = %q, esperado SHA-256 hexadecimal", primeiro)
+	}
+	if HashConteudo(m) != primeiro {
+		t.Error("o mesmo membro gerou hashes diferentes")
+	}
+
+	// Datas que diferem abaixo do milissegundo são iguais depois de gravadas no MongoDB
+	m.DataNascimento = m.DataNascimento.Add(time.Microsecond)
+	if HashConteudo(m) != primeiro {
+		t.Error("diferença abaixo do milissegundo mudou o hash")
+	}
+}
+
+// TestHashConteudoCobreTodosOsCampos garante que um campo novo em Membro seja classificado
+// em um dos mapas acima, decidindo conscientemente se ele entra no hash.
+func TestHashConteudoCobreTodosOsCampos(t *testing.T) {
+	verificar := func(prefixo string, tipo reflect.Type) {
+		for i := 0; i < tipo.NumField(); i++ {
+			nome := prefixo + tipo.Field(i).Name
+			if tipo.Field(i).Type == reflect.TypeOf(bancofinal.Endereco{}) {
+				continue
+			}
+			_, fora := camposForaDoHash[nome]
+			_, negocio := camposDeNegocio[nome]
+			if !fora && !negocio {
+				t.Errorf("campo %s não está coberto pelos testes de HashConteudo", nome)
+			}
+		}
+	}
+	verificar("", reflect.TypeOf(bancofinal.Membro{}))
+	verificar("Endereco.", reflect.TypeOf(bancofinal.Endereco{}))
+
+	for nome := range camposDeNegocio {
+		if strings.HasPrefix(nome, "Endereco.") {
+			continue
+		}
+		if _, ok := reflect.TypeOf(bancofinal.Membro{}).FieldByName(nome); !ok {
+			t.Errorf("campo %s não existe em Membro", nome)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Códigos dos avisos de qualidade, usados para configurar a severidade de cada um.
+const (
+	AvisoDataAmbigua          = "data_ambigua"           // Data aceita com interpretação duvidosa
+	AvisoTelefoneInvalido     = "telefone_invalido"      // Telefone descartado por ser inválido
+	AvisoTelefoneCorrigido    = "telefone_corrigido"     // Telefone corrigido (ex: nono dígito)
+	AvisoEmailInvalido        = "email_invalido"         // E-mail descartado por sintaxe inválida
+	AvisoEmailDominioSuspeito = "email_dominio_suspeito" // Domínio parecido com um provedor conhecido (ex: gmial.com)
+	AvisoEmailCompartilhado   = "email_compartilhado"    // Mesmo e-mail usado por mais de um membro
+	AvisoCepInvalido          = "cep_invalido"           // CEP descartado por não ter 8 dígitos
+	AvisoCepDesconhecido      = "cep_desconhecido"       // CEP ausente da base de referência
+	AvisoCepRuaDivergente     = "cep_rua_divergente"     // Rua informada diferente da rua do CEP na base de referência
+	AvisoEnderecoCorrigido    = "endereco_corrigido"     // Rua, bairro, cidade ou UF preenchidos ou corrigidos pela base de CEPs
+	AvisoEnumNaoMapeado       = "enum_nao_mapeado"       // Sexo, estado civil, status ou filho fora da tabela de valores
+)
+
+// Severidade define o que o pipeline faz com um aviso de qualidade.
+type Severidade string
+
+// Severidades possíveis, da mais branda para a mais grave.
+const (
+	SeveridadeIgnorar Severidade = "ignorar" // O aviso é descartado
+	SeveridadeInfo    Severidade = "info"    // Registrado no relatório como informativo
+	SeveridadeAviso   Severidade = "aviso"   // Registrado no relatório como aviso
+	SeveridadeErro    Severidade = "erro"    // O membro falha na transformação e não é carregado
+)
+
+// SeveridadesPadrao retorna a severidade de cada código de aviso quando nada é configurado.
+func SeveridadesPadrao() map[string]Severidade {
+	return map[string]Severidade{
+		AvisoDataAmbigua:          SeveridadeAviso,
+		AvisoTelefoneInvalido:     SeveridadeAviso,
+		AvisoTelefoneCorrigido:    SeveridadeInfo,
+		AvisoEmailInvalido:        SeveridadeAviso,
+		AvisoEmailDominioSuspeito: SeveridadeAviso,
+		AvisoEmailCompartilhado:   SeveridadeInfo,
+		AvisoCepInvalido:          SeveridadeAviso,
+		AvisoCepDesconhecido:      SeveridadeAviso,
+		AvisoCepRuaDivergente:     SeveridadeAviso,
+		AvisoEnderecoCorrigido:    SeveridadeInfo,
+		AvisoEnumNaoMapeado:       SeveridadeAviso,
+	}
+}
+
+// ParseSeveridades interpreta uma lista "codigo=severidade" separada por vírgulas
+// (ex: "email_compartilhado=erro,telefone_corrigido=ignorar") sobre as severidades padrão.
+func ParseSeveridades(texto string) (map[string]Severidade, error) {
+	severidades := SeveridadesPadrao()
+	for _, item := range strings.Split(texto, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		codigo, valor, ok := strings.Cut(item, "=")
+		codigo, valor = strings.TrimSpace(codigo), strings.ToLower(strings.TrimSpace(valor))
+		if !ok {
+			return nil, fmt.Errorf("severidade '%s' deve estar no formato codigo=severidade", item)
+		}
+		if _, conhecido := severidades[codigo]; !conhecido {
+			return nil, fmt.Errorf("código de aviso desconhecido: '%s'", codigo)
+		}
+		switch s := Severidade(valor); s {
+		case SeveridadeIgnorar, SeveridadeInfo, SeveridadeAviso, SeveridadeErro:
+			severidades[codigo] = s
+		default:
+			return nil, fmt.Errorf("severidade desconhecida '%s' para '%s' (use ignorar, info, aviso ou erro)", valor, codigo)
+		}
+	}
+	return severidades, nil
+}
@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// TipoTelefone distingue telefones fixos de celulares.
+type TipoTelefone string
+
+// Tipos de telefone reconhecidos por ParseTelefone.
+const (
+	TelefoneFixo    TipoTelefone = "fixo"    // 8 dígitos, iniciando de 2 a 5
+	TelefoneCelular TipoTelefone = "celular" // 9 dígitos, iniciando com o nono dígito 9
+)
+
+// ErrTelefoneInvalido indica que o valor não é um telefone brasileiro válido.
+var ErrTelefoneInvalido = errors.New("telefone inválido")
+
+// Telefone é um número brasileiro normalizado.
+type Telefone struct {
+	DDD       string       // Código de área (2 dígitos)
+	Numero    string       // Número sem o DDD (8 ou 9 dígitos)
+	Tipo      TipoTelefone // Fixo ou celular
+	Corrigido string       // Quando não vazio, descreve a correção aplicada ao valor original
+}
+
+// E164 retorna o número no formato internacional E.164 (ex: +5511987654321).
+func (t Telefone) E164() string {
+	return "+55" + t.DDD + t.Numero
+}
+
+// Exibicao retorna o número no formato de exibição nacional (ex: (11) 98765-4321).
+func (t Telefone) Exibicao() string {
+	corte := len(t.Numero) - 4
+	return fmt.Sprintf("(%s) %s-%s", t.DDD, t.Numero[:corte], t.Numero[corte:])
+}
+
+// dddsValidos são os códigos de área em uso no Brasil, definidos pela Anatel.
+var dddsValidos = map[string]bool{
+	"11": true, "12": true, "13": true, "14": true, "15": true, "16": true, "17": true, "18": true, "19": true,
+	"21": true, "22": true, "24": true, "27": true, "28": true,
+	"31": true, "32": true, "33": true, "34": true, "35": true, "37": true, "38": true,
+	"41": true, "42": true, "43": true, "44": true, "45": true, "46": true, "47": true, "48": true, "49": true,
+	"51": true, "53": true, "54": true, "55": true,
+	"61": true, "62": true, "63": true, "64": true, "65": true, "66": true, "67": true, "68": true, "69": true,
+	"71": true, "73": true, "74": true, "75": true, "77": true, "79": true,
+	"81": true, "82": true, "83": true, "84": true, "85": true, "86": true, "87": true, "88": true, "89": true,
+	"91": true, "92": true, "93": true, "94": true, "95": true, "96": true, "97": true, "98": true, "99": true,
+}
+
+// ParseTelefone interpreta um telefone brasileiro em qualquer formatação: com ou sem máscara,
+// com código do país (+55 ou 55), com zero de longa distância e código de operadora (0 XX DDD),
+// e com ou sem o nono dígito.
+//
+// Celulares antigos, com 8 dígitos iniciando de 6 a 9, são corrigidos com o nono dígito e
+// retornados com Corrigido preenchido. Retorna ErrTelefoneInvalido quando o DDD não existe,
+// o DDD está ausente ou a quantidade de dígitos não corresponde a fixo nem a celular.
+func ParseTelefone(valor string) (Telefone, error) {
+	digitos := apenasDigitos(valor)
+	internacional := strings.HasPrefix(strings.TrimSpace(valor), "+")
+
+	switch {
+	case internacional && strings.HasPrefix(digitos, "55"):
+		digitos = digitos[2:]
+	case internacional:
+		return Telefone{}, fmt.Errorf("%w: código de país diferente de +55", ErrTelefoneInvalido)
+	case (len(digitos) == 12 || len(digitos) == 13) && strings.HasPrefix(digitos, "55"):
+		digitos = digitos[2:]
+	case (len(digitos) == 13 || len(digitos) == 14) && strings.HasPrefix(digitos, "0"):
+		// Discagem de longa distância com código de operadora: 0 + operadora (2 dígitos) + DDD + número
+		digitos = digitos[3:]
+	case (len(digitos) == 11 || len(digitos) == 12) && strings.HasPrefix(digitos, "0"):
+		digitos = digitos[1:]
+	}
+
+	if len(digitos) == 8 || len(digitos) == 9 {
+		return Telefone{}, fmt.Errorf("%w: sem DDD", ErrTelefoneInvalido)
+	}
+	if len(digitos) != 10 && len(digitos) != 11 {
+		return Telefone{}, fmt.Errorf("%w: %d dígitos", ErrTelefoneInvalido, len(digitos))
+	}
+
+	t := Telefone{DDD: digitos[:2], Numero: digitos[2:]}
+	if !dddsValidos[t.DDD] {
+		return Telefone{}, fmt.Errorf("%w: DDD %s inexistente", ErrTelefoneInvalido, t.DDD)
+	}
+
+	switch primeiro := t.Numero[0]; {
+	case len(t.Numero) == 9 && primeiro == '9':
+		t.Tipo = TelefoneCelular
+	case len(t.Numero) == 9:
+		return Telefone{}, fmt.Errorf("%w: celular de 9 dígitos deve iniciar com 9", ErrTelefoneInvalido)
+	case primeiro >= '2' && primeiro <= '5':
+		t.Tipo = TelefoneFixo
+	case primeiro >= '6' && primeiro <= '9':
+		t.Numero = "9" + t.Numero
+		t.Tipo = TelefoneCelular
+		t.Corrigido = "celular sem o nono dígito, corrigido para " + t.Exibicao()
+	default:
+		return Telefone{}, fmt.Errorf("%w: número iniciando com %c", ErrTelefoneInvalido, primeiro)
+	}
+	return t, nil
+}
+
+// apenasDigitos remove tudo que não é dígito.
+func apenasDigitos(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseTelefone(t *testing.T) {
+	casos := []struct {
+		valor     string
+		e164      string
+		exibicao  string
+		tipo      TipoTelefone
+		corrigido bool
+	}{
+		{"(11) 98765-4321", "+5511987654321", "(11) 98765-4321", TelefoneCelular, false},
+		{"11987654321", "+5511987654321", "(11) 98765-4321", TelefoneCelular, false},
+		{"(11) 3222-1234", "+551132221234", "(11) 3222-1234", TelefoneFixo, false},
+		{"+55 11 98765-4321", "+5511987654321", "(11) 98765-4321", TelefoneCelular, false},
+		{"+55 (11) 3222-1234", "+551132221234", "(11) 3222-1234", TelefoneFixo, false},
+		{"55 11 98765 4321", "+5511987654321", "(11) 98765-4321", TelefoneCelular, false},
+		{"551132221234", "+551132221234", "(11) 3222-1234", TelefoneFixo, false},
+
+		// Zero de longa distância, com e sem código de operadora
+		{"011 98765-4321", "+5511987654321", "(11) 98765-4321", TelefoneCelular, false},
+		{"0 11 3222-1234", "+551132221234", "(11) 3222-1234", TelefoneFixo, false},
+		{"0 21 11 98765-4321", "+5511987654321", "(11) 98765-4321", TelefoneCelular, false},
+		{"0xx15 11 3222-1234", "+551132221234", "(11) 3222-1234", TelefoneFixo, false},
+		{"0 41 21 8765-4321", "+5521987654321", "(21) 98765-4321", TelefoneCelular, true},
+
+		// Nono dígito ausente em celulares antigos
+		{"(21) 8765-4321", "+5521987654321", "(21) 98765-4321", TelefoneCelular, true},
+		{"(31) 6123-4567", "+5531961234567", "(31) 96123-4567", TelefoneCelular, true},
+		{"+55 21 8765-4321", "+5521987654321", "(21) 98765-4321", TelefoneCelular, true},
+
+		// DDD 55 (RS) não é confundido com o código do país
+		{"(55) 98765-4321", "+5555987654321", "(55) 98765-4321", TelefoneCelular, false},
+		{"55987654321", "+5555987654321", "(55) 98765-4321", TelefoneCelular, false},
+		{"(55) 3222-1234", "+555532221234", "(55) 3222-1234", TelefoneFixo, false},
+		{"5555987654321", "+5555987654321", "(55) 98765-4321", TelefoneCelular, false},
+		{"+55 55 3222-1234", "+555532221234", "(55) 3222-1234", TelefoneFixo, false},
+		{"555532221234", "+555532221234", "(55) 3222-1234", TelefoneFixo, false},
+	}
+	for _, c := range casos {
+		t.Run(c.valor, func(t *testing.T) {
+			tel, err := ParseTelefone(c.valor)
+			if err != nil {
+				t.Fatalf("ParseTelefone(%q) = erro %v", c.valor, err)
+			}
+			if tel.E164() != c.e164 {
+				t.Errorf("E164() = %q, esperado %q", tel.E164(), c.e164)
+			}
+			if tel.Exibicao() != c.exibicao {
+				t.Errorf("Exibicao() = %q, esperado %q", tel.Exibicao(), c.exibicao)
+			}
+			if tel.Tipo != c.tipo {
+				t.Errorf("tipo = %q, esperado %q", tel.Tipo, c.tipo)
+			}
+			if (tel.Corrigido != "") != c.corrigido {
+				t.Errorf("corrigido = %q, esperado correção: %v", tel.Corrigido, c.corrigido)
+			}
+		})
+	}
+}
+
+func TestParseTelefoneInvalido(t *testing.T) {
+	casos := []struct {
+		valor  string
+		motivo string
+	}{
+		{"98765-4321", "sem DDD"},
+		{"3222-1234", "sem DDD"},
+		{"(20) 98765-4321", "DDD 20 inexistente"},
+		{"(10) 3222-1234", "DDD 10 inexistente"},
+		{"+1 415 555 0100", "código de país"},
+		{"+351 21 123 4567", "código de país"},
+		{"(11) 88765-4321", "deve iniciar com 9"},
+		{"(11) 1222-1234", "iniciando com 1"},
+		{"(11) 0222-1234", "iniciando com 0"},
+		{"123", "3 dígitos"},
+		{"", "0 dígitos"},
+		{"(11) 98765-43210", "12 dígitos"},
+	}
+	for _, c := range casos {
+		t.Run(c.valor, func(t *testing.T) {
+			tel, err := ParseTelefone(c.valor)
+			if !errors.Is(err, ErrTelefoneInvalido) {
+				t.Fatalf("ParseTelefone(%q) = %+v, %v; esperado ErrTelefoneInvalido", c.valor, tel, err)
+			}
+			if !strings.Contains(err.Error(), c.motivo) {
+				t.Errorf("erro = %q, esperado conter %q", err, c.motivo)
+			}
+		})
+	}
+}
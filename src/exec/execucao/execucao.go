@@ -0,0 +1,77 @@
+package execucao
+
+import (
+	"context"
+	"errors"
+	"etl-service/src/config/jobs"
+	"etl-service/src/config/model/historico"
+	getdata "etl-service/src/exec/get_data"
+)
+
+// Erros retornados pelo Gerenciador, usados pela API para escolher o status HTTP.
+var (
+	ErrJobDesconhecido        = errors.New("job desconhecido")
+	ErrJobEmExecucao          = errors.New("outra execução já está carregando o banco final")
+	ErrExecucaoNaoEncontrada  = errors.New("execução não encontrada")
+	ErrExecucaoFinalizada     = errors.New("execução já finalizada")
+	ErrExecucaoEmAndamento    = errors.New("execução em andamento: ocorrências disponíveis ao final")
+	ErrExecucaoOutraInstancia = errors.New("execução em andamento em outra instância do serviço")
+	ErrGerenciadorEncerrando  = errors.New("gerenciador em encerramento: novas execuções não são aceitas")
+)
+
+// Origens de uma execução registradas no histórico.
+const (
+	OrigemAPI       = "api"       // Disparada pela API de controle
+	OrigemAgendador = "agendador" // Disparada pelo agendador do daemon
+	OrigemCLI       = "cli"       // Executada pelo subcomando run
+)
+
+// Opcoes sobrescreve, para uma execução, as opções definidas no job.
+// Campos nil mantêm o valor do job.
+type Opcoes struct {
+	DryRun      *bool  // Executa sem gravar no banco final
+	Incremental *bool  // Lê apenas os membros modificados desde a última execução concluída do job
+	Origem      string // Quem disparou a execução (padrão: api)
+}
+
+// Estado é a situação de uma execução: o registro do histórico e, enquanto ela
+// ocorre neste processo, o progresso atual.
+type Estado struct {
+	historico.Execucao
+	Progresso *getdata.ProgressoAtual `json:"progresso,omitempty"` // Presente apenas em execuções em andamento
+}
+
+// Pagina é uma página de ocorrências (duplicados e falhas) de uma execução.
+type Pagina struct {
+	Itens   []historico.Ocorrencia `json:"itens"`
+	Total   int64                  `json:"total"`   // Total de ocorrências que atendem ao filtro
+	Pagina  int                    `json:"pagina"`  // Página atual, a partir de 1
+	Tamanho int                    `json:"tamanho"` // Itens por página
+}
+
+// Gerenciador define a interface do serviço que dispara, acompanha e cancela execuções do ETL
+// em segundo plano, registrando cada uma no histórico de execuções.
+type Gerenciador interface {
+	// Jobs retorna os jobs disponíveis.
+	Jobs() []jobs.Job
+
+	// Iniciar dispara em segundo plano uma execução do job informado e retorna o registro inicial.
+	// Retorna ErrJobDesconhecido se o job não existir e ErrJobEmExecucao se uma execução de qualquer job
+	// já estiver carregando o mesmo banco final.
+	Iniciar(ctx context.Context, job string, opcoes Opcoes) (historico.Execucao, error)
+
+	// Estado retorna a situação da execução, ou ErrExecucaoNaoEncontrada.
+	Estado(ctx context.Context, id string) (Estado, error)
+
+	// Ocorrencias pagina os duplicados e as falhas de uma execução finalizada, opcionalmente filtrados por tipo.
+	// Retorna ErrExecucaoEmAndamento enquanto a execução ainda não terminou.
+	Ocorrencias(ctx context.Context, id, tipo string, pagina, tamanho int) (Pagina, error)
+
+	// Cancelar interrompe uma execução em andamento neste processo.
+	// Retorna ErrExecucaoFinalizada se ela já terminou e ErrExecucaoNaoEncontrada se não existir.
+	Cancelar(ctx context.Context, id string) error
+
+	// Encerrar recusa novas execuções, cancela as que estão em andamento e aguarda
+	// até que todas registrem o resultado no histórico.
+	Encerrar()
+}
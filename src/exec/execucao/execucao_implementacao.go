@@ -0,0 +1,399 @@
+package execucao
+
+import (
+	"context"
+	"errors"
+	"etl-service/src/config/jobs"
+	"etl-service/src/config/logger"
+	"etl-service/src/config/metricas"
+	"etl-service/src/config/model/historico"
+	getdata "etl-service/src/exec/get_data"
+	"etl-service/src/exec/notificacao"
+	familiarepository "etl-service/src/exec/repository/familia_repository"
+	finalrepository "etl-service/src/exec/repository/final_repository"
+	historicorepository "etl-service/src/exec/repository/historico_repository"
+	inicialrepository "etl-service/src/exec/repository/inicial_repository"
+	"etl-service/src/exec/trava"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// execucaoAtiva guarda o estado em memória de uma execução em andamento neste processo.
+type execucaoAtiva struct {
+	registro  historico.Execucao
+	recurso   string // Recurso da trava distribuída detido pela execução
+	progresso *getdata.Progresso
+	lease     *trava.Lease
+	cancelar  context.CancelFunc
+	feito     chan struct{} // Fechado após o resultado ser gravado no histórico
+}
+
+// gerenciador é a implementação concreta da interface Gerenciador.
+type gerenciador struct {
+	inicial   inicialrepository.InicialRepository
+	final     finalrepository.FinalRepository
+	familias  familiarepository.FamiliaRepository
+	historico historicorepository.HistoricoRepository
+	trava     trava.Trava
+	notificar notificacao.Notificador
+	jobs      []jobs.Job
+	cfg       getdata.Config
+
+	mu         sync.Mutex
+	ativas     map[string]*execucaoAtiva // Execuções em andamento, indexadas pelo ID
+	porRecurso map[string]string         // Recurso da trava (destino da carga) -> ID da execução em andamento
+	encerrando bool
+}
+
+// NewGerenciador cria o gerenciador de execuções, recebendo os repositórios, a trava distribuída
+// (que impede outra instância de carregar o mesmo banco final), o notificador do resultado das execuções,
+// os jobs disponíveis e a configuração do pipeline usada como base em todas as execuções.
+func NewGerenciador(
+	inicial inicialrepository.InicialRepository,
+	final finalrepository.FinalRepository,
+	familias familiarepository.FamiliaRepository,
+	historico historicorepository.HistoricoRepository,
+	trava trava.Trava,
+	notificador notificacao.Notificador,
+	lista []jobs.Job,
+	cfg getdata.Config,
+) Gerenciador {
+	return &gerenciador{
+		inicial:    inicial,
+		final:      final,
+		familias:   familias,
+		historico:  historico,
+		trava:      trava,
+		notificar:  notificador,
+		jobs:       lista,
+		cfg:        cfg,
+		ativas:     make(map[string]*execucaoAtiva),
+		porRecurso: make(map[string]string),
+	}
+}
+
+// Jobs retorna os jobs disponíveis.
+func (g *gerenciador) Jobs() []jobs.Job {
+	return g.jobs
+}
+
+// Iniciar resolve as opções do job, calcula a janela incremental a partir do histórico,
+// adquire a trava do destino da carga, grava o registro inicial e dispara o pipeline em uma goroutine.
+//
+// Todos os jobs carregam a mesma coleção do banco final e, por isso, disputam a mesma trava: enquanto
+// uma execução de qualquer job estiver em andamento, neste processo ou em outra instância, as demais são
+// recusadas. Quando outra instância detém a trava, retorna um erro que satisfaz tanto
+// ErrJobEmExecucao quanto trava.ErrOcupada.
+//
+// A execução não é cancelada junto com ctx (normalmente o contexto da requisição HTTP),
+// mas herda os valores dele, como o logger.
+func (g *gerenciador) Iniciar(ctx context.Context, nome string, opcoes Opcoes) (historico.Execucao, error) {
+	job, ok := jobs.Buscar(g.jobs, nome)
+	if !ok {
+		return historico.Execucao{}, fmt.Errorf("%w: %q", ErrJobDesconhecido, nome)
+	}
+
+	registro := historico.Execucao{
+		ID:          logger.NovoRunID(),
+		Job:         job.Nome,
+		Origem:      opcoes.Origem,
+		Status:      historico.StatusExecutando,
+		DryRun:      job.DryRun,
+		Incremental: job.Incremental,
+		Inicio:      time.Now(),
+	}
+	if registro.Origem == "" {
+		registro.Origem = OrigemAPI
+	}
+	if opcoes.DryRun != nil {
+		registro.DryRun = *opcoes.DryRun
+	}
+	if opcoes.Incremental != nil {
+		registro.Incremental = *opcoes.Incremental
+	}
+
+	if registro.Incremental {
+		ultima, err := g.historico.UltimaConcluida(ctx, job.Nome)
+		if err != nil {
+			return historico.Execucao{}, err
+		}
+		// Sem execução anterior concluída, a primeira execução incremental lê todo o banco inicial
+		if ultima != nil {
+			desde := ultima.Inicio
+			registro.Desde = &desde
+		}
+	}
+
+	recurso := RecursoCarga()
+	ctxExecucao, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	ctxExecucao = logger.ComRunID(ctxExecucao, registro.ID)
+	ativa := &execucaoAtiva{
+		registro:  registro,
+		recurso:   recurso,
+		progresso: &getdata.Progresso{},
+		cancelar:  cancel,
+		feito:     make(chan struct{}),
+	}
+
+	// A execução entra em ativas antes de aguardar a trava para que Cancelar e Encerrar
+	// consigam interromper a espera; até o pipeline ser disparado, desistir fecha feito aqui.
+	g.mu.Lock()
+	if g.encerrando {
+		g.mu.Unlock()
+		cancel()
+		return historico.Execucao{}, ErrGerenciadorEncerrando
+	}
+	if id, ok := g.porRecurso[recurso]; ok {
+		outro := g.ativas[id].registro.Job
+		g.mu.Unlock()
+		cancel()
+		return historico.Execucao{}, fmt.Errorf("%w: a execução %s (job %q) está carregando %s", ErrJobEmExecucao, id, outro, recurso)
+	}
+	g.ativas[registro.ID] = ativa
+	g.porRecurso[recurso] = registro.ID
+	g.mu.Unlock()
+
+	desistir := func() {
+		g.remover(ativa)
+		cancel()
+		close(ativa.feito)
+	}
+
+	lease, err := g.trava.Adquirir(ctxExecucao, recurso)
+	if err != nil {
+		desistir()
+		if errors.Is(err, trava.ErrOcupada) {
+			return historico.Execucao{}, fmt.Errorf("%w: %w", ErrJobEmExecucao, err)
+		}
+		return historico.Execucao{}, err
+	}
+	ativa.lease = lease
+
+	if err := g.historico.Salvar(ctx, registro); err != nil {
+		lease.Liberar()
+		desistir()
+		return historico.Execucao{}, err
+	}
+
+	go g.executar(lease.Contexto(), ativa)
+	return registro, nil
+}
+
+// executar roda o pipeline da execução, grava o resultado e as ocorrências no histórico
+// e notifica o resultado.
+//
+// A trava e a vaga de execução ativa são liberadas antes da notificação, para que um canal
+// lento ou fora do ar não impeça as execuções seguintes.
+func (g *gerenciador) executar(ctx context.Context, ativa *execucaoAtiva) {
+	defer close(ativa.feito)
+	liberar := func() {
+		ativa.lease.Liberar()
+		ativa.cancelar()
+		g.remover(ativa)
+	}
+	defer liberar()
+
+	log := logger.DoContexto(ctx)
+	registro := ativa.registro
+	log.Info("execução iniciada", "job", registro.Job, "origem", registro.Origem, "dry_run", registro.DryRun, "incremental", registro.Incremental)
+
+	cfg := g.cfg
+	cfg.DryRun = registro.DryRun
+	cfg.Progresso = ativa.progresso
+	if registro.Desde != nil {
+		cfg.Desde = *registro.Desde
+	}
+
+	relatorio, err := getdata.NewGetDataBancoInicial(g.inicial, g.final, g.familias, cfg).GetAll(ctx)
+	if causa := context.Cause(ctx); errors.Is(causa, trava.ErrPerdida) {
+		err = causa
+	}
+	ConcluirRegistro(&registro, relatorio, err)
+
+	if !registro.DryRun {
+		metricas.DuracaoExecucao.Set(relatorio.Duracao.Seconds())
+		if registro.Status == historico.StatusConcluida {
+			metricas.UltimaExecucaoSucesso.SetToCurrentTime()
+		}
+	}
+
+	// O resultado é gravado mesmo quando a execução foi cancelada
+	ctxHistorico := context.WithoutCancel(ctx)
+	if err := SalvarResultado(ctxHistorico, g.historico, registro, relatorio); err != nil {
+		log.Error("erro ao gravar execução no histórico", logger.Erro(err))
+	}
+	liberar()
+
+	if err := g.notificar.Notificar(ctxHistorico, registro, relatorio); err != nil {
+		log.Error("erro ao enviar notificação da execução", logger.Erro(err))
+	}
+
+	log.Info("execução finalizada", "job", registro.Job, "status", registro.Status, "duracao", relatorio.Duracao)
+}
+
+// ConcluirRegistro preenche no registro os totais do relatório, o status final e o erro fatal, se houver.
+func ConcluirRegistro(registro *historico.Execucao, relatorio getdata.Relatorio, err error) {
+	fim := time.Now()
+	registro.Fim = &fim
+	registro.Total = relatorio.Total
+	registro.Inseridos = relatorio.Inseridos
+	registro.Atualizados = len(relatorio.Atualizados)
+	registro.Inalterados = relatorio.Inalterados
+	registro.Duplicados = len(relatorio.Duplicados)
+	registro.Falhas = relatorio.Falhas()
+	registro.Avisos = len(relatorio.Avisos)
+	registro.AvisosPorCodigo = relatorio.AvisosPorCodigo
+	registro.Conjuges = relatorio.Conjuges
+	registro.Inconsistencias = len(relatorio.Inconsistencias)
+	registro.Familias = relatorio.Familias
+	registro.Observacoes = relatorio.Observacoes
+	if len(relatorio.FalhasPorCategoria) > 0 {
+		registro.FalhasPorCategoria = make(map[string]int, len(relatorio.FalhasPorCategoria))
+		for categoria, total := range relatorio.FalhasPorCategoria {
+			registro.FalhasPorCategoria[string(categoria)] = total
+		}
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		registro.Status = historico.StatusCancelada
+	case err != nil:
+		registro.Status = historico.StatusFalha
+		registro.Erro = err.Error()
+	case relatorio.Parcial():
+		registro.Status = historico.StatusParcial
+	default:
+		registro.Status = historico.StatusConcluida
+	}
+}
+
+// SalvarResultado grava no histórico as ocorrências e o registro concluído de uma execução. As duas gravações
+// são tentadas mesmo que a primeira falhe; os erros são retornados juntos.
+func SalvarResultado(ctx context.Context, repo historicorepository.HistoricoRepository, registro historico.Execucao, relatorio getdata.Relatorio) error {
+	var erros []error
+	if err := repo.SalvarOcorrencias(ctx, ocorrencias(registro.ID, relatorio)); err != nil {
+		erros = append(erros, fmt.Errorf("erro ao gravar ocorrências: %w", err))
+	}
+	if err := repo.Salvar(ctx, registro); err != nil {
+		erros = append(erros, fmt.Errorf("erro ao gravar registro: %w", err))
+	}
+	return errors.Join(erros...)
+}
+
+// ocorrencias converte as listas de duplicados, atualizados, falhas, avisos e inconsistências do relatório em ocorrências do histórico.
+func ocorrencias(runID string, relatorio getdata.Relatorio) []historico.Ocorrencia {
+	lista := make([]historico.Ocorrencia, 0, len(relatorio.Duplicados)+len(relatorio.Atualizados)+relatorio.Falhas()+len(relatorio.Avisos)+len(relatorio.Inconsistencias))
+	adicionar := func(tipo string, linhas []string) {
+		for i, linha := range linhas {
+			lista = append(lista, historico.Ocorrencia{RunID: runID, Sequencia: i + 1, Tipo: tipo, Descricao: linha})
+		}
+	}
+	adicionar(historico.OcorrenciaDuplicado, relatorio.Duplicados)
+	adicionar(historico.OcorrenciaAtualizado, relatorio.Atualizados)
+	adicionar(historico.OcorrenciaFalhaTransformacao, relatorio.ErrosTransformacao)
+	adicionar(historico.OcorrenciaFalhaInsercao, relatorio.ErrosInsercao)
+	adicionar(historico.OcorrenciaAviso, relatorio.Avisos)
+	adicionar(historico.OcorrenciaInconsistencia, relatorio.Inconsistencias)
+	return lista
+}
+
+// RecursoCarga retorna o nome do recurso da trava distribuída que protege a carga: a coleção do banco final
+// (MONGO_DB_BANCO_FINAL e MONGO_COLLECTION_BANCO_FINAL), compartilhada por todos os jobs.
+func RecursoCarga() string {
+	return "carga:" + os.Getenv("MONGO_DB_BANCO_FINAL") + "." + os.Getenv("MONGO_COLLECTION_BANCO_FINAL")
+}
+
+// remover retira a execução das execuções em andamento.
+func (g *gerenciador) remover(ativa *execucaoAtiva) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.ativas, ativa.registro.ID)
+	if g.porRecurso[ativa.recurso] == ativa.registro.ID {
+		delete(g.porRecurso, ativa.recurso)
+	}
+}
+
+// ativa retorna a execução em andamento com o ID informado, se houver.
+func (g *gerenciador) ativa(id string) (*execucaoAtiva, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	a, ok := g.ativas[id]
+	return a, ok
+}
+
+// Estado retorna o registro da execução em andamento, com o progresso atual,
+// ou o registro gravado no histórico quando ela já terminou (ou ocorreu em outro processo).
+func (g *gerenciador) Estado(ctx context.Context, id string) (Estado, error) {
+	if a, ok := g.ativa(id); ok {
+		progresso := a.progresso.Atual()
+		return Estado{Execucao: a.registro, Progresso: &progresso}, nil
+	}
+
+	registro, err := g.historico.Buscar(ctx, id)
+	if err != nil {
+		return Estado{}, err
+	}
+	if registro == nil {
+		return Estado{}, ErrExecucaoNaoEncontrada
+	}
+	return Estado{Execucao: *registro}, nil
+}
+
+// Ocorrencias pagina as ocorrências gravadas no histórico ao final da execução.
+func (g *gerenciador) Ocorrencias(ctx context.Context, id, tipo string, pagina, tamanho int) (Pagina, error) {
+	estado, err := g.Estado(ctx, id)
+	if err != nil {
+		return Pagina{}, err
+	}
+	if estado.Status == historico.StatusExecutando {
+		return Pagina{}, ErrExecucaoEmAndamento
+	}
+
+	pagina = max(pagina, 1)
+	tamanho = max(tamanho, 1)
+	itens, total, err := g.historico.ListarOcorrencias(ctx, id, strings.TrimSpace(tipo), int64((pagina-1)*tamanho), int64(tamanho))
+	if err != nil {
+		return Pagina{}, err
+	}
+	return Pagina{Itens: itens, Total: total, Pagina: pagina, Tamanho: tamanho}, nil
+}
+
+// Cancelar cancela o contexto da execução. O pipeline encerra as etapas de forma ordenada
+// e a execução é registrada no histórico com status cancelada.
+func (g *gerenciador) Cancelar(ctx context.Context, id string) error {
+	if a, ok := g.ativa(id); ok {
+		a.cancelar()
+		return nil
+	}
+
+	registro, err := g.historico.Buscar(ctx, id)
+	if err != nil {
+		return err
+	}
+	if registro == nil {
+		return ErrExecucaoNaoEncontrada
+	}
+	if registro.Status == historico.StatusExecutando {
+		return ErrExecucaoOutraInstancia
+	}
+	return ErrExecucaoFinalizada
+}
+
+// Encerrar cancela todas as execuções em andamento e aguarda que terminem.
+func (g *gerenciador) Encerrar() {
+	g.mu.Lock()
+	g.encerrando = true
+	ativas := make([]*execucaoAtiva, 0, len(g.ativas))
+	for _, a := range g.ativas {
+		ativas = append(ativas, a)
+	}
+	g.mu.Unlock()
+
+	for _, a := range ativas {
+		a.cancelar()
+		<-a.feito
+	}
+}
@@ -0,0 +1,25 @@
+package familias
+
+import "context"
+
+// Agrupador define a interface do serviço que agrupa os membros do banco final em famílias (domicílios).
+//
+// Membros ficam na mesma família quando moram no mesmo endereço normalizado (CEP, rua, número e complemento)
+// ou quando são cônjuges vinculados (conjugeId), mesmo com endereços diferentes. Endereços sem número (S/N)
+// não agrupam membros, pois não identificam um domicílio.
+//
+// O responsável é escolhido, nesta ordem, entre os membros casados com o cônjuge na família, os que têm filhos
+// e os mais velhos; o nome desempata.
+type Agrupador interface {
+	// Agrupar recalcula as famílias a partir dos membros do banco final, grava a coleção de famílias
+	// e o familiaId de cada membro (nada é gravado quando simular é true).
+	Agrupar(ctx context.Context, simular bool) (Relatorio, error)
+}
+
+// Relatorio resume o agrupamento em famílias.
+type Relatorio struct {
+	Familias  int   // Famílias calculadas
+	Membros   int   // Membros em famílias com mais de uma pessoa
+	Alterados int   // Membros cujo familiaId mudou (no dry-run, os que mudariam)
+	Removidas int64 // Famílias da execução anterior que deixaram de existir
+}
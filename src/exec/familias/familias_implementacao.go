@@ -0,0 +1,242 @@
+package familias
+
+import (
+	"context"
+	bancofinal "etl-service/src/config/model/banco_final"
+	"etl-service/src/exec/domain"
+	familiarepository "etl-service/src/exec/repository/familia_repository"
+	finalrepository "etl-service/src/exec/repository/final_repository"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// camposAgrupamento são os campos lidos do banco final para montar as famílias.
+var camposAgrupamento = []string{"name", "endereco", "conjugeId", "familiaId", "filho", "dataNascimento"}
+
+// agrupador é a implementação concreta da interface Agrupador.
+type agrupador struct {
+	final    finalrepository.FinalRepository
+	familias familiarepository.FamiliaRepository
+}
+
+// NewAgrupador cria o serviço de agrupamento em famílias sobre os repositórios de membros e de famílias.
+func NewAgrupador(final finalrepository.FinalRepository, familias familiarepository.FamiliaRepository) Agrupador {
+	return &agrupador{final: final, familias: familias}
+}
+
+// Agrupar monta as famílias, regrava a coleção de famílias e atualiza apenas os familiaId que mudaram.
+func (a *agrupador) Agrupar(ctx context.Context, simular bool) (Relatorio, error) {
+	membros, err := a.final.ListarCampos(ctx, camposAgrupamento...)
+	if err != nil {
+		return Relatorio{}, err
+	}
+
+	lista := montar(membros)
+	relatorio := Relatorio{Familias: len(lista)}
+
+	familiaDe := make(map[primitive.ObjectID]primitive.ObjectID, len(membros))
+	for _, f := range lista {
+		if len(f.Membros) > 1 {
+			relatorio.Membros += len(f.Membros)
+		}
+		for _, m := range f.Membros {
+			familiaDe[m.ID] = f.ID
+		}
+	}
+	alteracoes := make(map[primitive.ObjectID]*primitive.ObjectID)
+	for _, m := range membros {
+		id := familiaDe[m.ID]
+		if m.FamiliaID == nil || *m.FamiliaID != id {
+			alteracoes[m.ID] = &id
+		}
+	}
+	relatorio.Alterados = len(alteracoes)
+
+	if simular {
+		return relatorio, nil
+	}
+	if relatorio.Removidas, err = a.familias.Substituir(ctx, lista, time.Now()); err != nil {
+		return relatorio, err
+	}
+	if len(alteracoes) > 0 {
+		if _, err := a.final.DefinirReferencias(ctx, "familiaId", alteracoes); err != nil {
+			return relatorio, err
+		}
+	}
+	return relatorio, nil
+}
+
+// montar agrupa os membros por endereço e por vínculo entre cônjuges, escolhe o responsável de cada grupo
+// e reaproveita o _id da família anterior da maioria dos membros, quando ainda não usado por outro grupo.
+func montar(membros []bancofinal.Membro) []bancofinal.Familia {
+	indice := make(map[primitive.ObjectID]int, len(membros))
+	for i, m := range membros {
+		indice[m.ID] = i
+	}
+
+	grupos := newConjuntos(len(membros))
+	porEndereco := make(map[string]int)
+	for i, m := range membros {
+		if chave := chaveEndereco(m.Endereco); chave != "" {
+			if j, ok := porEndereco[chave]; ok {
+				grupos.unir(i, j)
+			} else {
+				porEndereco[chave] = i
+			}
+		}
+		if m.ConjugeID != nil {
+			if j, ok := indice[*m.ConjugeID]; ok {
+				grupos.unir(i, j)
+			}
+		}
+	}
+
+	porRaiz := make(map[int][]*bancofinal.Membro)
+	for i := range membros {
+		raiz := grupos.raiz(i)
+		porRaiz[raiz] = append(porRaiz[raiz], &membros[i])
+	}
+	lista := make([][]*bancofinal.Membro, 0, len(porRaiz))
+	for _, grupo := range porRaiz {
+		sort.Slice(grupo, func(i, j int) bool { return antes(grupo[i], grupo[j], grupo) })
+		lista = append(lista, grupo)
+	}
+	// Os maiores grupos escolhem primeiro o _id anterior; o responsável desempata, mantendo a ordem estável
+	sort.Slice(lista, func(i, j int) bool {
+		if len(lista[i]) != len(lista[j]) {
+			return len(lista[i]) > len(lista[j])
+		}
+		return lista[i][0].Name < lista[j][0].Name
+	})
+
+	usados := make(map[primitive.ObjectID]bool)
+	familias := make([]bancofinal.Familia, 0, len(lista))
+	for _, grupo := range lista {
+		id := idAnterior(grupo, usados)
+		usados[id] = true
+		familias = append(familias, nova(id, grupo))
+	}
+	return familias
+}
+
+// nova cria a família a partir do grupo já ordenado (o primeiro membro é o responsável).
+func nova(id primitive.ObjectID, grupo []*bancofinal.Membro) bancofinal.Familia {
+	responsavel := grupo[0]
+	f := bancofinal.Familia{
+		ID:            id,
+		Endereco:      responsavel.Endereco,
+		ResponsavelID: responsavel.ID,
+		Responsavel:   responsavel.Name,
+		Membros:       make([]bancofinal.MembroFamilia, 0, len(grupo)),
+	}
+	for _, m := range grupo {
+		papel := bancofinal.PapelMembro
+		switch {
+		case m == responsavel:
+			papel = bancofinal.PapelResponsavel
+		case responsavel.ConjugeID != nil && *responsavel.ConjugeID == m.ID:
+			papel = bancofinal.PapelConjuge
+		}
+		f.Membros = append(f.Membros, bancofinal.MembroFamilia{ID: m.ID, Name: m.Name, Papel: papel})
+		f.ComFilhos = f.ComFilhos || m.Filho
+	}
+	return f
+}
+
+// antes ordena os membros de um grupo pela prioridade para ser o responsável: casados com o cônjuge no grupo,
+// com filhos, mais velhos e, por fim, pelo nome.
+func antes(a, b *bancofinal.Membro, grupo []*bancofinal.Membro) bool {
+	ca, cb := casadoNoGrupo(a, grupo), casadoNoGrupo(b, grupo)
+	if ca != cb {
+		return ca
+	}
+	if a.Filho != b.Filho {
+		return a.Filho
+	}
+	na, nb := a.DataNascimento, b.DataNascimento
+	if !na.Equal(nb) {
+		// Data de nascimento ausente fica por último
+		if na.IsZero() || nb.IsZero() {
+			return nb.IsZero()
+		}
+		return na.Before(nb)
+	}
+	return a.Name < b.Name
+}
+
+// casadoNoGrupo indica se o cônjuge vinculado ao membro está no mesmo grupo.
+func casadoNoGrupo(m *bancofinal.Membro, grupo []*bancofinal.Membro) bool {
+	if m.ConjugeID == nil {
+		return false
+	}
+	for _, outro := range grupo {
+		if outro.ID == *m.ConjugeID {
+			return true
+		}
+	}
+	return false
+}
+
+// idAnterior retorna o familiaId mais frequente entre os membros do grupo que ainda não foi usado
+// por outro grupo, ou um _id novo.
+func idAnterior(grupo []*bancofinal.Membro, usados map[primitive.ObjectID]bool) primitive.ObjectID {
+	contagem := make(map[primitive.ObjectID]int)
+	for _, m := range grupo {
+		if m.FamiliaID != nil && !usados[*m.FamiliaID] {
+			contagem[*m.FamiliaID]++
+		}
+	}
+	var escolhido primitive.ObjectID
+	maior := 0
+	for id, total := range contagem {
+		if total > maior || (total == maior && id.Hex() < escolhido.Hex()) {
+			escolhido, maior = id, total
+		}
+	}
+	if maior == 0 {
+		return primitive.NewObjectID()
+	}
+	return escolhido
+}
+
+// chaveEndereco identifica o domicílio pelo CEP, rua, número e complemento normalizados.
+// Retorna vazio quando o endereço não identifica um domicílio (sem rua ou sem número).
+func chaveEndereco(e bancofinal.Endereco) string {
+	rua := domain.ChaveLogradouro(e.Rua)
+	numero := domain.ChaveTexto(e.Numero)
+	if rua == "" || numero == "" || e.Numero == domain.SemNumero {
+		return ""
+	}
+	return strings.Join([]string{e.Cep, rua, numero, domain.ChaveTexto(e.Complemento)}, "|")
+}
+
+// conjuntos é uma estrutura union-find usada para juntar os membros de um mesmo domicílio.
+type conjuntos []int
+
+// newConjuntos cria n conjuntos, um para cada elemento.
+func newConjuntos(n int) conjuntos {
+	c := make(conjuntos, n)
+	for i := range c {
+		c[i] = i
+	}
+	return c
+}
+
+// raiz retorna o representante do conjunto do elemento, comprimindo o caminho.
+func (c conjuntos) raiz(i int) int {
+	for c[i] != i {
+		c[i] = c[c[i]]
+		i = c[i]
+	}
+	return i
+}
+
+// unir junta os conjuntos dos dois elementos.
+func (c conjuntos) unir(i, j int) {
+	if ri, rj := c.raiz(i), c.raiz(j); ri != rj {
+		c[ri] = rj
+	}
+}
@@ -0,0 +1,310 @@
+package familias
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	bancofinal "etl-service/src/config/model/banco_final"
+	"etl-service/src/exec/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// enderecoPaulista é o endereço de referência dos testes.
+var enderecoPaulista = bancofinal.Endereco{Cep: "01310100", Rua: "Avenida Paulista", Numero: "1000", Complemento: "Apto 12"}
+
+// nascido retorna a data de nascimento no fuso de São Paulo.
+func nascido(ano int) time.Time {
+	return time.Date(ano, time.January, 1, 0, 0, 0, 0, domain.FusoHorario)
+}
+
+// membro cria um membro do banco final com os campos usados no agrupamento.
+func membro(nome string, endereco bancofinal.Endereco, nascimento time.Time) bancofinal.Membro {
+	return bancofinal.Membro{ID: primitive.NewObjectID(), Name: nome, Endereco: endereco, DataNascimento: nascimento}
+}
+
+// casar vincula os dois membros como cônjuges.
+func casar(a, b *bancofinal.Membro) {
+	idA, idB := a.ID, b.ID
+	a.ConjugeID, b.ConjugeID = &idB, &idA
+}
+
+// composicao descreve as famílias pelos nomes: "RESPONSÁVEL: MEMBRO, MEMBRO", ordenadas.
+func composicao(familias []bancofinal.Familia) []string {
+	var obtido []string
+	for _, f := range familias {
+		nomes := make([]string, 0, len(f.Membros)-1)
+		for _, m := range f.Membros[1:] {
+			nomes = append(nomes, m.Name)
+		}
+		obtido = append(obtido, f.Responsavel+": "+strings.Join(nomes, ", "))
+	}
+	sort.Strings(obtido)
+	return obtido
+}
+
+func TestChaveEndereco(t *testing.T) {
+	chave := chaveEndereco(enderecoPaulista)
+	if chave == "" {
+		t.Fatal("chaveEndereco() vazio para um endereço completo")
+	}
+
+	casos := []struct {
+		nome     string
+		endereco bancofinal.Endereco
+		igual    bool
+	}{
+		{"tipo de logradouro abreviado", bancofinal.Endereco{Cep: "01310100", Rua: "Av. Paulista", Numero: "1000", Complemento: "Apto 12"}, true},
+		{"maiúsculas e acentos", bancofinal.Endereco{Cep: "01310100", Rua: "AVENIDA PAULISTA", Numero: "1000", Complemento: "apto. 12"}, true},
+		{"bairro e cidade não contam", bancofinal.Endereco{Cep: "01310100", Rua: "Avenida Paulista", Numero: "1000", Complemento: "Apto 12", Bairro: "Bela Vista", Cidade: "São Paulo"}, true},
+		{"outro complemento", bancofinal.Endereco{Cep: "01310100", Rua: "Avenida Paulista", Numero: "1000", Complemento: "Apto 13"}, false},
+		{"outro número", bancofinal.Endereco{Cep: "01310100", Rua: "Avenida Paulista", Numero: "1001", Complemento: "Apto 12"}, false},
+		{"outro CEP", bancofinal.Endereco{Cep: "01310200", Rua: "Avenida Paulista", Numero: "1000", Complemento: "Apto 12"}, false},
+	}
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			if obtido := chaveEndereco(c.endereco) == chave; obtido != c.igual {
+				t.Errorf("chaveEndereco(%+v) igual = %v, esperado %v", c.endereco, obtido, c.igual)
+			}
+		})
+	}
+
+	for nome, e := range map[string]bancofinal.Endereco{
+		"sem número":   {Cep: "01310100", Rua: "Avenida Paulista", Numero: domain.SemNumero},
+		"número vazio": {Cep: "01310100", Rua: "Avenida Paulista"},
+		"sem rua":      {Cep: "01310100", Numero: "1000"},
+	} {
+		if obtido := chaveEndereco(e); obtido != "" {
+			t.Errorf("%s: chaveEndereco() = %q, esperado vazio", nome, obtido)
+		}
+	}
+}
+
+func TestMontar(t *testing.T) {
+	outroEndereco := bancofinal.Endereco{Cep: "04538133", Rua: "Rua Funchal", Numero: "200"}
+	semNumero := bancofinal.Endereco{Cep: "01310100", Rua: "Avenida Paulista", Numero: domain.SemNumero}
+
+	casos := []struct {
+		nome     string
+		membros  func() []bancofinal.Membro
+		esperado []string
+	}{
+		{
+			nome: "mesmo endereço normalizado",
+			membros: func() []bancofinal.Membro {
+				variante := enderecoPaulista
+				variante.Rua = "Av. Paulista"
+				return []bancofinal.Membro{
+					membro("ANA", enderecoPaulista, nascido(1990)),
+					membro("BRUNO", variante, nascido(1980)),
+					membro("CARLA", outroEndereco, nascido(1970)),
+				}
+			},
+			esperado: []string{"BRUNO: ANA", "CARLA: "},
+		},
+		{
+			nome: "cônjuges em endereços diferentes ficam juntos",
+			membros: func() []bancofinal.Membro {
+				m := []bancofinal.Membro{
+					membro("JOÃO", enderecoPaulista, nascido(1980)),
+					membro("MARIA", outroEndereco, nascido(1982)),
+				}
+				casar(&m[0], &m[1])
+				return m
+			},
+			esperado: []string{"JOÃO: MARIA"},
+		},
+		{
+			nome: "união transitiva entre endereço e cônjuge",
+			membros: func() []bancofinal.Membro {
+				m := []bancofinal.Membro{
+					membro("JOÃO", enderecoPaulista, nascido(1980)),
+					membro("MARIA", outroEndereco, nascido(1982)),
+					membro("PEDRO", outroEndereco, nascido(2010)),
+				}
+				casar(&m[0], &m[1])
+				return m
+			},
+			esperado: []string{"JOÃO: MARIA, PEDRO"},
+		},
+		{
+			nome: "S/N não agrupa",
+			membros: func() []bancofinal.Membro {
+				return []bancofinal.Membro{
+					membro("ANA", semNumero, nascido(1990)),
+					membro("BRUNO", semNumero, nascido(1980)),
+				}
+			},
+			esperado: []string{"ANA: ", "BRUNO: "},
+		},
+		{
+			nome: "cônjuge fora da lista não agrupa",
+			membros: func() []bancofinal.Membro {
+				m := []bancofinal.Membro{membro("JOÃO", bancofinal.Endereco{}, nascido(1980))}
+				ausente := primitive.NewObjectID()
+				m[0].ConjugeID = &ausente
+				return m
+			},
+			esperado: []string{"JOÃO: "},
+		},
+	}
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			membros := c.membros()
+			familias := montar(membros)
+			if obtido := composicao(familias); !reflect.DeepEqual(obtido, c.esperado) {
+				t.Errorf("famílias = %q, esperado %q", obtido, c.esperado)
+			}
+			total := 0
+			for _, f := range familias {
+				total += len(f.Membros)
+			}
+			if total != len(membros) {
+				t.Errorf("%d membros nas famílias, esperado %d", total, len(membros))
+			}
+		})
+	}
+}
+
+func TestMontarPapeis(t *testing.T) {
+	membros := []bancofinal.Membro{
+		membro("PEDRO", enderecoPaulista, nascido(2010)),
+		membro("MARIA", enderecoPaulista, nascido(1982)),
+		membro("JOÃO", enderecoPaulista, nascido(1985)),
+	}
+	casar(&membros[1], &membros[2])
+	membros[2].Filho = true
+
+	familias := montar(membros)
+	if len(familias) != 1 {
+		t.Fatalf("%d famílias, esperado 1", len(familias))
+	}
+	f := familias[0]
+	if f.ResponsavelID != membros[2].ID || f.Endereco != membros[2].Endereco {
+		t.Errorf("responsável = %s, esperado JOÃO", f.Responsavel)
+	}
+	if !f.ComFilhos {
+		t.Error("ComFilhos = false, esperado true")
+	}
+	papeis := make(map[string]string)
+	for _, m := range f.Membros {
+		papeis[m.Name] = m.Papel
+	}
+	esperado := map[string]string{"JOÃO": bancofinal.PapelResponsavel, "MARIA": bancofinal.PapelConjuge, "PEDRO": bancofinal.PapelMembro}
+	if !reflect.DeepEqual(papeis, esperado) {
+		t.Errorf("papéis = %v, esperado %v", papeis, esperado)
+	}
+}
+
+func TestAntes(t *testing.T) {
+	casado := membro("ZECA", enderecoPaulista, nascido(1990))
+	conjuge := membro("ZULMIRA", enderecoPaulista, nascido(1991))
+	casar(&casado, &conjuge)
+	comFilhos := membro("YARA", enderecoPaulista, nascido(1995))
+	comFilhos.Filho = true
+	velho := membro("XAVIER", enderecoPaulista, nascido(1940))
+	novo := membro("ANA", enderecoPaulista, nascido(2000))
+	semNascimento := membro("AARON", enderecoPaulista, time.Time{})
+	mesmoNascimento := membro("BETO", enderecoPaulista, nascido(2000))
+
+	// Cônjuge vinculado que não está no grupo não conta como casado no grupo
+	casadoFora := membro("WALTER", enderecoPaulista, nascido(1990))
+	ausente := primitive.NewObjectID()
+	casadoFora.ConjugeID = &ausente
+
+	grupo := []*bancofinal.Membro{&casado, &conjuge, &comFilhos, &velho, &novo, &semNascimento, &mesmoNascimento, &casadoFora}
+	casos := []struct {
+		nome string
+		a, b *bancofinal.Membro
+	}{
+		{"casado com o cônjuge no grupo antes de quem tem filhos", &casado, &comFilhos},
+		{"casado com o cônjuge no grupo antes do mais velho", &casado, &velho},
+		{"cônjuge fora do grupo não tem prioridade", &velho, &casadoFora},
+		{"com filhos antes do mais velho", &comFilhos, &velho},
+		{"mais velho antes do mais novo", &velho, &novo},
+		{"nascimento informado antes do ausente", &novo, &semNascimento},
+		{"mesmo nascimento desempata pelo nome", &novo, &mesmoNascimento},
+	}
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			if !antes(c.a, c.b, grupo) {
+				t.Errorf("antes(%s, %s) = false, esperado true", c.a.Name, c.b.Name)
+			}
+			if antes(c.b, c.a, grupo) {
+				t.Errorf("antes(%s, %s) = true, esperado false", c.b.Name, c.a.Name)
+			}
+		})
+	}
+}
+
+func TestIdAnterior(t *testing.T) {
+	antigo, outro := primitive.NewObjectID(), primitive.NewObjectID()
+	menor, maior := antigo, outro
+	if maior.Hex() < menor.Hex() {
+		menor, maior = maior, menor
+	}
+	grupo := func(ids ...*primitive.ObjectID) []*bancofinal.Membro {
+		g := make([]*bancofinal.Membro, len(ids))
+		for i, id := range ids {
+			g[i] = &bancofinal.Membro{ID: primitive.NewObjectID(), FamiliaID: id}
+		}
+		return g
+	}
+
+	casos := []struct {
+		nome     string
+		grupo    []*bancofinal.Membro
+		usados   map[primitive.ObjectID]bool
+		esperado *primitive.ObjectID // nil espera um _id novo
+	}{
+		{"id da maioria", grupo(&outro, &antigo, &antigo, nil), nil, &antigo},
+		{"id da maioria já usado", grupo(&outro, &antigo, &antigo), map[primitive.ObjectID]bool{antigo: true}, &outro},
+		{"empate escolhe o menor _id", grupo(&maior, &menor), nil, &menor},
+		{"sem família anterior", grupo(nil, nil), nil, nil},
+		{"todos os ids já usados", grupo(&antigo), map[primitive.ObjectID]bool{antigo: true}, nil},
+	}
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			obtido := idAnterior(c.grupo, c.usados)
+			switch {
+			case c.esperado != nil && obtido != *c.esperado:
+				t.Errorf("idAnterior() = %s, esperado %s", obtido.Hex(), c.esperado.Hex())
+			case c.esperado == nil && (obtido.IsZero() || obtido == antigo || obtido == outro):
+				t.Errorf("idAnterior() = %s, esperado um _id novo", obtido.Hex())
+			}
+		})
+	}
+}
+
+func TestMontarReaproveitaFamiliaAnterior(t *testing.T) {
+	anterior := primitive.NewObjectID()
+	membros := []bancofinal.Membro{
+		membro("ANA", enderecoPaulista, nascido(1980)),
+		membro("BRUNO", enderecoPaulista, nascido(1985)),
+		membro("CARLA", bancofinal.Endereco{}, nascido(1990)),
+	}
+	// A família anterior foi dividida: o grupo maior fica com o _id, o menor recebe um novo
+	for i := range membros {
+		membros[i].FamiliaID = &anterior
+	}
+
+	familias := montar(membros)
+	if len(familias) != 2 {
+		t.Fatalf("%d famílias, esperado 2", len(familias))
+	}
+	for _, f := range familias {
+		switch f.Responsavel {
+		case "ANA":
+			if f.ID != anterior {
+				t.Errorf("família de ANA = %s, esperado o _id anterior %s", f.ID.Hex(), anterior.Hex())
+			}
+		case "CARLA":
+			if f.ID == anterior || f.ID.IsZero() {
+				t.Errorf("família de CARLA = %s, esperado um _id novo", f.ID.Hex())
+			}
+		}
+	}
+}
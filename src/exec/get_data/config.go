@@ -0,0 +1,125 @@
+package getdata
+
+import (
+	"etl-service/src/config/env"
+	"etl-service/src/config/logger"
+	"etl-service/src/exec/cep"
+	"etl-service/src/exec/conjuges"
+	controlecarga "etl-service/src/exec/controle_carga"
+	"etl-service/src/exec/domain"
+	"log/slog"
+	"runtime"
+	"time"
+)
+
+// Config define o dimensionamento das etapas do pipeline.
+//
+// A transformação é limitada por CPU e a carga por I/O, por isso
+// o número de workers de cada uma é configurado de forma independente.
+type Config struct {
+	TransformWorkers int // Workers da etapa de transformação
+	LoadWorkers      int // Workers da etapa de carga (inserções concorrentes no banco final)
+	Buffer           int // Capacidade dos canais entre as etapas (backpressure)
+	TamanhoLote      int // Quantidade de nomes consultados por vez na deduplicação
+
+	Carga controlecarga.Config // Limite de taxa e concorrência adaptativa da etapa de carga
+
+	Severidades map[string]domain.Severidade // Severidade de cada código de aviso de qualidade; ausentes usam o padrão do domínio
+
+	Conjuges      conjuges.Config      // Estados civis considerados casados na conferência dos cônjuges
+	FaixasEtarias domain.FaixasEtarias // Idades de início das faixas etárias dos campos derivados e dos resumos
+
+	// Opções da execução (não lidas do ambiente)
+	Desde     time.Time     // Execução incremental: extrai apenas membros modificados a partir deste instante (zero extrai todos)
+	DryRun    bool          // Executa deduplicação sem gravar no banco final; os membros novos são contados como inseridos
+	Progresso *Progresso    // Contadores atualizados durante a execução, para acompanhamento externo (opcional)
+	Ceps      cep.Base      // Base de CEPs para validar e completar os endereços (opcional)
+	Enums     *domain.Enums // Valores canônicos e sinônimos de sexo, estado civil e status (nil usa a tabela padrão)
+}
+
+// ConfigPadrao lê a configuração do pipeline das variáveis de ambiente:
+// ETL_TRANSFORM_WORKERS (padrão: número de CPUs), ETL_LOAD_WORKERS (padrão 10),
+// ETL_BUFFER (padrão 100) e ETL_DEDUP_LOTE (padrão 500).
+//
+// O controle de carga é lido de ETL_LOAD_RATE (ops/s, padrão 0 = sem limite), ETL_LOAD_BURST,
+// ETL_LOAD_ADAPTIVE (padrão false), ETL_LOAD_MIN_WORKERS (padrão 2), ETL_LOAD_MAX_WORKERS (padrão 50),
+// ETL_LOAD_TARGET_LATENCY (padrão 200ms) e ETL_LOAD_MAX_ERROR_RATE (padrão 0.05).
+//
+// A severidade dos avisos de qualidade é lida de ETL_SEVERIDADES, no formato
+// "codigo=severidade" separado por vírgulas (ex: "email_compartilhado=erro").
+// Uma lista inválida é registrada no log e as severidades padrão são usadas.
+//
+// Os estados civis de casado usados na conferência dos cônjuges são lidos de ETL_ESTADOS_CASADO, e as
+// idades de início das faixas etárias de ETL_FAIXAS_ETARIAS (padrão "12,18,30,60"); uma lista inválida
+// é registrada no log e as faixas padrão são usadas.
+func ConfigPadrao() Config {
+	severidades, err := domain.ParseSeveridades(env.GetString("ETL_SEVERIDADES", ""))
+	if err != nil {
+		slog.Warn("ETL_SEVERIDADES inválida, usando as severidades padrão", logger.Erro(err))
+		severidades = domain.SeveridadesPadrao()
+	}
+	faixas, err := domain.ParseFaixasEtarias(env.GetString("ETL_FAIXAS_ETARIAS", ""))
+	if err != nil {
+		slog.Warn("ETL_FAIXAS_ETARIAS inválida, usando as faixas padrão", logger.Erro(err))
+		faixas = domain.FaixasEtariasPadrao()
+	}
+	return Config{
+		TransformWorkers: env.GetInt("ETL_TRANSFORM_WORKERS", runtime.NumCPU()),
+		LoadWorkers:      env.GetInt("ETL_LOAD_WORKERS", 10),
+		Buffer:           env.GetInt("ETL_BUFFER", 100),
+		TamanhoLote:      env.GetInt("ETL_DEDUP_LOTE", 500),
+		Carga: controlecarga.Config{
+			OpsPorSegundo: env.GetFloat("ETL_LOAD_RATE", 0),
+			Rajada:        env.GetInt("ETL_LOAD_BURST", 0),
+			Adaptativo:    env.GetBool("ETL_LOAD_ADAPTIVE", false),
+			MinWorkers:    env.GetInt("ETL_LOAD_MIN_WORKERS", 2),
+			MaxWorkers:    env.GetInt("ETL_LOAD_MAX_WORKERS", 50),
+			LatenciaAlvo:  env.GetDuration("ETL_LOAD_TARGET_LATENCY", 200*time.Millisecond),
+			TaxaErroMax:   env.GetFloat("ETL_LOAD_MAX_ERROR_RATE", 0.05),
+		},
+		Severidades:   severidades,
+		Conjuges:      conjuges.ConfigPadrao(),
+		FaixasEtarias: faixas,
+	}
+}
+
+// normalizar garante valores mínimos válidos para cada campo.
+func (c Config) normalizar() Config {
+	if c.TransformWorkers < 1 {
+		c.TransformWorkers = 1
+	}
+	if c.LoadWorkers < 1 {
+		c.LoadWorkers = 1
+	}
+	if c.Buffer < 0 {
+		c.Buffer = 0
+	}
+	if c.TamanhoLote < 1 {
+		c.TamanhoLote = 1
+	}
+	c.Carga.Workers = c.LoadWorkers
+	if c.Severidades == nil {
+		c.Severidades = domain.SeveridadesPadrao()
+	}
+	if len(c.FaixasEtarias) == 0 {
+		c.FaixasEtarias = domain.FaixasEtariasPadrao()
+	}
+	return c
+}
+
+// severidade retorna a severidade configurada para o código de aviso; códigos desconhecidos são avisos.
+func (c Config) severidade(codigo string) domain.Severidade {
+	if s, ok := c.Severidades[codigo]; ok {
+		return s
+	}
+	return domain.SeveridadeAviso
+}
+
+// workersCarga retorna quantas goroutines a etapa de carga deve iniciar. No modo adaptativo
+// são iniciadas até o máximo configurado, e o controle de carga limita quantas escrevem ao mesmo tempo.
+func (c Config) workersCarga() int {
+	if c.Carga.Adaptativo && c.Carga.MaxWorkers > 0 {
+		return c.Carga.MaxWorkers
+	}
+	return c.LoadWorkers
+}
@@ -0,0 +1,98 @@
+package getdata
+
+import (
+	"context"
+	"etl-service/src/config/metricas"
+	"etl-service/src/config/tracing"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// EstatisticaEtapa resume o desempenho de uma etapa do pipeline.
+type EstatisticaEtapa struct {
+	Nome    string        // Nome da etapa (extracao, transformacao, deduplicacao, carga)
+	Workers int           // Quantidade de goroutines da etapa
+	Entrada int64         // Itens recebidos pela etapa
+	Saida   int64         // Itens entregues para a etapa seguinte (ou gravados, no caso da carga)
+	Ocupado time.Duration // Soma do tempo gasto processando itens em todos os workers
+	Duracao time.Duration // Tempo entre o início e o fim da etapa
+}
+
+// Throughput retorna a quantidade de itens entregues por segundo.
+func (e EstatisticaEtapa) Throughput() float64 {
+	if e.Duracao <= 0 {
+		return 0
+	}
+	return float64(e.Saida) / e.Duracao.Seconds()
+}
+
+// medidorEtapa acumula as estatísticas de uma etapa de forma segura entre goroutines
+// e mantém o span da etapa, do qual descendem os spans de lotes e de comandos MongoDB.
+type medidorEtapa struct {
+	ctx     context.Context // Contexto da etapa, carregando o span da etapa
+	span    trace.Span
+	nome    string
+	workers int
+	entrada atomic.Int64
+	saida   atomic.Int64
+	ocupado atomic.Int64
+	inicio  time.Time
+	fim     time.Time
+}
+
+// novoMedidor cria o medidor da etapa, abre o span da etapa e registra o instante de início.
+func novoMedidor(ctx context.Context, nome string, workers int) *medidorEtapa {
+	ctx, span := tracing.Span(ctx, "etl.etapa."+nome,
+		attribute.String("etl.etapa", nome),
+		attribute.Int("etl.workers", workers),
+	)
+	return &medidorEtapa{ctx: ctx, span: span, nome: nome, workers: workers, inicio: time.Now()}
+}
+
+// medir registra o tempo gasto processando um item a partir de inicio,
+// acumulando nas estatísticas da etapa e no histograma de latência por etapa.
+func (m *medidorEtapa) medir(inicio time.Time) {
+	d := time.Since(inicio)
+	m.ocupado.Add(int64(d))
+	metricas.LatenciaEtapa.WithLabelValues(m.nome).Observe(d.Seconds())
+}
+
+// ativar e desativar marcam o início e o fim de um worker da etapa na métrica de workers ativos.
+func (m *medidorEtapa) ativar() {
+	metricas.WorkersAtivos.WithLabelValues(m.nome).Inc()
+}
+
+func (m *medidorEtapa) desativar() {
+	metricas.WorkersAtivos.WithLabelValues(m.nome).Dec()
+}
+
+// finalizar registra o instante de término da etapa e encerra o span com os totais da etapa.
+func (m *medidorEtapa) finalizar(err error) {
+	m.fim = time.Now()
+	metricas.DuracaoEtapa.WithLabelValues(m.nome).Set(m.fim.Sub(m.inicio).Seconds())
+
+	m.span.SetAttributes(
+		attribute.Int64("etl.entrada", m.entrada.Load()),
+		attribute.Int64("etl.saida", m.saida.Load()),
+	)
+	tracing.Finalizar(m.span, err)
+}
+
+// estatistica retorna o retrato final da etapa.
+func (m *medidorEtapa) estatistica() EstatisticaEtapa {
+	fim := m.fim
+	if fim.IsZero() {
+		fim = time.Now()
+	}
+	return EstatisticaEtapa{
+		Nome:    m.nome,
+		Workers: m.workers,
+		Entrada: m.entrada.Load(),
+		Saida:   m.saida.Load(),
+		Ocupado: time.Duration(m.ocupado.Load()),
+		Duracao: fim.Sub(m.inicio),
+	}
+}
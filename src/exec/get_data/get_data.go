@@ -1,10 +1,18 @@
 package getdata
 
+import "context"
+
 // GetDataBancoInicial define a interface do serviço responsável por operações
 // relacionadas à obtenção de dados do banco inicial.
 // Essa interface abstrai as operações para facilitar a testabilidade e a troca da implementação.
 type GetDataBancoInicial interface {
-	// GetAll retorna uma lista de todos os membros presentes no banco inicial.
-	// Retorna um erro caso ocorra algum problema durante a busca.
-	GetAll() error
+	// GetAll executa o pipeline completo (extração, transformação, deduplicação e carga),
+	// lendo os membros do banco inicial e inserindo no banco final.
+	// Retorna o relatório da execução e um erro caso ocorra uma falha fatal durante o processo.
+	// O cancelamento de ctx interrompe todas as etapas.
+	GetAll(ctx context.Context) (Relatorio, error)
+
+	// Validate executa apenas as etapas de extração e transformação dos membros do banco inicial,
+	// sem escrever no banco final, reportando os registros que falhariam na conversão.
+	Validate(ctx context.Context) (Relatorio, error)
 }
@@ -1,133 +1,264 @@
 package getdata
 
 import (
-	bancofinal "etl-service/src/config/model/banco_final"
-	"etl-service/src/exec/domain"
+	"context"
+	"etl-service/src/config/logger"
+	"etl-service/src/config/tracing"
+	"etl-service/src/exec/conjuges"
+	"etl-service/src/exec/familias"
+	familiarepository "etl-service/src/exec/repository/familia_repository"
+	finalrepository "etl-service/src/exec/repository/final_repository"
 	inicialrepository "etl-service/src/exec/repository/inicial_repository"
+	"etl-service/src/exec/resumos"
 	"fmt"
 	"os"
-	"sync"
 	"time"
+
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // getDataBancoInicial é a implementação da interface GetDataBancoInicial.
-// Ela encapsula a lógica para acessar dados do banco inicial por meio do repositório.
+// Ela encapsula o pipeline que lê o banco inicial e grava no banco final por meio dos repositórios.
 type getDataBancoInicial struct {
-	repo inicialrepository.InicialRepository
+	inicial  inicialrepository.InicialRepository
+	final    finalrepository.FinalRepository
+	familias familiarepository.FamiliaRepository
+	cfg      Config
 }
 
 // NewGetDataBancoInicial cria uma nova instância de getDataBancoInicial
-// recebendo uma implementação da interface InicialRepository.
+// recebendo os repositórios do banco inicial, do banco final e das famílias e a configuração do pipeline.
 // Isso permite a injeção de dependência para maior flexibilidade e testabilidade.
-func NewGetDataBancoInicial(repo inicialrepository.InicialRepository) GetDataBancoInicial {
+func NewGetDataBancoInicial(inicial inicialrepository.InicialRepository, final finalrepository.FinalRepository, familias familiarepository.FamiliaRepository, cfg Config) GetDataBancoInicial {
 	return &getDataBancoInicial{
-		repo: repo,
+		inicial:  inicial,
+		final:    final,
+		familias: familias,
+		cfg:      cfg,
 	}
 }
 
-// GetAll busca todos os membros na fonte de dados usando o repositório.
-// Retorna uma lista de membros e um erro caso a operação falhe.
-// GetAll busca todos os membros, processa e cria arquivo txt com duplicados sem parar a execução
-func (g *getDataBancoInicial) GetAll() error {
+// GetAll executa o pipeline completo e cria arquivos txt com duplicados e erros sem parar a execução.
+// Membros que falham na conversão ou na inserção são registrados no relatório e não interrompem os demais.
+func (g *getDataBancoInicial) GetAll(ctx context.Context) (relatorio Relatorio, err error) {
 	start := time.Now()
 
-	membros, err := g.repo.GetAllMembrosRequisicao()
+	ctx, span := tracing.Span(ctx, "etl.run",
+		attribute.String("etl.modo", "run"),
+		attribute.Bool("etl.dry_run", g.cfg.DryRun),
+		attribute.Bool("etl.incremental", !g.cfg.Desde.IsZero()),
+	)
+	defer func() {
+		span.SetAttributes(atributosRelatorio(relatorio)...)
+		tracing.Finalizar(span, err)
+	}()
+
+	relatorio, err = g.executarPipeline(ctx, true)
+	relatorio.Duracao = time.Since(start)
 	if err != nil {
-		return fmt.Errorf("erro ao obter membros: %w", err)
+		return relatorio, err
+	}
+
+	log := logger.DoContexto(ctx)
+
+	if err := g.vincularConjuges(ctx, &relatorio); err != nil {
+		return relatorio, fmt.Errorf("erro ao vincular cônjuges: %w", err)
+	}
+	if err := g.agruparFamilias(ctx, &relatorio); err != nil {
+		return relatorio, fmt.Errorf("erro ao agrupar famílias: %w", err)
+	}
+	if err := g.atualizarResumos(ctx); err != nil {
+		return relatorio, fmt.Errorf("erro ao atualizar os resumos: %w", err)
 	}
 
-	var nomes []string
-	for _, m := range membros {
-		domainMembro, err := domain.NewBancoFinalMembroDomain(m)
+	// Grava duplicados num arquivo txt
+	if len(relatorio.Duplicados) > 0 {
+		err := writeLinesToFile("duplicados.txt", relatorio.Duplicados)
 		if err != nil {
-			return err
+			return relatorio, fmt.Errorf("erro ao criar arquivo de duplicados: %w", err)
 		}
-		model := domainMembro.ToModel()
-		nomes = append(nomes, model.Name)
+		log.Info("arquivo de duplicados criado", "arquivo", "duplicados.txt", "linhas", len(relatorio.Duplicados))
+	} else {
+		log.Info("nenhum membro duplicado encontrado")
 	}
 
-	existingMap, err := g.repo.ExistsByNames(nomes)
-	if err != nil {
-		return fmt.Errorf("erro ao verificar existência dos membros: %w", err)
+	// Grava os membros atualizados num arquivo txt
+	if len(relatorio.Atualizados) > 0 {
+		err := writeLinesToFile("atualizados.txt", relatorio.Atualizados)
+		if err != nil {
+			return relatorio, fmt.Errorf("erro ao criar arquivo de atualizados: %w", err)
+		}
+		log.Info("arquivo de membros atualizados criado", "arquivo", "atualizados.txt", "linhas", len(relatorio.Atualizados))
 	}
 
-	var duplicados []string
-	type insertResult struct {
-		name string
-		err  error
+	// Grava erros de transformação num arquivo txt
+	if len(relatorio.ErrosTransformacao) > 0 {
+		err := writeLinesToFile("erros_transformacao.txt", relatorio.ErrosTransformacao)
+		if err != nil {
+			return relatorio, fmt.Errorf("erro ao criar arquivo de erros de transformação: %w", err)
+		}
+		log.Info("arquivo de erros de transformação criado", "arquivo", "erros_transformacao.txt", "linhas", len(relatorio.ErrosTransformacao))
 	}
 
-	// Canal para resultados das inserções
-	resultCh := make(chan insertResult, len(membros))
-	var wg sync.WaitGroup
-
-	// Limitar número de goroutines concorrentes (pool)
-	const maxWorkers = 10
-	sem := make(chan struct{}, maxWorkers)
+	// Grava erros de inserção num arquivo txt
+	if len(relatorio.ErrosInsercao) > 0 {
+		err := writeLinesToFile("erros_insercao.txt", relatorio.ErrosInsercao)
+		if err != nil {
+			return relatorio, fmt.Errorf("erro ao criar arquivo de erros de inserção: %w", err)
+		}
+		log.Info("arquivo de erros de inserção criado", "arquivo", "erros_insercao.txt", "linhas", len(relatorio.ErrosInsercao))
+	} else {
+		log.Info("nenhum erro de inserção encontrado")
+	}
 
-	for _, m := range membros {
-		domainMembro, err := domain.NewBancoFinalMembroDomain(m)
+	// Grava avisos de qualidade num arquivo txt
+	if len(relatorio.Avisos) > 0 {
+		err := writeLinesToFile("avisos.txt", relatorio.Avisos)
 		if err != nil {
-			return err
+			return relatorio, fmt.Errorf("erro ao criar arquivo de avisos: %w", err)
 		}
-		model := domainMembro.ToModel()
+		log.Info("arquivo de avisos de qualidade criado", "arquivo", "avisos.txt", "linhas", len(relatorio.Avisos))
+	}
 
-		if existingMap[model.Name] {
-			duplicados = append(duplicados, model.Name)
-			continue
+	// Grava as inconsistências entre cônjuges num arquivo txt
+	if len(relatorio.Inconsistencias) > 0 {
+		err := writeLinesToFile("inconsistencias.txt", relatorio.Inconsistencias)
+		if err != nil {
+			return relatorio, fmt.Errorf("erro ao criar arquivo de inconsistências: %w", err)
 		}
+		log.Info("arquivo de inconsistências entre cônjuges criado", "arquivo", "inconsistencias.txt", "linhas", len(relatorio.Inconsistencias))
+	}
 
-		wg.Add(1)
-		sem <- struct{}{} // bloqueia se maxWorkers estiver cheio
-		go func(mod bancofinal.Membro) {
-			defer wg.Done()
+	for _, o := range relatorio.Observacoes {
+		log.Info("observação da execução", "observacao", o)
+	}
 
-			err := g.repo.Insert(mod)
-			resultCh <- insertResult{name: mod.Name, err: err}
-			<-sem // libera vaga no pool
-		}(model)
+	for _, e := range relatorio.Etapas {
+		log.Info("etapa concluída",
+			logger.ChaveEtapa, e.Nome,
+			"workers", e.Workers,
+			"entrada", e.Entrada,
+			"saida", e.Saida,
+			"ocupado", e.Ocupado.Round(time.Millisecond),
+			"duracao", e.Duracao.Round(time.Millisecond),
+			"itens_por_segundo", e.Throughput(),
+		)
+	}
+	if g.cfg.Carga.Adaptativo {
+		log.Info("concorrência adaptativa da carga", logger.ChaveEtapa, "carga", "limite_final", relatorio.LimiteCarga, "ajustes", relatorio.AjustesCarga)
 	}
+	log.Info("execução concluída",
+		"total", relatorio.Total,
+		"inseridos", relatorio.Inseridos,
+		"atualizados", len(relatorio.Atualizados),
+		"inalterados", relatorio.Inalterados,
+		"duplicados", len(relatorio.Duplicados),
+		"falhas", relatorio.Falhas(),
+		"avisos", len(relatorio.Avisos),
+		"conjuges", relatorio.Conjuges,
+		"inconsistencias", len(relatorio.Inconsistencias),
+		"familias", relatorio.Familias,
+		"duracao", relatorio.Duracao,
+	)
 
-	// Aguarda todas as goroutines terminarem
-	wg.Wait()
-	close(resultCh)
+	return relatorio, nil
+}
 
-	// Coleta erros de inserção para arquivo
-	var errosInsercao []string
-	for res := range resultCh {
-		if res.err != nil {
-			errosInsercao = append(errosInsercao, fmt.Sprintf("%s: %v", res.name, res.err))
-		}
-	}
+// vincularConjuges liga cada membro ao documento do cônjuge no banco final e adiciona ao relatório
+// as inconsistências encontradas. No dry-run nada é gravado e a conferência usa o estado atual do banco final.
+func (g *getDataBancoInicial) vincularConjuges(ctx context.Context, relatorio *Relatorio) (err error) {
+	ctx, span := tracing.Span(ctx, "etl.conjuges")
+	defer func() { tracing.Finalizar(span, err) }()
 
-	// Grava duplicados num arquivo txt
-	if len(duplicados) > 0 {
-		err := writeLinesToFile("duplicados.txt", duplicados)
-		if err != nil {
-			return fmt.Errorf("erro ao criar arquivo de duplicados: %w", err)
-		}
-		fmt.Printf("Arquivo 'duplicados.txt' criado com %d nomes duplicados\n", len(duplicados))
-	} else {
-		fmt.Println("Nenhum membro duplicado encontrado.")
+	resultado, err := conjuges.NewVinculador(g.final, g.cfg.Conjuges).Vincular(ctx, g.cfg.DryRun)
+	if err != nil {
+		return err
 	}
+	relatorio.Conjuges = resultado.Vinculados
+	for _, i := range resultado.Inconsistencias {
+		relatorio.Inconsistencias = append(relatorio.Inconsistencias, i.String())
+	}
+	span.SetAttributes(
+		attribute.Int("etl.conjuges.vinculados", resultado.Vinculados),
+		attribute.Int("etl.conjuges.alterados", resultado.Alterados),
+		attribute.Int("etl.conjuges.inconsistencias", len(resultado.Inconsistencias)),
+	)
+	return nil
+}
 
-	// Grava erros de inserção num arquivo txt
-	if len(errosInsercao) > 0 {
-		err := writeLinesToFile("erros_insercao.txt", errosInsercao)
-		if err != nil {
-			return fmt.Errorf("erro ao criar arquivo de erros de inserção: %w", err)
-		}
-		fmt.Printf("Arquivo 'erros_insercao.txt' criado com %d erros de inserção\n", len(errosInsercao))
-	} else {
-		fmt.Println("Nenhum erro de inserção encontrado.")
+// agruparFamilias recalcula as famílias (domicílios) a partir dos membros do banco final, depois do vínculo
+// entre cônjuges. No dry-run nada é gravado e o agrupamento usa o estado atual do banco final.
+func (g *getDataBancoInicial) agruparFamilias(ctx context.Context, relatorio *Relatorio) (err error) {
+	ctx, span := tracing.Span(ctx, "etl.familias")
+	defer func() { tracing.Finalizar(span, err) }()
+
+	resultado, err := familias.NewAgrupador(g.final, g.familias).Agrupar(ctx, g.cfg.DryRun)
+	if err != nil {
+		return err
 	}
+	relatorio.Familias = resultado.Familias
+	span.SetAttributes(
+		attribute.Int("etl.familias.total", resultado.Familias),
+		attribute.Int("etl.familias.membros_agrupados", resultado.Membros),
+		attribute.Int("etl.familias.alterados", resultado.Alterados),
+		attribute.Int64("etl.familias.removidas", resultado.Removidas),
+	)
+	logger.DoContexto(ctx).Info("famílias recalculadas",
+		"familias", resultado.Familias,
+		"membros_agrupados", resultado.Membros,
+		"alterados", resultado.Alterados,
+		"removidas", resultado.Removidas,
+	)
+	return nil
+}
 
-	fmt.Printf("Membros totais processados: %d\n", len(membros))
-	fmt.Printf("Tempo de execução: %s\n", time.Since(start))
+// atualizarResumos recalcula os campos derivados da data atual (idade, faixa etária e anos de batismo)
+// de todos os membros e materializa as coleções de resumo. No dry-run nada é gravado.
+func (g *getDataBancoInicial) atualizarResumos(ctx context.Context) (err error) {
+	ctx, span := tracing.Span(ctx, "etl.resumos")
+	defer func() { tracing.Finalizar(span, err) }()
 
+	resultado, err := resumos.NewAtualizador(g.final, g.cfg.normalizar().FaixasEtarias).Atualizar(ctx, g.cfg.DryRun)
+	if err != nil {
+		return err
+	}
+	span.SetAttributes(
+		attribute.Int("etl.resumos.derivados_alterados", resultado.Derivados),
+		attribute.StringSlice("etl.resumos.colecoes", resultado.Resumos),
+	)
+	logger.DoContexto(ctx).Info("campos derivados e resumos atualizados",
+		"derivados_alterados", resultado.Derivados,
+		"resumos", resultado.Resumos,
+	)
 	return nil
 }
 
+// Validate executa somente a extração e a conversão para o modelo final,
+// sem consultar nem escrever no banco final.
+func (g *getDataBancoInicial) Validate(ctx context.Context) (Relatorio, error) {
+	start := time.Now()
+
+	ctx, span := tracing.Span(ctx, "etl.run", attribute.String("etl.modo", "validate"))
+	relatorio, err := g.executarPipeline(ctx, false)
+	relatorio.Duracao = time.Since(start)
+
+	span.SetAttributes(atributosRelatorio(relatorio)...)
+	tracing.Finalizar(span, err)
+	return relatorio, err
+}
+
+// atributosRelatorio converte os totais do relatório em atributos do span da execução.
+func atributosRelatorio(r Relatorio) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.Int("etl.total", r.Total),
+		attribute.Int("etl.inseridos", r.Inseridos),
+		attribute.Int("etl.atualizados", len(r.Atualizados)),
+		attribute.Int("etl.inalterados", r.Inalterados),
+		attribute.Int("etl.duplicados", len(r.Duplicados)),
+		attribute.Int("etl.falhas", r.Falhas()),
+	}
+}
+
 // writeLinesToFile grava uma slice de strings em arquivo, uma linha por string
 func writeLinesToFile(filename string, lines []string) error {
 	file, err := os.Create(filename)
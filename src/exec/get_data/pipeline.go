@@ -0,0 +1,464 @@
+package getdata
+
+import (
+	"context"
+	"etl-service/src/config/database"
+	"etl-service/src/config/logger"
+	"etl-service/src/config/metricas"
+	bancofinal "etl-service/src/config/model/banco_final"
+	bancoinicial "etl-service/src/config/model/banco_inicial"
+	"etl-service/src/config/retry"
+	"etl-service/src/config/tracing"
+	controlecarga "etl-service/src/exec/controle_carga"
+	"etl-service/src/exec/domain"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"golang.org/x/sync/errgroup"
+)
+
+// intervaloFlushLote define de quanto em quanto tempo a deduplicação envia um lote incompleto,
+// evitando que membros fiquem parados quando a extração está lenta.
+const intervaloFlushLote = time.Second
+
+// tipoEvento identifica o desfecho de um membro em alguma etapa do pipeline.
+type tipoEvento int
+
+const (
+	eventoFalhaTransformacao  tipoEvento = iota // Membro falhou na conversão para o modelo final
+	eventoDuplicado                             // Membro se repete na execução
+	eventoInserido                              // Membro inserido com sucesso
+	eventoFalhaInsercao                         // Membro falhou na inserção ou atualização (após as tentativas)
+	eventoSimulado                              // Membro seria inserido (dry-run)
+	eventoAviso                                 // Membro convertido com valor que merece revisão
+	eventoAtualizado                            // Membro existente com conteúdo alterado, atualizado com sucesso
+	eventoSimuladoAtualizacao                   // Membro existente com conteúdo alterado seria atualizado (dry-run)
+	eventoInalterado                            // Membro existente com o mesmo conteúdo: nenhuma escrita
+)
+
+// itemCarga é um membro encaminhado à carga, novo (inserção) ou existente com conteúdo alterado (atualização).
+type itemCarga struct {
+	membro    bancofinal.Membro
+	atualizar bool
+}
+
+// evento é enviado pelas etapas ao coletor, que consolida o relatório em uma única goroutine.
+type evento struct {
+	tipo       tipoEvento
+	nome       string
+	mensagem   string                // Motivo da falha de transformação ou texto do aviso
+	codigo     string                // Código do aviso de qualidade
+	severidade domain.Severidade     // Severidade do aviso de qualidade
+	tentativas int                   // Tentativas de inserção realizadas
+	erro       *database.ErroEscrita // Erro de inserção classificado
+}
+
+// logarEvento registra o desfecho de um membro com a etapa, a chave do membro e a categoria do erro.
+// Inserções bem-sucedidas e duplicados ficam no nível debug para não inundar os logs em cargas grandes.
+func logarEvento(log *slog.Logger, ev evento) {
+	switch ev.tipo {
+	case eventoFalhaTransformacao:
+		log.Warn("falha na transformação do membro",
+			logger.ChaveEtapa, "transformacao",
+			logger.ChaveMembro, ev.nome,
+			logger.ChaveCategoria, "transformacao",
+			logger.ChaveErro, ev.mensagem,
+		)
+	case eventoDuplicado:
+		log.Debug("membro duplicado", logger.ChaveEtapa, "deduplicacao", logger.ChaveMembro, ev.nome)
+	case eventoInalterado:
+		log.Debug("membro inalterado", logger.ChaveEtapa, "deduplicacao", logger.ChaveMembro, ev.nome)
+	case eventoInserido:
+		log.Debug("membro inserido", logger.ChaveEtapa, "carga", logger.ChaveMembro, ev.nome)
+	case eventoAtualizado:
+		log.Debug("membro atualizado", logger.ChaveEtapa, "carga", logger.ChaveMembro, ev.nome)
+	case eventoSimulado:
+		log.Debug("membro seria inserido (dry-run)", logger.ChaveEtapa, "carga", logger.ChaveMembro, ev.nome)
+	case eventoSimuladoAtualizacao:
+		log.Debug("membro seria atualizado (dry-run)", logger.ChaveEtapa, "carga", logger.ChaveMembro, ev.nome)
+	case eventoAviso:
+		log.Debug("aviso de qualidade do membro", logger.ChaveEtapa, "transformacao", logger.ChaveMembro, ev.nome, "codigo", ev.codigo, "severidade", ev.severidade, "aviso", ev.mensagem)
+	case eventoFalhaInsercao:
+		log.Warn("falha na escrita do membro",
+			logger.ChaveEtapa, "carga",
+			logger.ChaveMembro, ev.nome,
+			logger.ChaveCategoria, string(ev.erro.Categoria),
+			"tentativas", ev.tentativas,
+			logger.ChaveErro, ev.erro.Motivo,
+		)
+	}
+}
+
+// executarPipeline conecta as etapas de extração, transformação, deduplicação e carga
+// por canais com capacidade limitada (backpressure), cada uma com seu próprio número de workers.
+//
+// Quando carregar é false, apenas extração e transformação são executadas (modo validação).
+// Uma falha fatal em qualquer etapa cancela o contexto compartilhado e interrompe as demais.
+func (g *getDataBancoInicial) executarPipeline(ctx context.Context, carregar bool) (Relatorio, error) {
+	cfg := g.cfg.normalizar()
+	grupo, ctx := errgroup.WithContext(ctx)
+
+	extraidos := make(chan bancoinicial.Membro, cfg.Buffer)
+	transformados := make(chan bancofinal.Membro, cfg.Buffer)
+	eventos := make(chan evento, cfg.Buffer)
+
+	// Coletor: única goroutine que escreve no relatório
+	var relatorio Relatorio
+	coletado := make(chan struct{})
+	go func() {
+		log := logger.DoContexto(ctx)
+		for ev := range eventos {
+			relatorio.registrar(ev)
+			cfg.Progresso.registrar(ev)
+			logarEvento(log, ev)
+		}
+		close(coletado)
+	}()
+
+	ext := novoMedidor(ctx, "extracao", 1)
+	grupo.Go(func() error {
+		ext.ativar()
+		defer ext.desativar()
+		defer close(extraidos)
+		err := g.extrair(ext.ctx, cfg, extraidos, ext)
+		ext.finalizar(err)
+		return err
+	})
+
+	trf := novoMedidor(ctx, "transformacao", cfg.TransformWorkers)
+	emails := novoRegistroEmails()
+	var wgTransformacao sync.WaitGroup
+	for i := 0; i < cfg.TransformWorkers; i++ {
+		wgTransformacao.Add(1)
+		grupo.Go(func() error {
+			trf.ativar()
+			defer trf.desativar()
+			defer wgTransformacao.Done()
+			return transformar(trf.ctx, cfg, emails, extraidos, transformados, eventos, trf)
+		})
+	}
+	grupo.Go(func() error {
+		wgTransformacao.Wait()
+		trf.finalizar(nil)
+		close(transformados)
+		return nil
+	})
+
+	medidores := []*medidorEtapa{ext, trf}
+	var controle controlecarga.ControleCarga
+
+	if carregar {
+		novos := make(chan itemCarga, cfg.Buffer)
+
+		ded := novoMedidor(ctx, "deduplicacao", 1)
+		grupo.Go(func() error {
+			ded.ativar()
+			defer ded.desativar()
+			defer close(novos)
+			err := g.deduplicar(ded.ctx, cfg.TamanhoLote, transformados, novos, eventos, ded)
+			ded.finalizar(err)
+			return err
+		})
+
+		controle = controlecarga.NewControleCarga(cfg.Carga)
+
+		crg := novoMedidor(ctx, "carga", cfg.workersCarga())
+		var wgCarga sync.WaitGroup
+		for i := 0; i < cfg.workersCarga(); i++ {
+			wgCarga.Add(1)
+			grupo.Go(func() error {
+				crg.ativar()
+				defer crg.desativar()
+				defer wgCarga.Done()
+				if cfg.DryRun {
+					simularCarga(novos, eventos, crg)
+					return nil
+				}
+				g.carregar(crg.ctx, controle, novos, eventos, crg)
+				return nil
+			})
+		}
+		grupo.Go(func() error {
+			wgCarga.Wait()
+			crg.finalizar(nil)
+			return nil
+		})
+
+		medidores = append(medidores, ded, crg)
+	} else {
+		// Em modo validação os membros convertidos são apenas descartados
+		grupo.Go(func() error {
+			for range transformados {
+			}
+			return nil
+		})
+	}
+
+	err := grupo.Wait()
+	close(eventos)
+	<-coletado
+
+	relatorio.Total = int(ext.saida.Load())
+	if obs := g.observacaoIncremental(cfg.Desde); obs != "" {
+		relatorio.Observacoes = append(relatorio.Observacoes, obs)
+	}
+	if controle != nil {
+		relatorio.LimiteCarga = controle.Limite()
+		relatorio.AjustesCarga = controle.Ajustes()
+	}
+	for _, m := range medidores {
+		relatorio.Etapas = append(relatorio.Etapas, m.estatistica())
+	}
+	return relatorio, err
+}
+
+// observacaoIncremental descreve o alcance da leitura de uma execução incremental. Sem campo de modificação
+// na origem não é possível saber quais membros mudaram: todos são lidos e a comparação de hash da carga evita
+// as escritas dos inalterados, o que mantém o banco final correto, mas sem reduzir o volume lido.
+func (g *getDataBancoInicial) observacaoIncremental(desde time.Time) string {
+	if desde.IsZero() {
+		return ""
+	}
+	if campo := g.inicial.CampoModificacao(); campo != "" {
+		return fmt.Sprintf("incremental: lidos os membros com '%s' a partir de %s", campo, desde.Format(time.RFC3339))
+	}
+	return "incremental sem campo de modificação na origem (MONGO_CAMPO_MODIFICACAO): todos os membros foram lidos e somente os alterados foram gravados"
+}
+
+// extrair percorre o banco inicial enviando cada membro para a etapa de transformação.
+// O envio bloqueia quando o canal está cheio, fazendo a leitura acompanhar o ritmo das etapas seguintes.
+func (g *getDataBancoInicial) extrair(ctx context.Context, cfg Config, out chan<- bancoinicial.Membro, med *medidorEtapa) error {
+	ultimo := time.Now()
+	err := g.inicial.StreamMembros(ctx, cfg.Desde, func(m bancoinicial.Membro) error {
+		med.medir(ultimo)
+		med.entrada.Add(1)
+		select {
+		case out <- m:
+			med.saida.Add(1)
+			metricas.Extraidos.Inc()
+			cfg.Progresso.extraido()
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		ultimo = time.Now()
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("erro ao obter membros: %w", err)
+	}
+	return nil
+}
+
+// transformar converte os membros do banco inicial para o modelo final e, com a base de CEPs
+// configurada, confere e completa os endereços. Membros inválidos são reportados ao coletor e não seguem no pipeline.
+// Os avisos de qualidade são tratados conforme a severidade configurada: ignorados, reportados
+// com o membro seguindo normalmente, ou, na severidade erro, reportados como falha de transformação.
+func transformar(ctx context.Context, cfg Config, emails *registroEmails, in <-chan bancoinicial.Membro, out chan<- bancofinal.Membro, eventos chan<- evento, med *medidorEtapa) error {
+	for m := range in {
+		med.entrada.Add(1)
+		inicio := time.Now()
+		domainMembro, err := domain.NewBancoFinalMembroDomain(m, cfg.Enums)
+		med.medir(inicio)
+		if err != nil {
+			eventos <- evento{tipo: eventoFalhaTransformacao, nome: m.Name, mensagem: err.Error()}
+			continue
+		}
+
+		membro := domainMembro.ToModel()
+		// Os campos derivados usam o instante da conversão e não entram no hash
+		domain.AplicarDerivados(&membro, membro.DataModificacao, cfg.FaixasEtarias)
+		avisos := domainMembro.Avisos()
+		if cfg.Ceps != nil {
+			avisos = append(avisos, cfg.Ceps.Enriquecer(&membro.Endereco)...)
+		}
+		// O hash é calculado depois de todas as alterações do membro
+		membro.Hash = domain.HashConteudo(membro)
+		if outro := emails.registrar(membro.Email, membro.Name); outro != "" {
+			avisos = append(avisos, avisoEmailCompartilhado(membro.Email, outro))
+		}
+
+		var bloqueantes []string
+		for _, aviso := range avisos {
+			switch severidade := cfg.severidade(aviso.Codigo); severidade {
+			case domain.SeveridadeIgnorar:
+			case domain.SeveridadeErro:
+				bloqueantes = append(bloqueantes, aviso.String())
+			default:
+				eventos <- evento{tipo: eventoAviso, nome: m.Name, mensagem: aviso.String(), codigo: aviso.Codigo, severidade: severidade}
+			}
+		}
+		if len(bloqueantes) > 0 {
+			eventos <- evento{tipo: eventoFalhaTransformacao, nome: m.Name, mensagem: "qualidade: " + strings.Join(bloqueantes, "; ")}
+			continue
+		}
+
+		select {
+		case out <- membro:
+			med.saida.Add(1)
+			metricas.Transformados.Inc()
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// deduplicar agrupa os membros convertidos em lotes e consulta quais já existem no banco final,
+// comparando o hash do conteúdo com o gravado: membros novos seguem para inserção, existentes com
+// conteúdo diferente seguem para atualização e os inalterados não geram escrita (nem mudam dataModificacao).
+// Nomes repetidos dentro da própria execução são tratados como duplicados.
+func (g *getDataBancoInicial) deduplicar(ctx context.Context, tamanhoLote int, in <-chan bancofinal.Membro, out chan<- itemCarga, eventos chan<- evento, med *medidorEtapa) error {
+	vistos := make(map[string]bool)
+	lote := make([]bancofinal.Membro, 0, tamanhoLote)
+
+	flush := func() error {
+		if len(lote) == 0 {
+			return nil
+		}
+
+		inicio := time.Now()
+		nomes := make([]string, len(lote))
+		for i, m := range lote {
+			nomes[i] = m.Name
+		}
+		ctxLote, span := tracing.Span(ctx, "etl.deduplicacao.lote", attribute.Int("etl.lote.tamanho", len(lote)))
+		existentes, err := g.final.HashesByNames(ctxLote, nomes)
+		span.SetAttributes(attribute.Int("etl.lote.existentes", len(existentes)))
+		tracing.Finalizar(span, err)
+		med.medir(inicio)
+		if err != nil {
+			return fmt.Errorf("erro ao verificar existência dos membros: %w", err)
+		}
+
+		for _, m := range lote {
+			hash, existe := existentes[m.Name]
+			if existe && hash == m.Hash {
+				eventos <- evento{tipo: eventoInalterado, nome: m.Name}
+				continue
+			}
+			select {
+			case out <- itemCarga{membro: m, atualizar: existe}:
+				med.saida.Add(1)
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		lote = lote[:0]
+		return nil
+	}
+
+	ticker := time.NewTicker(intervaloFlushLote)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case m, ok := <-in:
+			if !ok {
+				return flush()
+			}
+			med.entrada.Add(1)
+			if vistos[m.Name] {
+				eventos <- evento{tipo: eventoDuplicado, nome: m.Name}
+				continue
+			}
+			vistos[m.Name] = true
+
+			lote = append(lote, m)
+			if len(lote) >= tamanhoLote {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		case <-ticker.C:
+			if err := flush(); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// carregar insere os membros novos e substitui os alterados no banco final. Erros transitórios (rede, troca de primário, timeout)
+// são repetidos com backoff exponencial; os demais são classificados e reportados ao coletor.
+//
+// Cada tentativa de escrita passa pelo controle de carga, que aplica o limite de operações por segundo
+// e a concorrência (fixa ou adaptativa), alimentado pela latência e pelos erros de sobrecarga observados.
+func (g *getDataBancoInicial) carregar(ctx context.Context, controle controlecarga.ControleCarga, in <-chan itemCarga, eventos chan<- evento, med *medidorEtapa) {
+	politica := retry.PoliticaPadrao()
+	repetivel := func(err error) bool { return database.ClassificarErro(err).Repetivel() }
+
+	for item := range in {
+		m := item.membro
+		med.entrada.Add(1)
+		inicio := time.Now()
+		ctxMembro, span := tracing.Span(ctx, "etl.carga.membro", attribute.Bool("etl.atualizacao", item.atualizar))
+		tentativa := 0
+		tentativas, err := retry.Executar(ctxMembro, politica, func() error {
+			tentativa++
+			if err := controle.Adquirir(ctxMembro); err != nil {
+				return err
+			}
+			inicioEscrita := time.Now()
+			var err error
+			if item.atualizar {
+				err = g.final.Replace(ctxMembro, m)
+			} else {
+				err = g.final.Insert(ctxMembro, m)
+				if err != nil && tentativa > 1 && g.insercaoAplicada(ctxMembro, m, err) {
+					err = nil
+				}
+			}
+			controle.Liberar(time.Since(inicioEscrita), err != nil && repetivel(err))
+			metricas.LimiteCarga.Set(float64(controle.Limite()))
+			return err
+		}, repetivel)
+		span.SetAttributes(attribute.Int("etl.tentativas", tentativas))
+		tracing.Finalizar(span, err)
+		med.medir(inicio)
+
+		if err != nil {
+			eventos <- evento{tipo: eventoFalhaInsercao, nome: m.Name, tentativas: tentativas, erro: database.ClassificarErro(err)}
+			continue
+		}
+		med.saida.Add(1)
+		if item.atualizar {
+			eventos <- evento{tipo: eventoAtualizado, nome: m.Name}
+		} else {
+			eventos <- evento{tipo: eventoInserido, nome: m.Name}
+		}
+	}
+}
+
+// insercaoAplicada indica se a chave duplicada recebida ao repetir uma inserção é da própria inserção:
+// a tentativa anterior pode ter expirado no cliente depois de aplicada pelo servidor. É o caso quando o
+// documento gravado com o mesmo nome tem o hash do membro; com outro conteúdo, o duplicado é real.
+func (g *getDataBancoInicial) insercaoAplicada(ctx context.Context, m bancofinal.Membro, err error) bool {
+	if database.ClassificarErro(err).Categoria != database.CategoriaDuplicado {
+		return false
+	}
+	hashes, errConsulta := g.final.HashesByNames(ctx, []string{m.Name})
+	if errConsulta != nil {
+		return false
+	}
+	hash, ok := hashes[m.Name]
+	return ok && hash == m.Hash
+}
+
+// simularCarga substitui a carga no modo dry-run: os membros que seriam inseridos ou atualizados
+// são apenas contabilizados, sem nenhuma escrita no banco final.
+func simularCarga(in <-chan itemCarga, eventos chan<- evento, med *medidorEtapa) {
+	for item := range in {
+		med.entrada.Add(1)
+		med.saida.Add(1)
+		if item.atualizar {
+			eventos <- evento{tipo: eventoSimuladoAtualizacao, nome: item.membro.Name}
+		} else {
+			eventos <- evento{tipo: eventoSimulado, nome: item.membro.Name}
+		}
+	}
+}
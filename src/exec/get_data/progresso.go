@@ -0,0 +1,65 @@
+package getdata
+
+import "sync/atomic"
+
+// Progresso acompanha o andamento de uma execução enquanto ela ocorre.
+// É atualizado pelo pipeline e pode ser lido por outras goroutines (ex: API de controle).
+type Progresso struct {
+	extraidos   atomic.Int64
+	inseridos   atomic.Int64
+	atualizados atomic.Int64
+	inalterados atomic.Int64
+	duplicados  atomic.Int64
+	falhas      atomic.Int64
+}
+
+// ProgressoAtual é uma leitura instantânea do Progresso.
+type ProgressoAtual struct {
+	Extraidos   int64 `json:"extraidos"`
+	Inseridos   int64 `json:"inseridos"`
+	Atualizados int64 `json:"atualizados"`
+	Inalterados int64 `json:"inalterados"`
+	Duplicados  int64 `json:"duplicados"`
+	Falhas      int64 `json:"falhas"`
+}
+
+// Atual retorna os contadores no momento da chamada. Aceita receptor nil.
+func (p *Progresso) Atual() ProgressoAtual {
+	if p == nil {
+		return ProgressoAtual{}
+	}
+	return ProgressoAtual{
+		Extraidos:   p.extraidos.Load(),
+		Inseridos:   p.inseridos.Load(),
+		Atualizados: p.atualizados.Load(),
+		Inalterados: p.inalterados.Load(),
+		Duplicados:  p.duplicados.Load(),
+		Falhas:      p.falhas.Load(),
+	}
+}
+
+// registrar contabiliza o evento de um membro. Aceita receptor nil.
+func (p *Progresso) registrar(ev evento) {
+	if p == nil {
+		return
+	}
+	switch ev.tipo {
+	case eventoInserido, eventoSimulado:
+		p.inseridos.Add(1)
+	case eventoAtualizado, eventoSimuladoAtualizacao:
+		p.atualizados.Add(1)
+	case eventoInalterado:
+		p.inalterados.Add(1)
+	case eventoDuplicado:
+		p.duplicados.Add(1)
+	case eventoFalhaTransformacao, eventoFalhaInsercao:
+		p.falhas.Add(1)
+	}
+}
+
+// extraido contabiliza um membro lido do banco inicial. Aceita receptor nil.
+func (p *Progresso) extraido() {
+	if p != nil {
+		p.extraidos.Add(1)
+	}
+}
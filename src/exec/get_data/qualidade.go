@@ -0,0 +1,47 @@
+package getdata
+
+import (
+	"etl-service/src/exec/domain"
+	"sync"
+)
+
+// registroEmails guarda o primeiro membro de cada e-mail visto na execução, para detectar
+// o mesmo endereço usado por membros diferentes. É compartilhado pelos workers da transformação.
+type registroEmails struct {
+	mu    sync.Mutex
+	donos map[string]string
+}
+
+// novoRegistroEmails cria um registro vazio.
+func novoRegistroEmails() *registroEmails {
+	return &registroEmails{donos: make(map[string]string)}
+}
+
+// registrar associa o e-mail ao membro e, se ele já pertencia a outro membro, retorna o nome desse membro.
+// E-mails vazios e a repetição do mesmo membro (tratada pela deduplicação) não são considerados.
+func (r *registroEmails) registrar(email, nome string) string {
+	if email == "" {
+		return ""
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	dono, ok := r.donos[email]
+	if !ok {
+		r.donos[email] = nome
+		return ""
+	}
+	if dono == nome {
+		return ""
+	}
+	return dono
+}
+
+// avisoEmailCompartilhado descreve o e-mail já usado por outro membro.
+func avisoEmailCompartilhado(email, outro string) domain.Aviso {
+	return domain.Aviso{
+		Codigo:   domain.AvisoEmailCompartilhado,
+		Campo:    "email",
+		Valor:    email,
+		Mensagem: "mesmo e-mail do membro " + outro,
+	}
+}
@@ -0,0 +1,126 @@
+package getdata
+
+import (
+	"etl-service/src/config/database"
+	"etl-service/src/config/metricas"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Relatorio resume o resultado de uma execução do ETL.
+// É utilizado pela CLI para imprimir o resumo e decidir o código de saída.
+type Relatorio struct {
+	Total              int                        // Membros lidos do banco inicial
+	Inseridos          int                        // Membros inseridos com sucesso no banco final (no dry-run, os que seriam inseridos)
+	Atualizados        []string                   // Membros existentes com conteúdo alterado, atualizados (no dry-run, os que seriam atualizados)
+	Inalterados        int                        // Membros existentes com o mesmo conteúdo, que não geraram escrita
+	Duplicados         []string                   // Nomes que se repetiram na execução
+	ErrosTransformacao []string                   // Membros que falharam na conversão para o modelo final
+	ErrosInsercao      []string                   // Membros que falharam na inserção ou atualização, no formato "nome [categoria]: motivo"
+	FalhasPorCategoria map[database.Categoria]int // Contagem das falhas de inserção ou atualização por categoria
+	MotivosFalha       map[string]int             // Contagem das falhas (transformação e inserção) por motivo, sem o nome do membro
+	Avisos             []string                   // Valores aceitos que merecem revisão, no formato "nome [severidade]: campo 'valor': mensagem"
+	AvisosPorCodigo    map[string]int             // Contagem dos avisos por código (ex: email_invalido)
+	Conjuges           int                        // Membros vinculados ao documento do cônjuge
+	Inconsistencias    []string                   // Casais cujos dados não conferem, no formato "membro <-> cônjuge: motivo"
+	Familias           int                        // Famílias (domicílios) calculadas após a carga
+	Observacoes        []string                   // Observações sobre o alcance da execução (ex: leitura completa em uma execução incremental)
+	Etapas             []EstatisticaEtapa         // Estatísticas de cada etapa do pipeline
+	LimiteCarga        int                        // Limite de concorrência da carga ao final da execução
+	AjustesCarga       int                        // Quantas vezes o modo adaptativo alterou o limite de concorrência
+	Duracao            time.Duration              // Tempo total da execução
+}
+
+// Falhas retorna a quantidade de membros que não puderam ser processados.
+func (r Relatorio) Falhas() int {
+	return len(r.ErrosTransformacao) + len(r.ErrosInsercao)
+}
+
+// Parcial indica se a execução terminou com pelo menos uma falha de membro.
+func (r Relatorio) Parcial() bool {
+	return r.Falhas() > 0
+}
+
+// ContagemMotivo é um motivo de falha e quantos membros falharam por ele.
+type ContagemMotivo struct {
+	Motivo string `json:"motivo"`
+	Total  int    `json:"total"`
+}
+
+// PrincipaisFalhas retorna os n motivos de falha mais frequentes, do mais para o menos frequente.
+func (r Relatorio) PrincipaisFalhas(n int) []ContagemMotivo {
+	lista := make([]ContagemMotivo, 0, len(r.MotivosFalha))
+	for motivo, total := range r.MotivosFalha {
+		lista = append(lista, ContagemMotivo{Motivo: motivo, Total: total})
+	}
+	sort.Slice(lista, func(i, j int) bool {
+		if lista[i].Total != lista[j].Total {
+			return lista[i].Total > lista[j].Total
+		}
+		return lista[i].Motivo < lista[j].Motivo
+	})
+	if len(lista) > n {
+		lista = lista[:n]
+	}
+	return lista
+}
+
+// contarMotivo acumula a falha no motivo informado.
+func (r *Relatorio) contarMotivo(motivo string) {
+	if r.MotivosFalha == nil {
+		r.MotivosFalha = make(map[string]int)
+	}
+	r.MotivosFalha[motivo]++
+}
+
+// registrar consolida no relatório (e nas métricas) o evento enviado por uma etapa do pipeline.
+func (r *Relatorio) registrar(ev evento) {
+	switch ev.tipo {
+	case eventoFalhaTransformacao:
+		r.ErrosTransformacao = append(r.ErrosTransformacao, fmt.Sprintf("%s: %s", ev.nome, ev.mensagem))
+		r.contarMotivo("[transformacao] " + ev.mensagem)
+		metricas.Falhas.WithLabelValues("transformacao", "conversao").Inc()
+	case eventoDuplicado:
+		r.Duplicados = append(r.Duplicados, ev.nome)
+		metricas.Duplicados.Inc()
+	case eventoInserido:
+		r.Inseridos++
+		metricas.Inseridos.Inc()
+	case eventoSimulado:
+		r.Inseridos++
+	case eventoAtualizado:
+		r.Atualizados = append(r.Atualizados, ev.nome)
+		metricas.Atualizados.Inc()
+	case eventoSimuladoAtualizacao:
+		r.Atualizados = append(r.Atualizados, ev.nome)
+	case eventoInalterado:
+		r.Inalterados++
+		metricas.Inalterados.Inc()
+	case eventoAviso:
+		r.Avisos = append(r.Avisos, fmt.Sprintf("%s [%s]: %s", ev.nome, ev.severidade, ev.mensagem))
+		if r.AvisosPorCodigo == nil {
+			r.AvisosPorCodigo = make(map[string]int)
+		}
+		r.AvisosPorCodigo[ev.codigo]++
+		metricas.Avisos.WithLabelValues(ev.codigo, string(ev.severidade)).Inc()
+	case eventoFalhaInsercao:
+		r.registrarFalhaInsercao(ev.nome, ev.tentativas, ev.erro)
+		metricas.Falhas.WithLabelValues("carga", string(ev.erro.Categoria)).Inc()
+	}
+}
+
+// registrarFalhaInsercao adiciona uma falha de inserção já classificada ao relatório.
+func (r *Relatorio) registrarFalhaInsercao(nome string, tentativas int, err *database.ErroEscrita) {
+	if r.FalhasPorCategoria == nil {
+		r.FalhasPorCategoria = make(map[database.Categoria]int)
+	}
+	r.FalhasPorCategoria[err.Categoria]++
+	r.contarMotivo(fmt.Sprintf("[%s] %s", err.Categoria, err.Motivo))
+
+	linha := fmt.Sprintf("%s [%s]: %s", nome, err.Categoria, err.Motivo)
+	if tentativas > 1 {
+		linha += fmt.Sprintf(" (após %d tentativas)", tentativas)
+	}
+	r.ErrosInsercao = append(r.ErrosInsercao, linha)
+}
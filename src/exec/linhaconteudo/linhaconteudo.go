@@ -0,0 +1,38 @@
+package linhaconteudo
+
+import (
+	"bufio"
+	"strings"
+	"unicode/utf8"
+)
+
+// TamanhoMaximo é o tamanho máximo, em bytes, de uma linha de conteúdo do iCalendar (RFC 5545, 3.1)
+// e do vCard (RFC 6350, 3.2) antes da quebra, sem contar o CRLF.
+const TamanhoMaximo = 75
+
+// escape escapa barra invertida, ponto e vírgula, vírgula e quebras de linha.
+var escape = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// Escapar escapa os caracteres especiais de um valor de texto, ou de um componente de valor estruturado,
+// conforme as regras comuns ao iCalendar (RFC 5545, 3.3.11) e ao vCard (RFC 6350, 3.4).
+func Escapar(texto string) string {
+	return escape.Replace(texto)
+}
+
+// Escrever grava a linha terminada em CRLF, quebrando-a a cada TamanhoMaximo bytes sem dividir caracteres UTF-8;
+// as linhas de continuação começam com um espaço.
+func Escrever(w *bufio.Writer, texto string) {
+	limite := TamanhoMaximo
+	for len(texto) > limite {
+		corte := limite
+		for corte > 0 && !utf8.RuneStart(texto[corte]) {
+			corte--
+		}
+		w.WriteString(texto[:corte])
+		w.WriteString("\r\n ")
+		texto = texto[corte:]
+		limite = TamanhoMaximo - 1
+	}
+	w.WriteString(texto)
+	w.WriteString("\r\n")
+}
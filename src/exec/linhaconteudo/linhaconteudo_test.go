@@ -0,0 +1,82 @@
+package linhaconteudo
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestEscapar(t *testing.T) {
+	casos := []struct {
+		valor    string
+		esperado string
+	}{
+		{"Ana", "Ana"},
+		{"Silva, Ana", `Silva\, Ana`},
+		{"Apto 4; Bloco B", `Apto 4\; Bloco B`},
+		{`C:\dados`, `C:\\dados`},
+		{"linha 1\nlinha 2", `linha 1\nlinha 2`},
+		{"linha 1\r\nlinha 2", `linha 1\nlinha 2`},
+	}
+	for _, c := range casos {
+		if obtido := Escapar(c.valor); obtido != c.esperado {
+			t.Errorf("Escapar(%q) = %q, esperado %q", c.valor, obtido, c.esperado)
+		}
+	}
+}
+
+// escrever retorna o texto gravado por Escrever.
+func escrever(texto string) string {
+	var b strings.Builder
+	w := bufio.NewWriter(&b)
+	Escrever(w, texto)
+	w.Flush()
+	return b.String()
+}
+
+func TestEscreverLinhaCurta(t *testing.T) {
+	for _, texto := range []string{"", "BEGIN:VCARD", strings.Repeat("a", TamanhoMaximo)} {
+		if obtido := escrever(texto); obtido != texto+"\r\n" {
+			t.Errorf("Escrever(%d bytes) = %q, esperado a linha sem quebra", len(texto), obtido)
+		}
+	}
+}
+
+func TestEscreverQuebraLinhasLongas(t *testing.T) {
+	casos := []string{
+		"DESCRIPTION:" + strings.Repeat("a", 200),
+		"FN:" + strings.Repeat("João Conceição ", 12),
+		"SUMMARY:" + strings.Repeat("ã", 100),
+		"X:" + strings.Repeat("🎂", 40),
+	}
+	for _, texto := range casos {
+		obtido := escrever(texto)
+		if !strings.HasSuffix(obtido, "\r\n") {
+			t.Fatalf("a saída deve terminar em CRLF: %q", obtido)
+		}
+		linhas := strings.Split(strings.TrimSuffix(obtido, "\r\n"), "\r\n")
+		if len(linhas) < 2 {
+			t.Fatalf("Escrever(%d bytes) não quebrou a linha", len(texto))
+		}
+		var juntas strings.Builder
+		for i, linha := range linhas {
+			if len(linha) > TamanhoMaximo {
+				t.Errorf("linha %d com %d bytes, máximo %d", i, len(linha), TamanhoMaximo)
+			}
+			if !utf8.ValidString(linha) {
+				t.Errorf("linha %d divide um caractere UTF-8: %q", i, linha)
+			}
+			if i > 0 {
+				if !strings.HasPrefix(linha, " ") {
+					t.Fatalf("linha de continuação %d deve começar com espaço: %q", i, linha)
+				}
+				linha = linha[1:]
+			}
+			juntas.WriteString(linha)
+		}
+		if juntas.String() != texto {
+			t.Errorf("desfazer a quebra não reproduz o texto original:\n%q\n%q", juntas.String(), texto)
+		}
+	}
+}
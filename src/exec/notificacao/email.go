@@ -0,0 +1,128 @@
+package notificacao
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// email envia o resumo em texto puro (UTF-8) por SMTP.
+type email struct {
+	cfg SMTP
+}
+
+// newEmail cria o canal de e-mail.
+func newEmail(cfg SMTP) *email {
+	return &email{cfg: cfg}
+}
+
+func (e *email) nome() string {
+	return "email"
+}
+
+// enviar entrega a mensagem ao servidor SMTP, usando STARTTLS quando o servidor oferece;
+// a autenticação PLAIN só é usada quando há usuário configurado.
+//
+// A conexão é aberta com prazo e toda a conversa com o servidor é limitada por SMTP.Timeout e pelo
+// prazo de ctx (o que vencer primeiro): um servidor que aceita a conexão mas não responde não prende
+// a execução. O cancelamento de ctx fecha a conexão.
+func (e *email) enviar(ctx context.Context, resumo Resumo) error {
+	host, _, err := net.SplitHostPort(e.cfg.Endereco)
+	if err != nil {
+		return fmt.Errorf("endereço SMTP inválido '%s': %w", e.cfg.Endereco, err)
+	}
+
+	if e.cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.cfg.Timeout)
+		defer cancel()
+	}
+
+	dialer := net.Dialer{Timeout: e.cfg.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", e.cfg.Endereco)
+	if err != nil {
+		return fmt.Errorf("erro ao conectar ao servidor SMTP: %w", err)
+	}
+	defer conn.Close()
+	if prazo, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(prazo)
+	}
+	pararVigia := context.AfterFunc(ctx, func() { conn.Close() })
+	defer pararVigia()
+
+	if err := e.conversar(conn, host, resumo); err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("erro ao enviar e-mail: %w", ctx.Err())
+		}
+		return fmt.Errorf("erro ao enviar e-mail: %w", err)
+	}
+	return nil
+}
+
+// conversar executa o diálogo SMTP sobre a conexão já aberta, na mesma sequência de smtp.SendMail.
+func (e *email) conversar(conn net.Conn, host string, resumo Resumo) error {
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return err
+		}
+	}
+	if e.cfg.Usuario != "" {
+		if ok, _ := c.Extension("AUTH"); !ok {
+			return errors.New("servidor SMTP não oferece autenticação")
+		}
+		if err := c.Auth(smtp.PlainAuth("", e.cfg.Usuario, e.cfg.Senha, host)); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(e.cfg.De); err != nil {
+		return err
+	}
+	for _, para := range e.cfg.Para {
+		if err := c.Rcpt(para); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(e.mensagem(resumo)); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// mensagem monta a mensagem RFC 5322, com o assunto codificado (RFC 2047) para aceitar acentos.
+func (e *email) mensagem(resumo Resumo) []byte {
+	var buf bytes.Buffer
+	cabecalho := func(nome, valor string) {
+		fmt.Fprintf(&buf, "%s: %s\r\n", nome, valor)
+	}
+	cabecalho("From", e.cfg.De)
+	cabecalho("To", strings.Join(e.cfg.Para, ", "))
+	cabecalho("Subject", mime.QEncoding.Encode("utf-8", resumo.Assunto))
+	cabecalho("Date", time.Now().Format(time.RFC1123Z))
+	cabecalho("MIME-Version", "1.0")
+	cabecalho("Content-Type", "text/plain; charset=utf-8")
+	cabecalho("Content-Transfer-Encoding", "8bit")
+	cabecalho("X-ETL-Run-Id", resumo.RunID)
+	buf.WriteString("\r\n")
+	buf.WriteString(strings.ReplaceAll(strings.ReplaceAll(resumo.Texto, "\r\n", "\n"), "\n", "\r\n"))
+	return buf.Bytes()
+}
@@ -0,0 +1,163 @@
+package notificacao
+
+import (
+	"bufio"
+	"context"
+	"mime"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// mensagemSMTP é o que o servidor SMTP de teste recebeu em uma transação.
+type mensagemSMTP struct {
+	de    string
+	para  []string
+	dados string
+}
+
+// servidorSMTP inicia um servidor SMTP local mínimo, sem STARTTLS nem autenticação,
+// que aceita uma única mensagem e a entrega no canal retornado.
+func servidorSMTP(t *testing.T) (string, <-chan mensagemSMTP) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	recebida := make(chan mensagemSMTP, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+		r := bufio.NewReader(conn)
+		responder := func(linha string) { conn.Write([]byte(linha + "\r\n")) }
+		var msg mensagemSMTP
+		responder("220 localhost ESMTP")
+		for {
+			linha, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			comando := strings.TrimRight(linha, "\r\n")
+			switch maiusculo := strings.ToUpper(comando); {
+			case strings.HasPrefix(maiusculo, "EHLO"), strings.HasPrefix(maiusculo, "HELO"):
+				responder("250 localhost")
+			case strings.HasPrefix(maiusculo, "MAIL FROM:"):
+				msg.de = strings.Trim(comando[len("MAIL FROM:"):], "<> ")
+				responder("250 OK")
+			case strings.HasPrefix(maiusculo, "RCPT TO:"):
+				msg.para = append(msg.para, strings.Trim(comando[len("RCPT TO:"):], "<> "))
+				responder("250 OK")
+			case maiusculo == "DATA":
+				responder("354 fim com <CRLF>.<CRLF>")
+				var dados strings.Builder
+				for {
+					linha, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if linha == ".\r\n" {
+						break
+					}
+					dados.WriteString(linha)
+				}
+				msg.dados = dados.String()
+				responder("250 OK")
+				recebida <- msg
+			case maiusculo == "QUIT":
+				responder("221 até logo")
+				return
+			default:
+				responder("250 OK")
+			}
+		}
+	}()
+	return ln.Addr().String(), recebida
+}
+
+func TestEmailEnviaResumo(t *testing.T) {
+	endereco, recebida := servidorSMTP(t)
+	n, err := NewNotificador(Config{
+		Eventos:       []Evento{EventoLimite},
+		TaxaFalhasMax: 0.05,
+		SMTP:          SMTP{Endereco: endereco, De: "etl@exemplo.com.br", Para: []string{"ti@exemplo.com.br", "secretaria@exemplo.com.br"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	registro, relatorio := execucaoParcial()
+	if err := n.Notificar(context.Background(), registro, relatorio); err != nil {
+		t.Fatalf("Notificar() = %v", err)
+	}
+
+	var msg mensagemSMTP
+	select {
+	case msg = <-recebida:
+	case <-time.After(5 * time.Second):
+		t.Fatal("o servidor SMTP não recebeu a mensagem")
+	}
+	if msg.de != "etl@exemplo.com.br" {
+		t.Errorf("remetente = %q", msg.de)
+	}
+	if strings.Join(msg.para, ",") != "ti@exemplo.com.br,secretaria@exemplo.com.br" {
+		t.Errorf("destinatários = %v", msg.para)
+	}
+
+	cabecalhos, corpo, _ := strings.Cut(msg.dados, "\r\n\r\n")
+	cabecalhos += "\r\n"
+	if !strings.Contains(cabecalhos, "X-ETL-Run-Id: run-1\r\n") {
+		t.Errorf("cabeçalho X-ETL-Run-Id ausente em:\n%s", cabecalhos)
+	}
+	if !strings.Contains(cabecalhos, "Content-Type: text/plain; charset=utf-8\r\n") {
+		t.Errorf("Content-Type ausente em:\n%s", cabecalhos)
+	}
+	for _, linha := range strings.Split(cabecalhos, "\r\n") {
+		if valor, ok := strings.CutPrefix(linha, "Subject: "); ok {
+			assunto, err := new(mime.WordDecoder).DecodeHeader(valor)
+			if err != nil || !strings.Contains(assunto, "noturno") {
+				t.Errorf("assunto = %q (%v), esperado com o nome do job", assunto, err)
+			}
+		}
+	}
+	if !strings.Contains(corpo, "Falhas:      10 (10.0%)") {
+		t.Errorf("corpo sem o total de falhas:\n%s", corpo)
+	}
+	if strings.Contains(strings.ReplaceAll(corpo, "\r\n", ""), "\n") {
+		t.Error("o corpo deve usar apenas CRLF como quebra de linha")
+	}
+}
+
+func TestEmailServidorSemRespostaRespeitaTimeout(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	// Aceita a conexão e nunca envia a saudação
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		time.Sleep(5 * time.Second)
+	}()
+
+	e := newEmail(SMTP{Endereco: ln.Addr().String(), De: "etl@exemplo.com.br", Para: []string{"ti@exemplo.com.br"}, Timeout: 200 * time.Millisecond})
+	inicio := time.Now()
+	if err := e.enviar(context.Background(), Resumo{RunID: "run-1", Texto: "corpo"}); err == nil {
+		t.Fatal("enviar() = nil, esperado erro de timeout")
+	}
+	if decorrido := time.Since(inicio); decorrido > 2*time.Second {
+		t.Errorf("enviar() levou %v, esperado até o timeout", decorrido)
+	}
+}
@@ -0,0 +1,138 @@
+package notificacao
+
+import (
+	"context"
+	"etl-service/src/config/env"
+	"etl-service/src/config/model/historico"
+	getdata "etl-service/src/exec/get_data"
+	"strings"
+	"time"
+)
+
+// Evento identifica o motivo de uma notificação.
+type Evento string
+
+// Eventos que podem disparar notificações, configurados em ETL_NOTIFY_EVENTS.
+const (
+	EventoFinalizada Evento = "finalizada" // Toda execução finalizada, qualquer que seja o status
+	EventoFalha      Evento = "falha"      // Execução interrompida por erro fatal, lease perdida ou cancelamento
+	EventoLimite     Evento = "limite"     // Falhas ou duplicados acima dos limites configurados
+)
+
+// Notificador define a interface do serviço que avisa, por webhook e/ou e-mail,
+// o resultado das execuções do ETL.
+type Notificador interface {
+	// Notificar classifica a execução finalizada em um evento e, se ele estiver habilitado,
+	// envia o resumo por todos os canais configurados.
+	// Retorna os erros de envio combinados; a falha de um canal não impede os demais.
+	Notificar(ctx context.Context, registro historico.Execucao, relatorio getdata.Relatorio) error
+}
+
+// Resumo são os dados de uma execução disponíveis para os templates e enviados no corpo do webhook.
+type Resumo struct {
+	Evento             Evento                   `json:"evento"`
+	RunID              string                   `json:"runId"`
+	Job                string                   `json:"job"`
+	Origem             string                   `json:"origem,omitempty"`
+	Status             string                   `json:"status"`
+	DryRun             bool                     `json:"dryRun"`
+	Inicio             time.Time                `json:"inicio"`
+	Fim                time.Time                `json:"fim"`
+	Duracao            string                   `json:"duracao"`
+	Total              int                      `json:"total"`
+	Inseridos          int                      `json:"inseridos"`
+	Atualizados        int                      `json:"atualizados"`
+	Inalterados        int                      `json:"inalterados"`
+	Duplicados         int                      `json:"duplicados"`
+	Falhas             int                      `json:"falhas"`
+	Avisos             int                      `json:"avisos"`          // Avisos de qualidade dos dados
+	Inconsistencias    int                      `json:"inconsistencias"` // Casais cujos dados não conferem
+	TaxaFalhas         float64                  `json:"taxaFalhas"`      // Falhas / total lido, entre 0 e 1
+	FalhasPorCategoria map[string]int           `json:"falhasPorCategoria,omitempty"`
+	ExemplosDuplicados []string                 `json:"exemplosDuplicados,omitempty"` // Primeiros membros duplicados
+	PrincipaisFalhas   []getdata.ContagemMotivo `json:"principaisFalhas,omitempty"`   // Motivos de falha mais frequentes
+	Observacoes        []string                 `json:"observacoes,omitempty"`        // Observações sobre o alcance da execução
+	Erro               string                   `json:"erro,omitempty"`
+	Assunto            string                   `json:"assunto"` // Assunto gerado pelo template
+	Texto              string                   `json:"texto"`   // Corpo gerado pelo template
+}
+
+// Config define quando notificar e por quais canais.
+type Config struct {
+	Eventos       []Evento      // Eventos que geram notificação
+	TaxaFalhasMax float64       // Taxa de falhas acima da qual a execução gera o evento limite (0 desabilita)
+	FalhasMax     int           // Número de falhas acima do qual a execução gera o evento limite (0 desabilita)
+	DuplicadosMax int           // Número de duplicados acima do qual a execução gera o evento limite (0 desabilita)
+	Exemplos      int           // Quantos duplicados e motivos de falha incluir no resumo
+	Template      string        // Arquivo com o template (text/template) do corpo; vazio usa o template padrão
+	Timeout       time.Duration // Tempo máximo da notificação de uma execução, somando todos os canais (0 desabilita)
+
+	Webhook Webhook
+	SMTP    SMTP
+}
+
+// Webhook configura o envio do resumo em JSON por HTTP POST.
+type Webhook struct {
+	URL     string        // Endereço do webhook; vazio desabilita o canal
+	Segredo string        // Chave da assinatura HMAC-SHA256 do corpo; vazio envia sem assinatura
+	Timeout time.Duration // Tempo máximo de cada tentativa
+}
+
+// SMTP configura o envio do resumo por e-mail.
+type SMTP struct {
+	Endereco string        // host:porta do servidor; vazio desabilita o canal
+	Usuario  string        // Usuário da autenticação PLAIN; vazio envia sem autenticação
+	Senha    string        // Senha da autenticação PLAIN
+	De       string        // Remetente
+	Para     []string      // Destinatários
+	Timeout  time.Duration // Tempo máximo da conexão e de toda a conversa com o servidor
+}
+
+// ConfigPadrao lê a configuração das variáveis de ambiente:
+//
+//   - ETL_NOTIFY_EVENTS: eventos separados por vírgula (padrão "falha,limite")
+//   - ETL_NOTIFY_MAX_FAILURE_RATE (padrão 0.05), ETL_NOTIFY_MAX_FAILURES e ETL_NOTIFY_MAX_DUPLICATES (padrão 0, desabilitados)
+//   - ETL_NOTIFY_TOP: duplicados e motivos de falha listados no resumo (padrão 5)
+//   - ETL_NOTIFY_TEMPLATE: arquivo de template do corpo
+//   - ETL_NOTIFY_TIMEOUT: tempo máximo da notificação de uma execução (padrão 1m)
+//   - ETL_NOTIFY_WEBHOOK_URL, ETL_NOTIFY_WEBHOOK_SECRET e ETL_NOTIFY_WEBHOOK_TIMEOUT (padrão 10s)
+//   - ETL_NOTIFY_SMTP_ADDR, ETL_NOTIFY_SMTP_USER, ETL_NOTIFY_SMTP_PASSWORD, ETL_NOTIFY_SMTP_FROM e ETL_NOTIFY_SMTP_TO (separados por vírgula)
+//   - ETL_NOTIFY_SMTP_TIMEOUT: tempo máximo do envio por e-mail (padrão 30s)
+func ConfigPadrao() Config {
+	cfg := Config{
+		TaxaFalhasMax: env.GetFloat("ETL_NOTIFY_MAX_FAILURE_RATE", 0.05),
+		FalhasMax:     env.GetInt("ETL_NOTIFY_MAX_FAILURES", 0),
+		DuplicadosMax: env.GetInt("ETL_NOTIFY_MAX_DUPLICATES", 0),
+		Exemplos:      env.GetInt("ETL_NOTIFY_TOP", 5),
+		Template:      env.GetString("ETL_NOTIFY_TEMPLATE", ""),
+		Timeout:       env.GetDuration("ETL_NOTIFY_TIMEOUT", time.Minute),
+		Webhook: Webhook{
+			URL:     env.GetString("ETL_NOTIFY_WEBHOOK_URL", ""),
+			Segredo: env.GetString("ETL_NOTIFY_WEBHOOK_SECRET", ""),
+			Timeout: env.GetDuration("ETL_NOTIFY_WEBHOOK_TIMEOUT", 10*time.Second),
+		},
+		SMTP: SMTP{
+			Endereco: env.GetString("ETL_NOTIFY_SMTP_ADDR", ""),
+			Usuario:  env.GetString("ETL_NOTIFY_SMTP_USER", ""),
+			Senha:    env.GetString("ETL_NOTIFY_SMTP_PASSWORD", ""),
+			De:       env.GetString("ETL_NOTIFY_SMTP_FROM", ""),
+			Para:     dividir(env.GetString("ETL_NOTIFY_SMTP_TO", "")),
+			Timeout:  env.GetDuration("ETL_NOTIFY_SMTP_TIMEOUT", 30*time.Second),
+		},
+	}
+	for _, e := range dividir(env.GetString("ETL_NOTIFY_EVENTS", "falha,limite")) {
+		cfg.Eventos = append(cfg.Eventos, Evento(e))
+	}
+	return cfg
+}
+
+// dividir separa uma lista por vírgulas, descartando itens vazios.
+func dividir(s string) []string {
+	var itens []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			itens = append(itens, item)
+		}
+	}
+	return itens
+}
@@ -0,0 +1,238 @@
+package notificacao
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"etl-service/src/config/logger"
+	"etl-service/src/config/model/historico"
+	getdata "etl-service/src/exec/get_data"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// templateAssunto gera o assunto do e-mail e o campo assunto do webhook.
+const templateAssunto = `[etl-service] {{.Job}}: {{.Status}}{{if .DryRun}} (dry-run){{end}} - {{.Inseridos}} inseridos, {{.Falhas}} falhas`
+
+// templatePadrao gera o corpo da notificação quando ETL_NOTIFY_TEMPLATE não é informado.
+const templatePadrao = `Execução {{.RunID}} do job {{.Job}} finalizada com status {{.Status}}{{if .DryRun}} (dry-run){{end}}.
+
+Evento:      {{.Evento}}
+Origem:      {{.Origem}}
+Início:      {{.Inicio.Format "02/01/2006 15:04:05"}}
+Duração:     {{.Duracao}}
+
+Lidos:       {{.Total}}
+Inseridos:   {{.Inseridos}}
+Atualizados: {{.Atualizados}}
+Inalterados: {{.Inalterados}}
+Duplicados:  {{.Duplicados}}
+Falhas:      {{.Falhas}} ({{porcentagem .TaxaFalhas}})
+Avisos:      {{.Avisos}}
+{{- if .Inconsistencias}}
+Inconsistências entre cônjuges: {{.Inconsistencias}}
+{{- end}}
+{{- range .Observacoes}}
+Observação: {{.}}
+{{- end}}
+{{- if .Erro}}
+
+Erro fatal: {{.Erro}}
+{{- end}}
+{{- if .FalhasPorCategoria}}
+
+Falhas de inserção por categoria:
+{{- range $categoria, $total := .FalhasPorCategoria}}
+  - {{$categoria}}: {{$total}}
+{{- end}}
+{{- end}}
+{{- if .PrincipaisFalhas}}
+
+Principais motivos de falha:
+{{- range .PrincipaisFalhas}}
+  - {{.Total}}x {{.Motivo}}
+{{- end}}
+{{- end}}
+{{- if .ExemplosDuplicados}}
+
+Duplicados (primeiros {{len .ExemplosDuplicados}} de {{.Duplicados}}):
+{{- range .ExemplosDuplicados}}
+  - {{.}}
+{{- end}}
+{{- end}}
+`
+
+// canal é um meio de entrega do resumo (webhook, e-mail).
+type canal interface {
+	nome() string
+	enviar(ctx context.Context, resumo Resumo) error
+}
+
+// notificador é a implementação concreta da interface Notificador.
+type notificador struct {
+	cfg     Config
+	assunto *template.Template
+	corpo   *template.Template
+	canais  []canal
+}
+
+// NewNotificador valida a configuração, interpreta os templates e cria os canais configurados.
+// Sem webhook nem SMTP configurados, o notificador retornado não envia nada.
+func NewNotificador(cfg Config) (Notificador, error) {
+	for _, e := range cfg.Eventos {
+		if e != EventoFinalizada && e != EventoFalha && e != EventoLimite {
+			return nil, fmt.Errorf("evento de notificação desconhecido: %q (use %s, %s ou %s)", e, EventoFinalizada, EventoFalha, EventoLimite)
+		}
+	}
+
+	funcoes := template.FuncMap{"porcentagem": porcentagem}
+	assunto := template.Must(template.New("assunto").Funcs(funcoes).Parse(templateAssunto))
+
+	textoCorpo := templatePadrao
+	if cfg.Template != "" {
+		conteudo, err := os.ReadFile(cfg.Template)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao ler template de notificação '%s': %w", cfg.Template, err)
+		}
+		textoCorpo = string(conteudo)
+	}
+	corpo, err := template.New("corpo").Funcs(funcoes).Parse(textoCorpo)
+	if err != nil {
+		return nil, fmt.Errorf("template de notificação inválido: %w", err)
+	}
+
+	n := &notificador{cfg: cfg, assunto: assunto, corpo: corpo}
+	if cfg.Webhook.URL != "" {
+		n.canais = append(n.canais, newWebhook(cfg.Webhook))
+	}
+	if cfg.SMTP.Endereco != "" {
+		if cfg.SMTP.De == "" || len(cfg.SMTP.Para) == 0 {
+			return nil, errors.New("notificação por e-mail exige ETL_NOTIFY_SMTP_FROM e ETL_NOTIFY_SMTP_TO")
+		}
+		n.canais = append(n.canais, newEmail(cfg.SMTP))
+	}
+	return n, nil
+}
+
+// Notificar envia o resumo da execução por todos os canais quando o evento dela está habilitado.
+// O envio por todos os canais é limitado por Config.Timeout, mesmo que ctx não tenha prazo.
+func (n *notificador) Notificar(ctx context.Context, registro historico.Execucao, relatorio getdata.Relatorio) error {
+	if len(n.canais) == 0 {
+		return nil
+	}
+	evento := n.classificar(registro)
+	if !n.habilitado(evento) {
+		return nil
+	}
+
+	resumo, err := n.resumir(evento, registro, relatorio)
+	if err != nil {
+		return err
+	}
+
+	if n.cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, n.cfg.Timeout)
+		defer cancel()
+	}
+
+	log := logger.DoContexto(ctx)
+	var erros []error
+	for _, c := range n.canais {
+		if err := c.enviar(ctx, resumo); err != nil {
+			erros = append(erros, fmt.Errorf("%s: %w", c.nome(), err))
+			continue
+		}
+		log.Info("notificação enviada", "canal", c.nome(), "evento", evento)
+	}
+	return errors.Join(erros...)
+}
+
+// classificar escolhe o evento mais específico da execução: falha quando ela não terminou (erro fatal,
+// lease perdida ou cancelamento, que deixam a carga incompleta), limite quando falhas ou duplicados
+// ultrapassaram os limites, e finalizada nos demais casos.
+func (n *notificador) classificar(registro historico.Execucao) Evento {
+	if registro.Status == historico.StatusFalha || registro.Status == historico.StatusCancelada {
+		return EventoFalha
+	}
+	if n.cfg.TaxaFalhasMax > 0 && taxaFalhas(registro) > n.cfg.TaxaFalhasMax {
+		return EventoLimite
+	}
+	if n.cfg.FalhasMax > 0 && registro.Falhas > n.cfg.FalhasMax {
+		return EventoLimite
+	}
+	if n.cfg.DuplicadosMax > 0 && registro.Duplicados > n.cfg.DuplicadosMax {
+		return EventoLimite
+	}
+	return EventoFinalizada
+}
+
+// habilitado indica se o evento deve ser notificado. Com finalizada habilitado,
+// toda execução é notificada, identificada pelo evento mais específico.
+func (n *notificador) habilitado(evento Evento) bool {
+	return slices.Contains(n.cfg.Eventos, evento) || slices.Contains(n.cfg.Eventos, EventoFinalizada)
+}
+
+// resumir monta o resumo da execução e gera o assunto e o corpo pelos templates.
+func (n *notificador) resumir(evento Evento, registro historico.Execucao, relatorio getdata.Relatorio) (Resumo, error) {
+	resumo := Resumo{
+		Evento:             evento,
+		RunID:              registro.ID,
+		Job:                registro.Job,
+		Origem:             registro.Origem,
+		Status:             registro.Status,
+		DryRun:             registro.DryRun,
+		Inicio:             registro.Inicio,
+		Fim:                time.Now(),
+		Duracao:            relatorio.Duracao.Round(time.Millisecond).String(),
+		Total:              registro.Total,
+		Inseridos:          registro.Inseridos,
+		Atualizados:        registro.Atualizados,
+		Inalterados:        registro.Inalterados,
+		Duplicados:         registro.Duplicados,
+		Falhas:             registro.Falhas,
+		Avisos:             registro.Avisos,
+		Inconsistencias:    registro.Inconsistencias,
+		TaxaFalhas:         taxaFalhas(registro),
+		FalhasPorCategoria: registro.FalhasPorCategoria,
+		PrincipaisFalhas:   relatorio.PrincipaisFalhas(n.cfg.Exemplos),
+		Observacoes:        registro.Observacoes,
+		Erro:               registro.Erro,
+	}
+	if registro.Fim != nil {
+		resumo.Fim = *registro.Fim
+	}
+	if len(relatorio.Duplicados) > 0 {
+		resumo.ExemplosDuplicados = relatorio.Duplicados[:min(n.cfg.Exemplos, len(relatorio.Duplicados))]
+	}
+
+	var buf bytes.Buffer
+	if err := n.assunto.Execute(&buf, resumo); err != nil {
+		return Resumo{}, fmt.Errorf("erro ao gerar assunto da notificação: %w", err)
+	}
+	resumo.Assunto = strings.TrimSpace(buf.String())
+
+	buf.Reset()
+	if err := n.corpo.Execute(&buf, resumo); err != nil {
+		return Resumo{}, fmt.Errorf("erro ao gerar texto da notificação: %w", err)
+	}
+	resumo.Texto = buf.String()
+	return resumo, nil
+}
+
+// taxaFalhas calcula a fração dos membros lidos que falharam.
+func taxaFalhas(registro historico.Execucao) float64 {
+	if registro.Total == 0 {
+		return 0
+	}
+	return float64(registro.Falhas) / float64(registro.Total)
+}
+
+// porcentagem formata uma fração como porcentagem com uma casa decimal.
+func porcentagem(v float64) string {
+	return fmt.Sprintf("%.1f%%", v*100)
+}
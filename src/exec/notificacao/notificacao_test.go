@@ -0,0 +1,74 @@
+package notificacao
+
+import (
+	"testing"
+
+	"etl-service/src/config/model/historico"
+)
+
+func TestClassificar(t *testing.T) {
+	cfg := Config{TaxaFalhasMax: 0.05, FalhasMax: 10, DuplicadosMax: 3}
+	casos := []struct {
+		nome     string
+		cfg      Config
+		registro historico.Execucao
+		esperado Evento
+	}{
+		{"erro fatal", cfg, historico.Execucao{Status: historico.StatusFalha}, EventoFalha},
+		{"erro fatal prevalece sobre limites", cfg, historico.Execucao{Status: historico.StatusFalha, Total: 10, Falhas: 10, Duplicados: 10}, EventoFalha},
+		{"sem falhas", cfg, historico.Execucao{Status: historico.StatusConcluida, Total: 100}, EventoFinalizada},
+		{"taxa no limite", cfg, historico.Execucao{Status: historico.StatusParcial, Total: 100, Falhas: 5}, EventoFinalizada},
+		{"taxa acima do limite", cfg, historico.Execucao{Status: historico.StatusParcial, Total: 100, Falhas: 6}, EventoLimite},
+		{"falhas acima do limite absoluto", Config{FalhasMax: 10}, historico.Execucao{Status: historico.StatusParcial, Total: 10000, Falhas: 11}, EventoLimite},
+		{"falhas no limite absoluto", Config{FalhasMax: 10}, historico.Execucao{Status: historico.StatusParcial, Total: 10000, Falhas: 10}, EventoFinalizada},
+		{"duplicados acima do limite", cfg, historico.Execucao{Status: historico.StatusConcluida, Total: 100, Duplicados: 4}, EventoLimite},
+		{"duplicados no limite", cfg, historico.Execucao{Status: historico.StatusConcluida, Total: 100, Duplicados: 3}, EventoFinalizada},
+		{"limites desabilitados", Config{}, historico.Execucao{Status: historico.StatusParcial, Total: 10, Falhas: 9, Duplicados: 50}, EventoFinalizada},
+		{"sem membros lidos", cfg, historico.Execucao{Status: historico.StatusConcluida}, EventoFinalizada},
+		{"cancelada", cfg, historico.Execucao{Status: historico.StatusCancelada, Total: 10}, EventoFalha},
+		{"cancelada prevalece sobre limites", cfg, historico.Execucao{Status: historico.StatusCancelada, Total: 10, Falhas: 10}, EventoFalha},
+		{"lease perdida", cfg, historico.Execucao{Status: historico.StatusFalha, Total: 10, Erro: "lease perdida: a trava expirou ou foi tomada por outra instância"}, EventoFalha},
+	}
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			n := &notificador{cfg: c.cfg}
+			if obtido := n.classificar(c.registro); obtido != c.esperado {
+				t.Errorf("classificar() = %q, esperado %q", obtido, c.esperado)
+			}
+		})
+	}
+}
+
+func TestHabilitado(t *testing.T) {
+	casos := []struct {
+		eventos  []Evento
+		evento   Evento
+		esperado bool
+	}{
+		{[]Evento{EventoFalha, EventoLimite}, EventoFalha, true},
+		{[]Evento{EventoFalha, EventoLimite}, EventoLimite, true},
+		{[]Evento{EventoFalha, EventoLimite}, EventoFinalizada, false},
+		{[]Evento{EventoFalha}, EventoLimite, false},
+		{[]Evento{EventoFinalizada}, EventoFalha, true},
+		{[]Evento{EventoFinalizada}, EventoLimite, true},
+		{nil, EventoFalha, false},
+	}
+	for _, c := range casos {
+		n := &notificador{cfg: Config{Eventos: c.eventos}}
+		if obtido := n.habilitado(c.evento); obtido != c.esperado {
+			t.Errorf("habilitado(%q) com eventos %v = %v, esperado %v", c.evento, c.eventos, obtido, c.esperado)
+		}
+	}
+}
+
+func TestNewNotificadorEventoDesconhecido(t *testing.T) {
+	if _, err := NewNotificador(Config{Eventos: []Evento{"sucesso"}}); err == nil {
+		t.Fatal("esperado erro para evento desconhecido")
+	}
+}
+
+func TestNewNotificadorSMTPIncompleto(t *testing.T) {
+	if _, err := NewNotificador(Config{SMTP: SMTP{Endereco: "localhost:25"}}); err == nil {
+		t.Fatal("esperado erro para SMTP sem remetente e destinatários")
+	}
+}
@@ -0,0 +1,113 @@
+package notificacao
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"etl-service/src/config/retry"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Cabeçalhos enviados ao webhook.
+const (
+	CabecalhoEvento     = "X-ETL-Event"     // Evento que gerou a notificação
+	CabecalhoTimestamp  = "X-ETL-Timestamp" // Instante do envio, em segundos desde a época Unix
+	CabecalhoAssinatura = "X-ETL-Signature" // "sha256=" + HMAC-SHA256 hexadecimal de "<timestamp>.<corpo>"
+)
+
+// erroStatus é a resposta do webhook com status diferente de 2xx.
+type erroStatus struct {
+	status int
+}
+
+func (e erroStatus) Error() string {
+	return fmt.Sprintf("webhook respondeu com status %d", e.status)
+}
+
+// webhook envia o resumo em JSON por HTTP POST.
+type webhook struct {
+	cfg     Webhook
+	cliente *http.Client
+	retry   retry.Politica
+}
+
+// newWebhook cria o canal de webhook com as tentativas da política de repetição padrão.
+func newWebhook(cfg Webhook) *webhook {
+	return &webhook{
+		cfg:     cfg,
+		cliente: &http.Client{Timeout: cfg.Timeout},
+		retry:   retry.PoliticaPadrao(),
+	}
+}
+
+func (w *webhook) nome() string {
+	return "webhook"
+}
+
+// enviar faz o POST do resumo, repetindo em erros de rede, 429 e 5xx.
+func (w *webhook) enviar(ctx context.Context, resumo Resumo) error {
+	corpo, err := json.Marshal(resumo)
+	if err != nil {
+		return fmt.Errorf("erro ao serializar resumo: %w", err)
+	}
+
+	_, err = retry.Executar(ctx, w.retry, func() error {
+		return w.postar(ctx, resumo.Evento, corpo)
+	}, repetivel)
+	return err
+}
+
+// postar faz uma tentativa de envio, assinando o corpo quando há segredo configurado.
+func (w *webhook) postar(ctx context.Context, evento Evento, corpo []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.cfg.URL, bytes.NewReader(corpo))
+	if err != nil {
+		return err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("User-Agent", "etl-service")
+	req.Header.Set(CabecalhoEvento, string(evento))
+	req.Header.Set(CabecalhoTimestamp, timestamp)
+	if w.cfg.Segredo != "" {
+		req.Header.Set(CabecalhoAssinatura, Assinar(w.cfg.Segredo, timestamp, corpo))
+	}
+
+	resp, err := w.cliente.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return erroStatus{status: resp.StatusCode}
+	}
+	return nil
+}
+
+// Assinar calcula a assinatura enviada em X-ETL-Signature. O receptor deve recalculá-la com o
+// mesmo segredo, compará-la em tempo constante e rejeitar timestamps antigos para evitar reenvios.
+func Assinar(segredo, timestamp string, corpo []byte) string {
+	mac := hmac.New(sha256.New, []byte(segredo))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(corpo)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// repetivel indica se vale tentar novamente: erros de rede, 429 e 5xx.
+func repetivel(err error) bool {
+	var status erroStatus
+	if errors.As(err, &status) {
+		return status.status == http.StatusTooManyRequests || status.status >= 500
+	}
+	return !errors.Is(err, context.Canceled)
+}
@@ -0,0 +1,212 @@
+package notificacao
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"etl-service/src/config/model/historico"
+	getdata "etl-service/src/exec/get_data"
+)
+
+// requisicao guarda o que o webhook de teste recebeu.
+type requisicao struct {
+	cabecalhos http.Header
+	corpo      []byte
+}
+
+// servidorWebhook inicia um webhook local que responde com os status informados, na ordem, e depois com 200.
+func servidorWebhook(t *testing.T, status ...int) (*httptest.Server, func() []requisicao) {
+	t.Helper()
+	var mu sync.Mutex
+	var recebidas []requisicao
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		corpo, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		recebidas = append(recebidas, requisicao{cabecalhos: r.Header.Clone(), corpo: corpo})
+		i := len(recebidas) - 1
+		mu.Unlock()
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if i < len(status) {
+			w.WriteHeader(status[i])
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, func() []requisicao {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]requisicao(nil), recebidas...)
+	}
+}
+
+// execucaoParcial retorna um registro e um relatório de uma execução com falhas.
+func execucaoParcial() (historico.Execucao, getdata.Relatorio) {
+	fim := time.Date(2026, 3, 1, 2, 5, 0, 0, time.UTC)
+	registro := historico.Execucao{
+		ID:         "run-1",
+		Job:        "noturno",
+		Origem:     "agendador",
+		Status:     historico.StatusParcial,
+		Inicio:     fim.Add(-5 * time.Minute),
+		Fim:        &fim,
+		Total:      100,
+		Inseridos:  90,
+		Falhas:     10,
+		Duplicados: 2,
+	}
+	relatorio := getdata.Relatorio{
+		Duplicados:   []string{"Ana", "Bia"},
+		MotivosFalha: map[string]int{"data de nascimento inválida": 7, "e-mail duplicado": 3},
+		Duracao:      5 * time.Minute,
+	}
+	return registro, relatorio
+}
+
+func TestWebhookAssinaOCorpo(t *testing.T) {
+	srv, recebidas := servidorWebhook(t)
+	n, err := NewNotificador(Config{
+		Eventos:       []Evento{EventoLimite},
+		TaxaFalhasMax: 0.05,
+		Exemplos:      1,
+		Webhook:       Webhook{URL: srv.URL, Segredo: "segredo", Timeout: time.Second},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	registro, relatorio := execucaoParcial()
+	if err := n.Notificar(context.Background(), registro, relatorio); err != nil {
+		t.Fatalf("Notificar() = %v", err)
+	}
+
+	lista := recebidas()
+	if len(lista) != 1 {
+		t.Fatalf("webhook recebeu %d requisições, esperado 1", len(lista))
+	}
+	req := lista[0]
+	if evento := req.cabecalhos.Get(CabecalhoEvento); evento != string(EventoLimite) {
+		t.Errorf("%s = %q, esperado %q", CabecalhoEvento, evento, EventoLimite)
+	}
+	timestamp := req.cabecalhos.Get(CabecalhoTimestamp)
+	segundos, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || time.Since(time.Unix(segundos, 0)) > time.Minute {
+		t.Errorf("%s = %q, esperado o instante do envio em segundos", CabecalhoTimestamp, timestamp)
+	}
+	if assinatura, esperada := req.cabecalhos.Get(CabecalhoAssinatura), Assinar("segredo", timestamp, req.corpo); assinatura != esperada {
+		t.Errorf("%s = %q, esperado %q", CabecalhoAssinatura, assinatura, esperada)
+	}
+	if tipo := req.cabecalhos.Get("Content-Type"); tipo != "application/json; charset=utf-8" {
+		t.Errorf("Content-Type = %q", tipo)
+	}
+
+	var resumo Resumo
+	if err := json.Unmarshal(req.corpo, &resumo); err != nil {
+		t.Fatalf("corpo não é JSON: %v", err)
+	}
+	if resumo.RunID != "run-1" || resumo.Job != "noturno" || resumo.Evento != EventoLimite {
+		t.Errorf("resumo = %+v", resumo)
+	}
+	if resumo.Total != 100 || resumo.Falhas != 10 || resumo.TaxaFalhas != 0.1 {
+		t.Errorf("totais do resumo = total %d, falhas %d, taxa %v", resumo.Total, resumo.Falhas, resumo.TaxaFalhas)
+	}
+	if len(resumo.ExemplosDuplicados) != 1 || resumo.ExemplosDuplicados[0] != "Ana" {
+		t.Errorf("exemplos de duplicados = %v, esperado [Ana]", resumo.ExemplosDuplicados)
+	}
+	if len(resumo.PrincipaisFalhas) != 1 || resumo.PrincipaisFalhas[0].Motivo != "data de nascimento inválida" {
+		t.Errorf("principais falhas = %v", resumo.PrincipaisFalhas)
+	}
+	if resumo.Assunto == "" || resumo.Texto == "" {
+		t.Error("assunto e texto devem ser gerados pelos templates")
+	}
+}
+
+func TestAssinar(t *testing.T) {
+	// HMAC-SHA256 de "1700000000.{}" com a chave "segredo", calculado de forma independente
+	const esperada = "sha256=28d73844c84580182772a1e98a60aeb8f04184b1bef0d4e147cfa59ebf0bfedc"
+	if obtida := Assinar("segredo", "1700000000", []byte("{}")); obtida != esperada {
+		t.Errorf("Assinar() = %q, esperado %q", obtida, esperada)
+	}
+	if Assinar("segredo", "1700000001", []byte("{}")) == esperada {
+		t.Error("o timestamp deve fazer parte da assinatura")
+	}
+}
+
+func TestWebhookSemSegredoNaoAssina(t *testing.T) {
+	srv, recebidas := servidorWebhook(t)
+	n, err := NewNotificador(Config{Eventos: []Evento{EventoFinalizada}, Webhook: Webhook{URL: srv.URL, Timeout: time.Second}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	registro, relatorio := execucaoParcial()
+	if err := n.Notificar(context.Background(), registro, relatorio); err != nil {
+		t.Fatalf("Notificar() = %v", err)
+	}
+	lista := recebidas()
+	if len(lista) != 1 {
+		t.Fatalf("webhook recebeu %d requisições, esperado 1", len(lista))
+	}
+	if assinatura := lista[0].cabecalhos.Get(CabecalhoAssinatura); assinatura != "" {
+		t.Errorf("%s = %q, esperado ausente sem segredo", CabecalhoAssinatura, assinatura)
+	}
+}
+
+func TestWebhookEventoDesabilitado(t *testing.T) {
+	srv, recebidas := servidorWebhook(t)
+	n, err := NewNotificador(Config{Eventos: []Evento{EventoFalha}, TaxaFalhasMax: 0.05, Webhook: Webhook{URL: srv.URL, Timeout: time.Second}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	registro, relatorio := execucaoParcial()
+	if err := n.Notificar(context.Background(), registro, relatorio); err != nil {
+		t.Fatalf("Notificar() = %v", err)
+	}
+	if lista := recebidas(); len(lista) != 0 {
+		t.Errorf("webhook recebeu %d requisições, esperado nenhuma para evento limite desabilitado", len(lista))
+	}
+}
+
+func TestWebhookRepeteErrosTemporarios(t *testing.T) {
+	t.Setenv("ETL_RETRY_BASE", "1ms")
+	t.Setenv("ETL_RETRY_MAX", "1ms")
+
+	srv, recebidas := servidorWebhook(t, http.StatusServiceUnavailable, http.StatusTooManyRequests)
+	n, err := NewNotificador(Config{Eventos: []Evento{EventoFinalizada}, Webhook: Webhook{URL: srv.URL, Timeout: time.Second}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	registro, relatorio := execucaoParcial()
+	if err := n.Notificar(context.Background(), registro, relatorio); err != nil {
+		t.Fatalf("Notificar() = %v", err)
+	}
+	if lista := recebidas(); len(lista) != 3 {
+		t.Errorf("webhook recebeu %d requisições, esperado 3 (503, 429 e 200)", len(lista))
+	}
+}
+
+func TestWebhookNaoRepeteErroDoCliente(t *testing.T) {
+	t.Setenv("ETL_RETRY_BASE", "1ms")
+
+	srv, recebidas := servidorWebhook(t, http.StatusBadRequest)
+	n, err := NewNotificador(Config{Eventos: []Evento{EventoFinalizada}, Webhook: Webhook{URL: srv.URL, Timeout: time.Second}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	registro, relatorio := execucaoParcial()
+	if err := n.Notificar(context.Background(), registro, relatorio); err == nil {
+		t.Fatal("Notificar() deve retornar o erro do webhook")
+	}
+	if lista := recebidas(); len(lista) != 1 {
+		t.Errorf("webhook recebeu %d requisições, esperado 1", len(lista))
+	}
+}
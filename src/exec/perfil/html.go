@@ -0,0 +1,98 @@
+package perfil
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+)
+
+// templateHTML é o resumo do perfil em uma página HTML autocontida.
+var templateHTML = template.Must(template.New("perfil").Funcs(template.FuncMap{
+	"porcentagem": func(v float64) string { return fmt.Sprintf("%.1f%%", v*100) },
+}).Parse(`<!DOCTYPE html>
+<html lang="pt-BR">
+<head>
+<meta charset="utf-8">
+<title>Perfil do banco inicial</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #222; }
+table { border-collapse: collapse; margin-bottom: 1.5em; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; }
+th { background: #f0f0f0; }
+td.n { text-align: right; }
+.ruim { color: #b00; font-weight: bold; }
+details { margin-bottom: 1em; }
+code { background: #f6f6f6; }
+</style>
+</head>
+<body>
+<h1>Perfil do banco inicial</h1>
+<p>Gerado em {{.GeradoEm.Format "02/01/2006 15:04:05"}} &middot; {{.Total}} documentos lidos.</p>
+
+<h2>Conversão</h2>
+<p>Válidos: {{.Conversao.Validos}} &middot; <span{{if .Conversao.Invalidos}} class="ruim"{{end}}>inválidos: {{.Conversao.Invalidos}}</span></p>
+{{- if .Conversao.Motivos}}
+<table>
+<tr><th>Motivo da falha</th><th>Membros</th></tr>
+{{- range .Conversao.Motivos}}
+<tr><td>{{.Valor}}</td><td class="n">{{.Total}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .Conversao.Avisos}}
+<table>
+<tr><th>Aviso de qualidade</th><th>Ocorrências</th></tr>
+{{- range .Conversao.Avisos}}
+<tr><td><code>{{.Valor}}</code></td><td class="n">{{.Total}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+
+<h2>Campos</h2>
+<table>
+<tr><th>Campo</th><th>Preenchimento</th><th>Distintos</th><th>Tamanho</th><th>Rejeitados</th><th>Formatos</th></tr>
+{{- range .Campos}}
+<tr>
+<td><code>{{.Nome}}</code></td>
+<td class="n">{{porcentagem .TaxaPreenchimento}} ({{.Preenchidos}})</td>
+<td class="n">{{if .DistintosAproximado}}&ge; {{end}}{{.Distintos}}</td>
+<td class="n">{{.TamanhoMin}}–{{.TamanhoMax}}</td>
+<td class="n{{if .Rejeitados}} ruim{{end}}">{{.Rejeitados}}{{if and .Rejeitados .BloqueiaMembro}} (bloqueia){{end}}</td>
+<td>{{range $i, $f := .Formatos}}{{if $i}}<br>{{end}}<code>{{$f.Valor}}</code>: {{$f.Total}}{{end}}</td>
+</tr>
+{{- end}}
+</table>
+
+{{- range .Campos}}
+<details{{if .Rejeitados}} open{{end}}>
+<summary><code>{{.Nome}}</code></summary>
+{{- if .Principais}}
+<table>
+<tr><th>Valor mais frequente</th><th>Ocorrências</th></tr>
+{{- range .Principais}}
+<tr><td><code>{{.Valor}}</code></td><td class="n">{{.Total}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .ExemplosRejeitados}}
+<table>
+<tr><th>Valor rejeitado</th><th>Motivo</th><th>Ocorrências</th></tr>
+{{- range .ExemplosRejeitados}}
+<tr><td><code>{{if .Valor}}{{.Valor}}{{else}}(vazio){{end}}</code></td><td>{{.Motivo}}</td><td class="n">{{.Total}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+</details>
+{{- end}}
+</body>
+</html>
+`))
+
+// EscreverHTML grava em w o resumo do perfil em HTML, com a tabela de campos e, para cada campo,
+// os valores mais frequentes e os valores rejeitados.
+func EscreverHTML(w io.Writer, r Relatorio) error {
+	if err := templateHTML.Execute(w, r); err != nil {
+		return fmt.Errorf("erro ao gerar o resumo HTML do perfil: %w", err)
+	}
+	return nil
+}
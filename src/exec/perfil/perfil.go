@@ -0,0 +1,72 @@
+package perfil
+
+import (
+	"context"
+	"time"
+)
+
+// Perfilador define a interface do serviço que levanta o perfil dos dados da coleção do banco inicial,
+// antes da transformação, para antecipar os problemas que a conversão encontraria.
+type Perfilador interface {
+	// Perfilar percorre a coleção do banco inicial com cursor e retorna o perfil de cada campo
+	// e o resultado da conversão de cada membro para o modelo final.
+	Perfilar(ctx context.Context) (Relatorio, error)
+}
+
+// Config limita o tamanho do perfil.
+type Config struct {
+	Principais   int // Quantos valores mais frequentes listar por campo
+	Exemplos     int // Quantos valores rejeitados (e motivos de falha da conversão) listar por campo
+	MaxDistintos int // Quantos valores distintos contar por campo; acima disso a contagem é um limite inferior
+}
+
+// ConfigPadrao lista os 10 valores mais frequentes e os 10 principais valores rejeitados de cada campo,
+// contando até 100000 valores distintos por campo.
+func ConfigPadrao() Config {
+	return Config{Principais: 10, Exemplos: 10, MaxDistintos: 100000}
+}
+
+// Relatorio é o perfil da coleção do banco inicial.
+type Relatorio struct {
+	GeradoEm  time.Time `json:"geradoEm"`
+	Total     int       `json:"total"`     // Documentos lidos
+	Conversao Conversao `json:"conversao"` // Resultado da conversão completa de cada membro
+	Campos    []Campo   `json:"campos"`    // Perfil de cada campo, na ordem do modelo inicial
+}
+
+// Conversao resume a conversão dos membros com as mesmas regras do run.
+type Conversao struct {
+	Validos   int        `json:"validos"`           // Membros que seriam convertidos
+	Invalidos int        `json:"invalidos"`         // Membros que falhariam na conversão
+	Motivos   []Contagem `json:"motivos,omitempty"` // Motivos de falha mais frequentes
+	Avisos    []Contagem `json:"avisos,omitempty"`  // Quantidade de avisos de qualidade por código
+}
+
+// Campo é o perfil de um campo do banco inicial.
+type Campo struct {
+	Nome                string     `json:"nome"`                          // Nome BSON (ex: data_nascimento, endereco.cep)
+	Preenchidos         int        `json:"preenchidos"`                   // Documentos com valor não vazio
+	TaxaPreenchimento   float64    `json:"taxaPreenchimento"`             // Preenchidos / total, entre 0 e 1
+	Distintos           int        `json:"distintos"`                     // Valores distintos entre os preenchidos
+	DistintosAproximado bool       `json:"distintosAproximado,omitempty"` // A contagem atingiu Config.MaxDistintos
+	TamanhoMin          int        `json:"tamanhoMin"`                    // Menor quantidade de caracteres entre os preenchidos
+	TamanhoMax          int        `json:"tamanhoMax"`                    // Maior quantidade de caracteres entre os preenchidos
+	Principais          []Contagem `json:"principais,omitempty"`          // Valores mais frequentes
+	Formatos            []Contagem `json:"formatos,omitempty"`            // Formatos detectados (ex: DD/MM/AAAA ou a máscara (99) 99999-9999)
+	Rejeitados          int        `json:"rejeitados"`                    // Valores que a transformação do campo rejeitaria
+	BloqueiaMembro      bool       `json:"bloqueiaMembro"`                // Um valor rejeitado faz o membro inteiro falhar (senão o valor é descartado)
+	ExemplosRejeitados  []Rejeicao `json:"exemplosRejeitados,omitempty"`  // Valores rejeitados mais frequentes
+}
+
+// Contagem é um valor e a quantidade de ocorrências.
+type Contagem struct {
+	Valor string `json:"valor"`
+	Total int    `json:"total"`
+}
+
+// Rejeicao é um valor rejeitado pela transformação do campo, com o motivo.
+type Rejeicao struct {
+	Valor  string `json:"valor"`
+	Motivo string `json:"motivo"`
+	Total  int    `json:"total"`
+}
@@ -0,0 +1,337 @@
+package perfil
+
+import (
+	"context"
+	bancoinicial "etl-service/src/config/model/banco_inicial"
+	"etl-service/src/exec/domain"
+	inicialrepository "etl-service/src/exec/repository/inicial_repository"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// tamanhoMaxMascara limita o tamanho das máscaras de formato, para que textos longos não virem um formato cada.
+const tamanhoMaxMascara = 24
+
+// analisador descreve como ler, classificar e validar um campo do banco inicial.
+type analisador struct {
+	nome       string
+	bloqueia   bool                                   // Um valor rejeitado faz o membro falhar na conversão
+	valor      func(m bancoinicial.Membro) string     // Valor do campo no documento
+	formato    func(v string) string                  // Formato detectado do valor preenchido (nil não detecta)
+	rejeitar   func(v string, e *domain.Enums) string // Motivo pelo qual a transformação rejeitaria o valor preenchido ("" aceita)
+	vazioFalha string                                 // Motivo da falha quando o campo é obrigatório e está vazio
+	vazioExato bool                                   // A transformação só considera vazio o valor "" (espaços contam como preenchido)
+}
+
+// analisadores lista os campos do modelo inicial, na ordem do modelo, com as regras de cada transformação.
+var analisadores = []analisador{
+	{nome: "name", bloqueia: true, valor: func(m bancoinicial.Membro) string { return m.Name },
+		vazioFalha: domain.ErrNomeVazio.Error(), vazioExato: true},
+	{nome: "data_nascimento", bloqueia: true, valor: func(m bancoinicial.Membro) string { return m.DataNascimento },
+		formato: formatoData, rejeitar: rejeitarDataObrigatoria, vazioFalha: "data de nascimento não informada"},
+	{nome: "ano_batismo", bloqueia: true, valor: func(m bancoinicial.Membro) string { return m.AnoBatismo },
+		formato: mascara, rejeitar: rejeitarAno, vazioExato: true},
+	{nome: "sexo", valor: func(m bancoinicial.Membro) string { return m.Sexo }, rejeitar: rejeitarEnum(domain.EnumSexo)},
+	{nome: "estado_civil", valor: func(m bancoinicial.Membro) string { return m.EstadoCivil }, rejeitar: rejeitarEnum(domain.EnumEstadoCivil)},
+	{nome: "data_casamento", bloqueia: true, valor: func(m bancoinicial.Membro) string { return m.DataCasamento },
+		formato: formatoData, rejeitar: rejeitarData},
+	{nome: "nome_conjuge", valor: func(m bancoinicial.Membro) string { return texto(m.NomeConjuge) }},
+	{nome: "filho", valor: func(m bancoinicial.Membro) string { return m.Filho }, rejeitar: rejeitarFilho},
+	{nome: "email", valor: func(m bancoinicial.Membro) string { return m.Email }, formato: formatoEmail, rejeitar: rejeitarEmail},
+	{nome: "telefone", valor: func(m bancoinicial.Membro) string { return m.Telefone }, formato: mascara, rejeitar: rejeitarTelefone},
+	{nome: "status", valor: func(m bancoinicial.Membro) string { return m.Status }, rejeitar: rejeitarEnum(domain.EnumStatus)},
+	{nome: "data_status", bloqueia: true, valor: func(m bancoinicial.Membro) string { return m.DataStatus },
+		formato: formatoData, rejeitar: rejeitarData},
+	{nome: "validado", valor: func(m bancoinicial.Membro) string { return strconv.FormatBool(m.Validado) }},
+	{nome: "endereco.cep", valor: func(m bancoinicial.Membro) string { return m.Endereco.Cep }, formato: mascara, rejeitar: rejeitarCep},
+	{nome: "endereco.rua", valor: func(m bancoinicial.Membro) string { return m.Endereco.Rua }, formato: formatoLogradouro},
+	{nome: "endereco.numero", valor: func(m bancoinicial.Membro) string { return m.Endereco.Numero }, formato: mascara},
+	{nome: "endereco.bairro", valor: func(m bancoinicial.Membro) string { return m.Endereco.Bairro }},
+	{nome: "endereco.complemento", valor: func(m bancoinicial.Membro) string { return texto(m.Endereco.Complemento) }},
+}
+
+// perfilador é a implementação concreta da interface Perfilador.
+type perfilador struct {
+	inicial inicialrepository.InicialRepository
+	enums   *domain.Enums
+	cfg     Config
+}
+
+// NewPerfilador cria o serviço de perfil sobre o repositório do banco inicial. Os campos enumerados
+// são conferidos com enums (nil usa a tabela padrão).
+func NewPerfilador(inicial inicialrepository.InicialRepository, enums *domain.Enums, cfg Config) Perfilador {
+	if enums == nil {
+		enums = domain.EnumsPadrao()
+	}
+	return &perfilador{inicial: inicial, enums: enums, cfg: cfg}
+}
+
+// acumulador guarda as contagens de um campo durante a leitura.
+type acumulador struct {
+	preenchidos int
+	tamanhoMin  int
+	tamanhoMax  int
+	valores     map[string]int
+	aproximado  bool
+	formatos    map[string]int
+	rejeitados  int
+	rejeicoes   map[[2]string]int // (valor, motivo) -> total
+}
+
+// Perfilar lê todos os membros do banco inicial, acumulando o perfil de cada campo e convertendo cada membro.
+func (p *perfilador) Perfilar(ctx context.Context) (Relatorio, error) {
+	acumuladores := make([]*acumulador, len(analisadores))
+	for i := range acumuladores {
+		acumuladores[i] = &acumulador{valores: map[string]int{}, formatos: map[string]int{}, rejeicoes: map[[2]string]int{}}
+	}
+	relatorio := Relatorio{GeradoEm: time.Now()}
+	motivos := make(map[string]int)
+	avisos := make(map[string]int)
+
+	err := p.inicial.StreamMembros(ctx, time.Time{}, func(m bancoinicial.Membro) error {
+		relatorio.Total++
+		for i, a := range analisadores {
+			p.acumular(acumuladores[i], a, a.valor(m))
+		}
+
+		membro, err := domain.NewBancoFinalMembroDomain(m, p.enums)
+		if err != nil {
+			relatorio.Conversao.Invalidos++
+			motivos[err.Error()]++
+			return nil
+		}
+		relatorio.Conversao.Validos++
+		for _, aviso := range membro.Avisos() {
+			avisos[aviso.Codigo]++
+		}
+		return nil
+	})
+	if err != nil {
+		return relatorio, err
+	}
+
+	relatorio.Conversao.Motivos = principais(motivos, p.cfg.Exemplos)
+	relatorio.Conversao.Avisos = principais(avisos, len(avisos))
+	for i, a := range analisadores {
+		relatorio.Campos = append(relatorio.Campos, p.resumir(a, acumuladores[i], relatorio.Total))
+	}
+	return relatorio, nil
+}
+
+// acumular registra o valor de um documento no acumulador do campo.
+func (p *perfilador) acumular(acc *acumulador, a analisador, valor string) {
+	v := strings.TrimSpace(valor)
+	if v == "" && (valor == "" || !a.vazioExato) {
+		if a.vazioFalha != "" {
+			acc.rejeitados++
+			acc.rejeicoes[[2]string{"", a.vazioFalha}]++
+		}
+		return
+	}
+
+	acc.preenchidos++
+	tamanho := utf8.RuneCountInString(v)
+	if acc.preenchidos == 1 || tamanho < acc.tamanhoMin {
+		acc.tamanhoMin = tamanho
+	}
+	acc.tamanhoMax = max(acc.tamanhoMax, tamanho)
+
+	if _, ok := acc.valores[v]; ok || len(acc.valores) < p.cfg.MaxDistintos {
+		acc.valores[v]++
+	} else {
+		acc.aproximado = true
+	}
+	if a.formato != nil {
+		acc.formatos[a.formato(v)]++
+	}
+	if a.rejeitar != nil {
+		// A transformação recebe o valor original, sem os espaços removidos
+		if motivo := a.rejeitar(valor, p.enums); motivo != "" {
+			acc.rejeitados++
+			acc.rejeicoes[[2]string{valor, motivo}]++
+		}
+	}
+}
+
+// resumir converte o acumulador no perfil do campo.
+func (p *perfilador) resumir(a analisador, acc *acumulador, total int) Campo {
+	c := Campo{
+		Nome:                a.nome,
+		Preenchidos:         acc.preenchidos,
+		Distintos:           len(acc.valores),
+		DistintosAproximado: acc.aproximado,
+		TamanhoMin:          acc.tamanhoMin,
+		TamanhoMax:          acc.tamanhoMax,
+		Principais:          principais(acc.valores, p.cfg.Principais),
+		Formatos:            principais(acc.formatos, p.cfg.Principais),
+		Rejeitados:          acc.rejeitados,
+		BloqueiaMembro:      a.bloqueia,
+	}
+	if total > 0 {
+		c.TaxaPreenchimento = float64(acc.preenchidos) / float64(total)
+	}
+	for chave, n := range acc.rejeicoes {
+		c.ExemplosRejeitados = append(c.ExemplosRejeitados, Rejeicao{Valor: chave[0], Motivo: chave[1], Total: n})
+	}
+	sort.Slice(c.ExemplosRejeitados, func(i, j int) bool {
+		x, y := c.ExemplosRejeitados[i], c.ExemplosRejeitados[j]
+		if x.Total != y.Total {
+			return x.Total > y.Total
+		}
+		return x.Valor < y.Valor
+	})
+	if len(c.ExemplosRejeitados) > p.cfg.Exemplos {
+		c.ExemplosRejeitados = c.ExemplosRejeitados[:p.cfg.Exemplos]
+	}
+	return c
+}
+
+// principais retorna os n valores mais frequentes, do mais para o menos frequente.
+func principais(contagem map[string]int, n int) []Contagem {
+	lista := make([]Contagem, 0, len(contagem))
+	for valor, total := range contagem {
+		lista = append(lista, Contagem{Valor: valor, Total: total})
+	}
+	sort.Slice(lista, func(i, j int) bool {
+		if lista[i].Total != lista[j].Total {
+			return lista[i].Total > lista[j].Total
+		}
+		return lista[i].Valor < lista[j].Valor
+	})
+	if len(lista) > n {
+		lista = lista[:n]
+	}
+	return lista
+}
+
+// formatoData retorna o formato reconhecido por domain.ParseData, ou "inválido".
+func formatoData(v string) string {
+	d, err := domain.ParseData(v)
+	if err != nil {
+		return "inválido"
+	}
+	if d.Ambigua != "" {
+		return string(d.Formato) + " (ambígua)"
+	}
+	return string(d.Formato)
+}
+
+// formatoEmail classifica o e-mail pelo domínio normalizado, ou como "inválido".
+func formatoEmail(v string) string {
+	e, err := domain.NormalizarEmail(v)
+	if err != nil {
+		return "inválido"
+	}
+	_, dominio, _ := strings.Cut(e.Endereco, "@")
+	return "@" + dominio
+}
+
+// formatoLogradouro classifica a rua pelo tipo de logradouro reconhecido (ex: Rua, Avenida).
+func formatoLogradouro(v string) string {
+	if tipo := domain.TipoLogradouro(v); tipo != "" {
+		return tipo
+	}
+	return "sem tipo"
+}
+
+// mascara descreve o formato do valor trocando dígitos por 9 e letras por A (ex: "(11) 98765-4321" vira "(99) 99999-9999").
+func mascara(v string) string {
+	var b strings.Builder
+	for i, r := range []rune(v) {
+		if i == tamanhoMaxMascara {
+			b.WriteString("…")
+			break
+		}
+		switch {
+		case unicode.IsDigit(r):
+			b.WriteRune('9')
+		case unicode.IsLetter(r):
+			b.WriteRune('A')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// rejeitarData retorna o motivo pelo qual domain.ParseData rejeita a data.
+func rejeitarData(v string, _ *domain.Enums) string {
+	if _, err := domain.ParseData(v); err != nil {
+		return err.Error()
+	}
+	return ""
+}
+
+// rejeitarDataObrigatoria rejeita, além das datas inválidas, os marcadores de data ausente (ex: "N/A").
+func rejeitarDataObrigatoria(v string, _ *domain.Enums) string {
+	d, err := domain.ParseData(v)
+	switch {
+	case err != nil:
+		return err.Error()
+	case d.Vazia():
+		return "data de nascimento não informada"
+	}
+	return ""
+}
+
+// rejeitarAno rejeita anos de batismo que não são inteiros, com a mesma conversão do run.
+func rejeitarAno(v string, _ *domain.Enums) string {
+	if _, err := domain.ParseAnoBatismo(v); err != nil {
+		return err.Error()
+	}
+	return ""
+}
+
+// rejeitarEnum rejeita valores fora da tabela de enums do campo.
+func rejeitarEnum(enum string) func(string, *domain.Enums) string {
+	return func(v string, e *domain.Enums) string {
+		if _, ok := e.Normalizar(enum, v); !ok {
+			return "valor fora da tabela (" + strings.Join(e.Valores(enum), ", ") + ")"
+		}
+		return ""
+	}
+}
+
+// rejeitarFilho rejeita valores que domain.ParseFilho não reconhece.
+func rejeitarFilho(v string, _ *domain.Enums) string {
+	if _, ok := domain.ParseFilho(v); !ok {
+		return "valor não reconhecido (use sim ou não)"
+	}
+	return ""
+}
+
+// rejeitarEmail retorna o motivo pelo qual domain.NormalizarEmail rejeita o e-mail.
+func rejeitarEmail(v string, _ *domain.Enums) string {
+	if _, err := domain.NormalizarEmail(v); err != nil {
+		return err.Error()
+	}
+	return ""
+}
+
+// rejeitarTelefone retorna o motivo pelo qual domain.ParseTelefone rejeita o telefone.
+func rejeitarTelefone(v string, _ *domain.Enums) string {
+	if _, err := domain.ParseTelefone(v); err != nil {
+		return err.Error()
+	}
+	return ""
+}
+
+// rejeitarCep retorna o motivo pelo qual domain.ParseCep rejeita o CEP.
+func rejeitarCep(v string, _ *domain.Enums) string {
+	if _, err := domain.ParseCep(v); err != nil {
+		return err.Error()
+	}
+	return ""
+}
+
+// texto retorna o valor do campo opcional, ou vazio.
+func texto(v *string) string {
+	if v == nil {
+		return ""
+	}
+	return *v
+}
@@ -0,0 +1,46 @@
+package perfil
+
+import (
+	"testing"
+
+	"etl-service/src/exec/domain"
+)
+
+// TestAcumularPreveConversao garante que o perfil rejeita exatamente os valores que a
+// transformação do run rejeita, inclusive os que têm apenas espaços.
+func TestAcumularPreveConversao(t *testing.T) {
+	converter := map[string]func(v string) error{
+		"name":        func(v string) error { _, err := domain.ParseNome(v); return err },
+		"ano_batismo": func(v string) error { _, err := domain.ParseAnoBatismo(v); return err },
+	}
+	valores := []string{"", " ", "  ", "1999", " 1999", "1999 ", "abc", "JOÃO DA SILVA"}
+
+	p := NewPerfilador(nil, nil, ConfigPadrao()).(*perfilador)
+	for _, a := range analisadores {
+		conversao, ok := converter[a.nome]
+		if !ok {
+			continue
+		}
+		for _, v := range valores {
+			t.Run(a.nome+"/"+v, func(t *testing.T) {
+				acc := &acumulador{valores: map[string]int{}, formatos: map[string]int{}, rejeicoes: map[[2]string]int{}}
+				p.acumular(acc, a, v)
+
+				esperado := conversao(v)
+				if obtido := acc.rejeitados > 0; obtido != (esperado != nil) {
+					t.Fatalf("rejeitado = %v, conversão retornou %v", obtido, esperado)
+				}
+				if esperado != nil {
+					for chave := range acc.rejeicoes {
+						if chave[1] != esperado.Error() {
+							t.Errorf("motivo = %q, esperado %q", chave[1], esperado.Error())
+						}
+					}
+				}
+				if preenchido := acc.preenchidos > 0; preenchido != (v != "") {
+					t.Errorf("preenchido = %v para %q", preenchido, v)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package provisionamento
+
+import "go.mongodb.org/mongo-driver/bson"
+
+// ProvisionamentoFinal define a interface do serviço responsável por manter o schema
+// e os índices da coleção de membros do banco final.
+//
+// O validador é gerado a partir das tags BSON de bancofinal.Membro, de forma que
+// o modelo Go seja a única fonte da verdade para a estrutura da coleção.
+type ProvisionamentoFinal interface {
+	// Validador retorna o validador $jsonSchema esperado para a coleção.
+	Validador() bson.M
+
+	// Aplicar cria a coleção com o validador (ou atualiza via collMod, caso já exista)
+	// e garante a existência dos índices esperados.
+	Aplicar() (Resultado, error)
+
+	// Drift compara o validador e os índices existentes na coleção com os esperados,
+	// retornando uma linha por divergência encontrada. Lista vazia indica que não há drift.
+	Drift() ([]string, error)
+}
+
+// Resultado descreve as alterações realizadas por Aplicar.
+type Resultado struct {
+	ColecaoCriada bool     // Indica se a coleção foi criada (false quando o validador foi atualizado via collMod)
+	Indices       []string // Nomes dos índices garantidos na coleção
+}
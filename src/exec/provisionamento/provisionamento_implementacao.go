@@ -0,0 +1,233 @@
+package provisionamento
+
+import (
+	"etl-service/src/config/database"
+	"etl-service/src/config/logger"
+	bancofinal "etl-service/src/config/model/banco_final"
+	"etl-service/src/config/schema"
+	"etl-service/src/exec/domain"
+	"fmt"
+	"os"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	validationLevel  = "strict" // Valida todas as inserções e atualizações
+	validationAction = "error"  // Rejeita documentos inválidos
+)
+
+// indice descreve um índice esperado na coleção do banco final.
+type indice struct {
+	nome   string
+	chaves bson.D
+	unico  bool
+}
+
+// indicesEsperados lista os índices que a coleção de membros deve possuir.
+// O campo name é a chave de identidade usada na deduplicação, por isso possui índice único.
+var indicesEsperados = []indice{
+	{nome: "ux_name", chaves: bson.D{{Key: "name", Value: 1}}, unico: true},
+	{nome: "ix_dataAniversario", chaves: bson.D{{Key: "dataAniversario", Value: 1}}},
+	{nome: "ix_status", chaves: bson.D{{Key: "status", Value: 1}}},
+	{nome: "ix_endereco_bairro", chaves: bson.D{{Key: "endereco.bairro", Value: 1}}},
+}
+
+// provisionamentoFinal é a implementação concreta da interface ProvisionamentoFinal.
+type provisionamentoFinal struct {
+	conn      database.MongoConnection // Interface que gerencia a conexão com o MongoDB.
+	validador bson.M                   // Validador $jsonSchema gerado a partir do modelo final
+}
+
+// NewProvisionamentoFinal cria o serviço de provisionamento, gerando o validador
+// a partir das tags BSON de bancofinal.Membro e da tabela de enums.
+func NewProvisionamentoFinal(conn database.MongoConnection, enums *domain.Enums) ProvisionamentoFinal {
+	return &provisionamentoFinal{
+		conn:      conn,
+		validador: ValidadorEsperado(enums),
+	}
+}
+
+// ValidadorEsperado gera o validador $jsonSchema da coleção do banco final a partir das tags BSON
+// de bancofinal.Membro, restringindo os campos enumerados aos valores canônicos de enums (nil usa a tabela padrão).
+// Não depende de conexão com o banco.
+func ValidadorEsperado(enums *domain.Enums) bson.M {
+	if enums == nil {
+		enums = domain.EnumsPadrao()
+	}
+	return schema.Gerar(bancofinal.Membro{}, enums.Restricoes())
+}
+
+// Validador retorna o validador $jsonSchema esperado para a coleção.
+func (p *provisionamentoFinal) Validador() bson.M {
+	return p.validador
+}
+
+// nomes lê as variáveis de ambiente MONGO_DB_BANCO_FINAL e MONGO_COLLECTION_BANCO_FINAL.
+func (p *provisionamentoFinal) nomes() (string, string) {
+	MONGO_DB_BANCO_FINAL := os.Getenv("MONGO_DB_BANCO_FINAL")
+	if MONGO_DB_BANCO_FINAL == "" {
+		logger.Fatal("variável de ambiente não configurada", "variavel", "MONGO_DB_BANCO_FINAL")
+	}
+
+	MONGO_COLLECTION_BANCO_FINAL := os.Getenv("MONGO_COLLECTION_BANCO_FINAL")
+	if MONGO_COLLECTION_BANCO_FINAL == "" {
+		logger.Fatal("variável de ambiente não configurada", "variavel", "MONGO_COLLECTION_BANCO_FINAL")
+	}
+
+	return MONGO_DB_BANCO_FINAL, MONGO_COLLECTION_BANCO_FINAL
+}
+
+// Aplicar cria a coleção com o validador esperado ou, se ela já existir, executa collMod
+// para substituir o validador atual. Em seguida garante os índices esperados.
+func (p *provisionamentoFinal) Aplicar() (Resultado, error) {
+	ctx, cancel := p.conn.ContextWithTimeout()
+	defer cancel()
+
+	dbName, collName := p.nomes()
+	db := p.conn.Database(dbName)
+
+	existentes, err := db.ListCollectionNames(ctx, bson.D{{Key: "name", Value: collName}})
+	if err != nil {
+		return Resultado{}, fmt.Errorf("erro ao listar coleções: %w", err)
+	}
+
+	var resultado Resultado
+	if len(existentes) == 0 {
+		opts := options.CreateCollection().
+			SetValidator(p.validador).
+			SetValidationLevel(validationLevel).
+			SetValidationAction(validationAction)
+		if err := db.CreateCollection(ctx, collName, opts); err != nil {
+			return Resultado{}, fmt.Errorf("erro ao criar coleção '%s': %w", collName, err)
+		}
+		resultado.ColecaoCriada = true
+	} else {
+		cmd := bson.D{
+			{Key: "collMod", Value: collName},
+			{Key: "validator", Value: p.validador},
+			{Key: "validationLevel", Value: validationLevel},
+			{Key: "validationAction", Value: validationAction},
+		}
+		if err := db.RunCommand(ctx, cmd).Err(); err != nil {
+			return Resultado{}, fmt.Errorf("erro ao atualizar validador (collMod) de '%s': %w", collName, err)
+		}
+	}
+
+	models := make([]mongo.IndexModel, 0, len(indicesEsperados))
+	for _, idx := range indicesEsperados {
+		opts := options.Index().SetName(idx.nome)
+		if idx.unico {
+			opts.SetUnique(true)
+		}
+		models = append(models, mongo.IndexModel{Keys: idx.chaves, Options: opts})
+	}
+
+	nomes, err := db.Collection(collName).Indexes().CreateMany(ctx, models)
+	if err != nil {
+		return resultado, fmt.Errorf("erro ao criar índices: %w", err)
+	}
+	resultado.Indices = nomes
+
+	return resultado, nil
+}
+
+// Drift compara o validador, o nível/ação de validação e os índices existentes
+// com os esperados, retornando uma linha por divergência.
+func (p *provisionamentoFinal) Drift() ([]string, error) {
+	ctx, cancel := p.conn.ContextWithTimeout()
+	defer cancel()
+
+	dbName, collName := p.nomes()
+	db := p.conn.Database(dbName)
+
+	specs, err := db.ListCollectionSpecifications(ctx, bson.D{{Key: "name", Value: collName}})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar coleções: %w", err)
+	}
+	if len(specs) == 0 {
+		return []string{fmt.Sprintf("coleção '%s.%s' não existe", dbName, collName)}, nil
+	}
+
+	var opcoes struct {
+		Validator        bson.Raw `bson:"validator"`
+		ValidationLevel  string   `bson:"validationLevel"`
+		ValidationAction string   `bson:"validationAction"`
+	}
+	if specs[0].Options != nil {
+		if err := bson.Unmarshal(specs[0].Options, &opcoes); err != nil {
+			return nil, fmt.Errorf("erro ao decodificar opções da coleção: %w", err)
+		}
+	}
+
+	var drift []string
+	if opcoes.Validator == nil {
+		drift = append(drift, "validador: coleção sem validador $jsonSchema")
+	} else {
+		diffs, err := schema.Diferencas(p.validador, opcoes.Validator)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range diffs {
+			drift = append(drift, "validador: "+d)
+		}
+	}
+
+	// O servidor omite validationLevel/validationAction quando estão no padrão (strict/error)
+	if opcoes.ValidationLevel != "" && opcoes.ValidationLevel != validationLevel {
+		drift = append(drift, fmt.Sprintf("validationLevel: esperado %q, atual %q", validationLevel, opcoes.ValidationLevel))
+	}
+	if opcoes.ValidationAction != "" && opcoes.ValidationAction != validationAction {
+		drift = append(drift, fmt.Sprintf("validationAction: esperado %q, atual %q", validationAction, opcoes.ValidationAction))
+	}
+
+	indices, err := db.Collection(collName).Indexes().ListSpecifications(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar índices: %w", err)
+	}
+	atuais := make(map[string]*mongo.IndexSpecification, len(indices))
+	for _, spec := range indices {
+		atuais[spec.Name] = spec
+	}
+	for _, idx := range indicesEsperados {
+		atual, ok := atuais[idx.nome]
+		if !ok {
+			drift = append(drift, fmt.Sprintf("índice %s: ausente", idx.nome))
+			continue
+		}
+		esperado, _ := bson.Marshal(idx.chaves)
+		if !reflect.DeepEqual(normalizarChaves(esperado), normalizarChaves(atual.KeysDocument)) {
+			drift = append(drift, fmt.Sprintf("índice %s: chaves divergentes (atual %s)", idx.nome, atual.KeysDocument))
+		}
+		unico := atual.Unique != nil && *atual.Unique
+		if unico != idx.unico {
+			drift = append(drift, fmt.Sprintf("índice %s: unique esperado %t, atual %t", idx.nome, idx.unico, unico))
+		}
+	}
+
+	return drift, nil
+}
+
+// normalizarChaves converte o documento de chaves de um índice para uma lista
+// de pares campo/direção, ignorando o tipo numérico usado pelo servidor (int32, int64, double).
+func normalizarChaves(doc bson.Raw) []string {
+	elems, err := doc.Elements()
+	if err != nil {
+		return nil
+	}
+	chaves := make([]string, 0, len(elems))
+	for _, e := range elems {
+		v := e.Value()
+		direcao := v.String()
+		if n, ok := v.AsInt64OK(); ok {
+			direcao = fmt.Sprint(n)
+		} else if f, ok := v.DoubleOK(); ok {
+			direcao = fmt.Sprint(int64(f))
+		}
+		chaves = append(chaves, e.Key()+":"+direcao)
+	}
+	return chaves
+}
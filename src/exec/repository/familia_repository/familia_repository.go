@@ -0,0 +1,16 @@
+package familiarepository
+
+import (
+	"context"
+	bancofinal "etl-service/src/config/model/banco_final"
+	"time"
+)
+
+// FamiliaRepository define a interface para o repositório que gerencia a coleção de famílias
+// (domicílios) do banco final.
+type FamiliaRepository interface {
+	// Substituir grava as famílias calculadas na execução, identificadas pelo _id, com dataAtualizacao
+	// igual a instante, e remove as que não foram recalculadas (dataAtualizacao anterior a instante).
+	// Retorna quantas famílias antigas foram removidas.
+	Substituir(ctx context.Context, familias []bancofinal.Familia, instante time.Time) (int64, error)
+}
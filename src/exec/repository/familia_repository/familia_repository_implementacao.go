@@ -0,0 +1,73 @@
+package familiarepository
+
+import (
+	"context"
+	"etl-service/src/config/database"
+	"etl-service/src/config/env"
+	"etl-service/src/config/logger"
+	bancofinal "etl-service/src/config/model/banco_final"
+	"fmt"
+	"os"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// tamanhoLoteFamilias limita a quantidade de operações de cada BulkWrite de Substituir.
+const tamanhoLoteFamilias = 1000
+
+// dataFamiliaRepository é a implementação concreta da interface FamiliaRepository.
+// A coleção fica no banco final (MONGO_DB_BANCO_FINAL).
+type dataFamiliaRepository struct {
+	conn database.MongoConnection // Interface que gerencia a conexão com o MongoDB.
+}
+
+// NewDataFamiliaRepository cria e retorna uma nova instância de dataFamiliaRepository.
+func NewDataFamiliaRepository(conn database.MongoConnection) FamiliaRepository {
+	return &dataFamiliaRepository{
+		conn: conn,
+	}
+}
+
+// collection lê as variáveis de ambiente MONGO_DB_BANCO_FINAL e MONGO_COLLECTION_FAMILIAS (padrão familias)
+// e retorna a coleção de famílias.
+func (d *dataFamiliaRepository) collection() *mongo.Collection {
+	MONGO_DB_BANCO_FINAL := os.Getenv("MONGO_DB_BANCO_FINAL")
+	if MONGO_DB_BANCO_FINAL == "" {
+		logger.Fatal("variável de ambiente não configurada", "variavel", "MONGO_DB_BANCO_FINAL")
+	}
+	return d.conn.Collection(MONGO_DB_BANCO_FINAL, env.GetString("MONGO_COLLECTION_FAMILIAS", "familias"))
+}
+
+// Substituir regrava as famílias com ReplaceOne (upsert) pelo _id, em BulkWrite não ordenado e em lotes,
+// e depois remove com DeleteMany as famílias que não foram regravadas.
+func (d *dataFamiliaRepository) Substituir(ctx context.Context, familias []bancofinal.Familia, instante time.Time) (int64, error) {
+	// O MongoDB guarda datas com precisão de milissegundos
+	instante = instante.Truncate(time.Millisecond)
+
+	for inicio := 0; inicio < len(familias); inicio += tamanhoLoteFamilias {
+		lote := familias[inicio:min(inicio+tamanhoLoteFamilias, len(familias))]
+		modelos := make([]mongo.WriteModel, 0, len(lote))
+		for _, f := range lote {
+			f.DataAtualizacao = instante
+			modelos = append(modelos, mongo.NewReplaceOneModel().SetFilter(bson.M{"_id": f.ID}).SetReplacement(f).SetUpsert(true))
+		}
+
+		ctxLote, cancel := d.conn.ContextWithTimeoutFrom(ctx)
+		_, err := d.collection().BulkWrite(ctxLote, modelos, options.BulkWrite().SetOrdered(false))
+		cancel()
+		if err != nil {
+			return 0, fmt.Errorf("erro ao gravar famílias: %w", err)
+		}
+	}
+
+	ctx, cancel := d.conn.ContextWithTimeoutFrom(ctx)
+	defer cancel()
+	resultado, err := d.collection().DeleteMany(ctx, bson.M{"dataAtualizacao": bson.M{"$lt": instante}})
+	if err != nil {
+		return 0, fmt.Errorf("erro ao remover famílias antigas: %w", err)
+	}
+	return resultado.DeletedCount, nil
+}
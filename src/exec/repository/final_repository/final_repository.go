@@ -1,7 +1,54 @@
 package finalrepository
 
-import bancofinal "etl-service/src/config/model/banco_final"
+import (
+	"context"
+	bancofinal "etl-service/src/config/model/banco_final"
 
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// FinalRepository define a interface para o repositório que gerencia o acesso
+// à coleção de membros do banco final.
 type FinalRepository interface {
-	Insert(membro bancofinal.Membro) error
+	// Insert insere um novo membro na coleção do banco final.
+	Insert(ctx context.Context, membro bancofinal.Membro) error
+
+	// Replace substitui o documento do membro com o mesmo nome, mantendo o _id e as referências
+	// calculadas após a carga (conjugeId e familiaId). Se o membro não existir mais, ele é inserido.
+	Replace(ctx context.Context, membro bancofinal.Membro) error
+
+	// HashesByNames verifica quais nomes dentre os passados existem atualmente no banco final.
+	//
+	// Retorna:
+	// - Um mapa nome->hash com os nomes que existem no banco; o hash é vazio para documentos
+	//   gravados antes da detecção de alterações.
+	// - Um erro caso ocorra falha durante a consulta.
+	HashesByNames(ctx context.Context, names []string) (map[string]string, error)
+
+	// ListarCampos retorna todos os membros da coleção com apenas o _id e os campos informados
+	// (nomes BSON, ex: "nomeConjuge", "endereco"); os demais ficam com o valor zero.
+	ListarCampos(ctx context.Context, campos ...string) ([]bancofinal.Membro, error)
+
+	// DefinirReferencias grava, para cada _id do mapa, o _id referenciado no campo informado
+	// (ex: "conjugeId"); referências nil removem o campo. Retorna quantos documentos foram alterados.
+	DefinirReferencias(ctx context.Context, campo string, referencias map[primitive.ObjectID]*primitive.ObjectID) (int64, error)
+
+	// AtualizarCampos grava, para cada _id do mapa, os campos informados (nomes BSON); valores nil removem
+	// o campo. Retorna quantos documentos foram alterados.
+	AtualizarCampos(ctx context.Context, valores map[primitive.ObjectID]bson.M) (int64, error)
+
+	// MaterializarContagem conta os membros agrupados pela expressão de agregação (ex: "$status") e
+	// substitui o conteúdo da coleção de resumo com o nome informado (ex: "status").
+	MaterializarContagem(ctx context.Context, nome string, expressao any) error
+
+	// GetAll retorna todos os membros presentes na coleção do banco final.
+	GetAll() ([]bancofinal.Membro, error)
+
+	// Count retorna a quantidade total de membros na coleção do banco final.
+	Count() (int64, error)
+
+	// CountBy agrupa os membros pelo campo informado (nome BSON, ex: "status" ou "endereco.bairro")
+	// e retorna a contagem de documentos para cada valor encontrado.
+	CountBy(campo string) (map[string]int64, error)
 }
@@ -1,20 +1,27 @@
 package finalrepository
 
 import (
+	"context"
 	"etl-service/src/config/database"
+	"etl-service/src/config/env"
+	"etl-service/src/config/logger"
 	bancofinal "etl-service/src/config/model/banco_final"
 	"fmt"
-	"log"
 	"os"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-// dataInicialRepository é a implementação concreta da interface InicialRepository.
-// Responsável por executar operações de leitura na base de dados MongoDB para a entidade Membro.
+// dataFinalRepository é a implementação concreta da interface FinalRepository.
+// Responsável por executar operações de leitura e escrita na coleção de membros do banco final.
 type dataFinalRepository struct {
 	conn database.MongoConnection // Interface que gerencia a conexão com o MongoDB.
 }
 
-// NewDataInicialRepository cria e retorna uma nova instância de dataInicialRepository,
+// NewDataFinalRepository cria e retorna uma nova instância de dataFinalRepository,
 // recebendo uma conexão MongoConnection para interação com o banco.
 func NewDataFinalRepository(conn database.MongoConnection) FinalRepository {
 	return &dataFinalRepository{
@@ -22,43 +29,291 @@ func NewDataFinalRepository(conn database.MongoConnection) FinalRepository {
 	}
 }
 
-// Insert insere um novo membro na coleção do banco inicial.
+// collection lê as variáveis de ambiente MONGO_DB_BANCO_FINAL e MONGO_COLLECTION_BANCO_FINAL
+// e retorna a coleção de membros do banco final.
+func (d *dataFinalRepository) collection() *mongo.Collection {
+	MONGO_DB_BANCO_FINAL := os.Getenv("MONGO_DB_BANCO_FINAL")
+	if MONGO_DB_BANCO_FINAL == "" {
+		logger.Fatal("variável de ambiente não configurada", "variavel", "MONGO_DB_BANCO_FINAL")
+	}
+
+	MONGO_COLLECTION_BANCO_FINAL := os.Getenv("MONGO_COLLECTION_BANCO_FINAL")
+	if MONGO_COLLECTION_BANCO_FINAL == "" {
+		logger.Fatal("variável de ambiente não configurada", "variavel", "MONGO_COLLECTION_BANCO_FINAL")
+	}
+
+	return d.conn.Collection(MONGO_DB_BANCO_FINAL, MONGO_COLLECTION_BANCO_FINAL)
+}
+
+// Insert insere um novo membro na coleção do banco final.
 //
 // Parâmetros:
-// - membro: objeto do tipo bancoinicial.Membro contendo os dados a serem inseridos.
+// - membro: objeto do tipo bancofinal.Membro contendo os dados a serem inseridos.
 //
 // Fluxo da função:
-// - Obtém contexto com timeout da conexão para evitar operações longas.
+// - Obtém contexto com timeout derivado de ctx para evitar operações longas.
 // - Lê as variáveis de ambiente MONGO_DB_BANCO_FINAL e MONGO_COLLECTION_BANCO_FINAL para determinar banco e coleção.
 // - Insere o documento na coleção usando InsertOne.
 // - Retorna erro em caso de falha na inserção ou no contexto.
 //
 // Uso:
-// err := repo.Insert(novoMembro)
+// err := repo.Insert(ctx, novoMembro)
 //
 //	if err != nil {
 //	    // Tratar erro
 //	}
-func (d *dataFinalRepository) Insert(membro bancofinal.Membro) error {
+func (d *dataFinalRepository) Insert(ctx context.Context, membro bancofinal.Membro) error {
+	ctx, cancel := d.conn.ContextWithTimeoutFrom(ctx)
+	defer cancel()
+
+	_, err := d.collection().InsertOne(ctx, membro)
+	if err != nil {
+		return fmt.Errorf("erro ao inserir membro: %w", err)
+	}
+
+	return nil
+}
+
+// Replace substitui o documento do membro pelo nome (chave de identidade) com um update em pipeline
+// ($replaceWith), que troca o conteúdo pelo membro transformado mas copia do documento gravado o _id e as
+// referências conjugeId e familiaId. Assim, os vínculos continuam válidos durante a execução e mesmo que ela
+// termine antes de recalculá-los. O membro entra como $literal para que valores iniciados por "$" não sejam
+// interpretados como expressões. Usa upsert para o caso de o documento ter sido removido após a deduplicação.
+func (d *dataFinalRepository) Replace(ctx context.Context, membro bancofinal.Membro) error {
+	ctx, cancel := d.conn.ContextWithTimeoutFrom(ctx)
+	defer cancel()
+
+	atualizacao := mongo.Pipeline{
+		{{Key: "$replaceWith", Value: bson.M{"$mergeObjects": bson.A{
+			bson.M{"$literal": membro},
+			bson.M{"_id": "$_id", "conjugeId": "$conjugeId", "familiaId": "$familiaId"},
+		}}}},
+	}
+	opts := options.Update().SetUpsert(true)
+	if _, err := d.collection().UpdateOne(ctx, bson.M{"name": membro.Name}, atualizacao, opts); err != nil {
+		return fmt.Errorf("erro ao atualizar membro: %w", err)
+	}
+	return nil
+}
+
+// HashesByNames verifica a existência de múltiplos nomes na coleção do banco final.
+//
+// Fluxo da função:
+// - Cria contexto com timeout derivado de ctx.
+// - Executa uma consulta usando filtro {$in: names}, projetando apenas os campos name e hash.
+// - Itera sobre os resultados e preenche um mapa nome->hash com os nomes que existem.
+func (d *dataFinalRepository) HashesByNames(ctx context.Context, names []string) (map[string]string, error) {
+	ctx, cancel := d.conn.ContextWithTimeoutFrom(ctx)
+	defer cancel()
+
+	filter := bson.M{"name": bson.M{"$in": names}}
+	opts := options.Find().SetProjection(bson.M{"name": 1, "hash": 1, "_id": 0})
+
+	cursor, err := d.collection().Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao consultar nomes existentes: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	existing := make(map[string]string)
+	for cursor.Next(ctx) {
+		var m struct {
+			Name string `bson:"name"`
+			Hash string `bson:"hash"`
+		}
+		if err := cursor.Decode(&m); err != nil {
+			return nil, err
+		}
+		existing[m.Name] = m.Hash
+	}
+
+	return existing, cursor.Err()
+}
+
+// ListarCampos busca todos os documentos da coleção projetando apenas o _id e os campos informados.
+func (d *dataFinalRepository) ListarCampos(ctx context.Context, campos ...string) ([]bancofinal.Membro, error) {
+	ctx, cancel := d.conn.ContextWithTimeoutFrom(ctx)
+	defer cancel()
+
+	projecao := bson.M{}
+	for _, campo := range campos {
+		projecao[campo] = 1
+	}
+
+	cursor, err := d.collection().Find(ctx, bson.D{}, options.Find().SetProjection(projecao))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar membros do banco final: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var membros []bancofinal.Membro
+	if err := cursor.All(ctx, &membros); err != nil {
+		return nil, fmt.Errorf("erro ao decodificar os membros do banco final: %w", err)
+	}
+	return membros, nil
+}
+
+// tamanhoLoteAtualizacoes limita a quantidade de operações de cada BulkWrite de DefinirReferencias e AtualizarCampos.
+const tamanhoLoteAtualizacoes = 1000
+
+// DefinirReferencias atualiza o campo de referência com BulkWrite não ordenado, em lotes,
+// usando $set para referências definidas e $unset para as nulas.
+func (d *dataFinalRepository) DefinirReferencias(ctx context.Context, campo string, referencias map[primitive.ObjectID]*primitive.ObjectID) (int64, error) {
+	valores := make(map[primitive.ObjectID]bson.M, len(referencias))
+	for id, ref := range referencias {
+		if ref != nil {
+			valores[id] = bson.M{campo: *ref}
+		} else {
+			valores[id] = bson.M{campo: nil}
+		}
+	}
+	alterados, err := d.AtualizarCampos(ctx, valores)
+	if err != nil {
+		return alterados, fmt.Errorf("erro ao gravar '%s' no banco final: %w", campo, err)
+	}
+	return alterados, nil
+}
+
+// AtualizarCampos atualiza os documentos com BulkWrite não ordenado, em lotes, usando $set
+// para os valores definidos e $unset para os nulos.
+func (d *dataFinalRepository) AtualizarCampos(ctx context.Context, valores map[primitive.ObjectID]bson.M) (int64, error) {
+	modelos := make([]mongo.WriteModel, 0, min(len(valores), tamanhoLoteAtualizacoes))
+	var alterados int64
+
+	gravar := func() error {
+		if len(modelos) == 0 {
+			return nil
+		}
+		ctx, cancel := d.conn.ContextWithTimeoutFrom(ctx)
+		defer cancel()
+		resultado, err := d.collection().BulkWrite(ctx, modelos, options.BulkWrite().SetOrdered(false))
+		if err != nil {
+			return fmt.Errorf("erro ao atualizar membros do banco final: %w", err)
+		}
+		alterados += resultado.ModifiedCount
+		modelos = modelos[:0]
+		return nil
+	}
+
+	for id, campos := range valores {
+		definir, remover := bson.M{}, bson.M{}
+		for campo, valor := range campos {
+			if valor == nil {
+				remover[campo] = ""
+			} else {
+				definir[campo] = valor
+			}
+		}
+		atualizacao := bson.M{}
+		if len(definir) > 0 {
+			atualizacao["$set"] = definir
+		}
+		if len(remover) > 0 {
+			atualizacao["$unset"] = remover
+		}
+		if len(atualizacao) == 0 {
+			continue
+		}
+		modelos = append(modelos, mongo.NewUpdateOneModel().SetFilter(bson.M{"_id": id}).SetUpdate(atualizacao))
+		if len(modelos) == tamanhoLoteAtualizacoes {
+			if err := gravar(); err != nil {
+				return alterados, err
+			}
+		}
+	}
+	return alterados, gravar()
+}
+
+// MaterializarContagem agrupa os membros pela expressão com $group e grava o resultado com $out na coleção
+// MONGO_COLLECTION_RESUMOS_PREFIXO (padrão resumo_) + nome, substituindo o conteúdo anterior.
+// Cada documento gravado tem o valor em _id, o total de membros e o instante do cálculo (atualizadoEm).
+func (d *dataFinalRepository) MaterializarContagem(ctx context.Context, nome string, expressao any) error {
+	ctx, cancel := d.conn.ContextWithTimeoutFrom(ctx)
+	defer cancel()
+
+	colecao := env.GetString("MONGO_COLLECTION_RESUMOS_PREFIXO", "resumo_") + nome
+	pipeline := mongo.Pipeline{
+		{{Key: "$group", Value: bson.M{"_id": expressao, "total": bson.M{"$sum": 1}}}},
+		{{Key: "$set", Value: bson.M{"atualizadoEm": "$$NOW"}}},
+		{{Key: "$out", Value: colecao}},
+	}
+	cursor, err := d.collection().Aggregate(ctx, pipeline)
+	if err != nil {
+		return fmt.Errorf("erro ao materializar o resumo '%s': %w", colecao, err)
+	}
+	return cursor.Close(ctx)
+}
+
+// GetAll busca todos os documentos da coleção de membros do banco final.
+//
+// Tratamento especial para erros de timeout do contexto, retornando mensagens específicas.
+func (d *dataFinalRepository) GetAll() ([]bancofinal.Membro, error) {
 	ctx, cancel := d.conn.ContextWithTimeout()
 	defer cancel()
 
-	MONGO_DB_BANCO_FINAL := os.Getenv("MONGO_DB_BANCO_FINAL")
-	if MONGO_DB_BANCO_FINAL == "" {
-		log.Fatal("❌ Variável de ambiente MONGO_DB_BANCO_FINAL não configurada.")
+	cursor, err := d.collection().Find(ctx, bson.D{})
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("tempo limite excedido para buscar membros do banco final")
+		}
+		return nil, fmt.Errorf("erro ao buscar membros do banco final: %w", err)
 	}
+	defer cursor.Close(ctx)
 
-	MONGO_COLLECTION_BANCO_FINAL := os.Getenv("MONGO_COLLECTION_BANCO_FINAL")
-	if MONGO_COLLECTION_BANCO_FINAL == "" {
-		log.Fatal("❌ Variável de ambiente MONGO_COLLECTION_BANCO_FINAL não configurada.")
+	var membros []bancofinal.Membro
+	if err := cursor.All(ctx, &membros); err != nil {
+		return nil, fmt.Errorf("erro ao decodificar os membros do banco final: %w", err)
 	}
 
-	collection := d.conn.Collection(MONGO_DB_BANCO_FINAL, MONGO_COLLECTION_BANCO_FINAL)
+	return membros, nil
+}
 
-	_, err := collection.InsertOne(ctx, membro)
+// Count retorna a quantidade de documentos da coleção de membros do banco final.
+func (d *dataFinalRepository) Count() (int64, error) {
+	ctx, cancel := d.conn.ContextWithTimeout()
+	defer cancel()
+
+	total, err := d.collection().CountDocuments(ctx, bson.D{})
 	if err != nil {
-		return fmt.Errorf("erro ao inserir membro: %w", err)
+		return 0, fmt.Errorf("erro ao contar membros do banco final: %w", err)
+	}
+	return total, nil
+}
+
+// CountBy executa uma agregação $group pelo campo informado e retorna
+// a contagem de membros para cada valor. Valores ausentes são agrupados como string vazia.
+func (d *dataFinalRepository) CountBy(campo string) (map[string]int64, error) {
+	ctx, cancel := d.conn.ContextWithTimeout()
+	defer cancel()
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$" + campo},
+			{Key: "total", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
 	}
 
-	return nil
+	cursor, err := d.collection().Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao agregar membros por '%s': %w", campo, err)
+	}
+	defer cursor.Close(ctx)
+
+	contagem := make(map[string]int64)
+	for cursor.Next(ctx) {
+		var r struct {
+			ID    interface{} `bson:"_id"`
+			Total int64       `bson:"total"`
+		}
+		if err := cursor.Decode(&r); err != nil {
+			return nil, fmt.Errorf("erro ao decodificar agregação por '%s': %w", campo, err)
+		}
+		chave := ""
+		if r.ID != nil {
+			chave = fmt.Sprint(r.ID)
+		}
+		contagem[chave] += r.Total
+	}
+
+	return contagem, cursor.Err()
 }
@@ -0,0 +1,34 @@
+package historicorepository
+
+import (
+	"context"
+	"etl-service/src/config/model/historico"
+)
+
+// HistoricoRepository define a interface para o repositório que gerencia o histórico
+// de execuções do ETL e as ocorrências (duplicados e falhas) de cada execução.
+type HistoricoRepository interface {
+	// GarantirIndices cria, se ainda não existirem, os índices usados nas consultas do histórico.
+	GarantirIndices(ctx context.Context) error
+
+	// Salvar insere ou substitui o registro da execução, identificado pelo ID.
+	Salvar(ctx context.Context, execucao historico.Execucao) error
+
+	// Buscar retorna a execução com o ID informado, ou nil quando ela não existe.
+	Buscar(ctx context.Context, id string) (*historico.Execucao, error)
+
+	// UltimaConcluida retorna a execução mais recente do job que terminou (com ou sem falhas de membros)
+	// e gravou no banco final, ou nil quando não houver. É a base das execuções incrementais.
+	UltimaConcluida(ctx context.Context, job string) (*historico.Execucao, error)
+
+	// UltimaExecucao retorna a execução mais recente do job, em qualquer status, ou nil quando não houver.
+	// É usada pelo agendador para detectar disparos perdidos.
+	UltimaExecucao(ctx context.Context, job string) (*historico.Execucao, error)
+
+	// SalvarOcorrencias grava as ocorrências de uma execução.
+	SalvarOcorrencias(ctx context.Context, ocorrencias []historico.Ocorrencia) error
+
+	// ListarOcorrencias retorna uma página das ocorrências da execução, opcionalmente filtradas por tipo,
+	// e o total de ocorrências que atendem ao filtro.
+	ListarOcorrencias(ctx context.Context, runID, tipo string, pular, limite int64) ([]historico.Ocorrencia, int64, error)
+}
@@ -0,0 +1,197 @@
+package historicorepository
+
+import (
+	"context"
+	"errors"
+	"etl-service/src/config/database"
+	"etl-service/src/config/env"
+	"etl-service/src/config/logger"
+	"etl-service/src/config/model/historico"
+	"fmt"
+	"os"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// tamanhoLoteOcorrencias limita quantas ocorrências são enviadas em cada InsertMany.
+const tamanhoLoteOcorrencias = 1000
+
+// dataHistoricoRepository é a implementação concreta da interface HistoricoRepository.
+// As coleções ficam no banco final (MONGO_DB_BANCO_FINAL).
+type dataHistoricoRepository struct {
+	conn database.MongoConnection // Interface que gerencia a conexão com o MongoDB.
+}
+
+// NewDataHistoricoRepository cria e retorna uma nova instância de dataHistoricoRepository.
+func NewDataHistoricoRepository(conn database.MongoConnection) HistoricoRepository {
+	return &dataHistoricoRepository{
+		conn: conn,
+	}
+}
+
+// banco lê a variável de ambiente MONGO_DB_BANCO_FINAL.
+func (d *dataHistoricoRepository) banco() string {
+	MONGO_DB_BANCO_FINAL := os.Getenv("MONGO_DB_BANCO_FINAL")
+	if MONGO_DB_BANCO_FINAL == "" {
+		logger.Fatal("variável de ambiente não configurada", "variavel", "MONGO_DB_BANCO_FINAL")
+	}
+	return MONGO_DB_BANCO_FINAL
+}
+
+// execucoes retorna a coleção do histórico de execuções (MONGO_COLLECTION_EXECUCOES, padrão etl_execucoes).
+func (d *dataHistoricoRepository) execucoes() *mongo.Collection {
+	return d.conn.Collection(d.banco(), env.GetString("MONGO_COLLECTION_EXECUCOES", "etl_execucoes"))
+}
+
+// ocorrencias retorna a coleção de ocorrências das execuções (MONGO_COLLECTION_OCORRENCIAS, padrão etl_ocorrencias).
+func (d *dataHistoricoRepository) ocorrencias() *mongo.Collection {
+	return d.conn.Collection(d.banco(), env.GetString("MONGO_COLLECTION_OCORRENCIAS", "etl_ocorrencias"))
+}
+
+// GarantirIndices cria os índices ix_job_inicio (execuções por job, mais recentes primeiro)
+// e ix_runId_tipo_seq (paginação das ocorrências de uma execução).
+func (d *dataHistoricoRepository) GarantirIndices(ctx context.Context) error {
+	ctx, cancel := d.conn.ContextWithTimeoutFrom(ctx)
+	defer cancel()
+
+	_, err := d.execucoes().Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "job", Value: 1}, {Key: "inicio", Value: -1}},
+		Options: options.Index().SetName("ix_job_inicio"),
+	})
+	if err != nil {
+		return fmt.Errorf("erro ao criar índice do histórico de execuções: %w", err)
+	}
+
+	_, err = d.ocorrencias().Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "runId", Value: 1}, {Key: "tipo", Value: 1}, {Key: "seq", Value: 1}},
+		Options: options.Index().SetName("ix_runId_tipo_seq"),
+	})
+	if err != nil {
+		return fmt.Errorf("erro ao criar índice das ocorrências: %w", err)
+	}
+	return nil
+}
+
+// Salvar substitui o documento da execução pelo ID, criando-o quando ainda não existe.
+func (d *dataHistoricoRepository) Salvar(ctx context.Context, execucao historico.Execucao) error {
+	ctx, cancel := d.conn.ContextWithTimeoutFrom(ctx)
+	defer cancel()
+
+	opts := options.Replace().SetUpsert(true)
+	if _, err := d.execucoes().ReplaceOne(ctx, bson.M{"_id": execucao.ID}, execucao, opts); err != nil {
+		return fmt.Errorf("erro ao salvar execução '%s': %w", execucao.ID, err)
+	}
+	return nil
+}
+
+// Buscar retorna a execução pelo ID, ou nil quando ela não existe.
+func (d *dataHistoricoRepository) Buscar(ctx context.Context, id string) (*historico.Execucao, error) {
+	ctx, cancel := d.conn.ContextWithTimeoutFrom(ctx)
+	defer cancel()
+
+	var execucao historico.Execucao
+	err := d.execucoes().FindOne(ctx, bson.M{"_id": id}).Decode(&execucao)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar execução '%s': %w", id, err)
+	}
+	return &execucao, nil
+}
+
+// UltimaConcluida busca a execução mais recente do job com status concluida ou parcial,
+// desconsiderando execuções em dry-run, que não gravam no banco final.
+func (d *dataHistoricoRepository) UltimaConcluida(ctx context.Context, job string) (*historico.Execucao, error) {
+	ctx, cancel := d.conn.ContextWithTimeoutFrom(ctx)
+	defer cancel()
+
+	filtro := bson.M{
+		"job":    job,
+		"dryRun": false,
+		"status": bson.M{"$in": []string{historico.StatusConcluida, historico.StatusParcial}},
+	}
+	opts := options.FindOne().SetSort(bson.D{{Key: "inicio", Value: -1}})
+
+	var execucao historico.Execucao
+	err := d.execucoes().FindOne(ctx, filtro, opts).Decode(&execucao)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar última execução do job '%s': %w", job, err)
+	}
+	return &execucao, nil
+}
+
+// UltimaExecucao busca a execução mais recente do job, independentemente do status e da origem.
+func (d *dataHistoricoRepository) UltimaExecucao(ctx context.Context, job string) (*historico.Execucao, error) {
+	ctx, cancel := d.conn.ContextWithTimeoutFrom(ctx)
+	defer cancel()
+
+	opts := options.FindOne().SetSort(bson.D{{Key: "inicio", Value: -1}})
+
+	var execucao historico.Execucao
+	err := d.execucoes().FindOne(ctx, bson.M{"job": job}, opts).Decode(&execucao)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar última execução do job '%s': %w", job, err)
+	}
+	return &execucao, nil
+}
+
+// SalvarOcorrencias insere as ocorrências em lotes de tamanhoLoteOcorrencias, com escrita não ordenada.
+func (d *dataHistoricoRepository) SalvarOcorrencias(ctx context.Context, ocorrencias []historico.Ocorrencia) error {
+	for inicio := 0; inicio < len(ocorrencias); inicio += tamanhoLoteOcorrencias {
+		fim := min(inicio+tamanhoLoteOcorrencias, len(ocorrencias))
+
+		docs := make([]interface{}, 0, fim-inicio)
+		for _, o := range ocorrencias[inicio:fim] {
+			docs = append(docs, o)
+		}
+
+		ctxLote, cancel := d.conn.ContextWithTimeoutFrom(ctx)
+		_, err := d.ocorrencias().InsertMany(ctxLote, docs, options.InsertMany().SetOrdered(false))
+		cancel()
+		if err != nil {
+			return fmt.Errorf("erro ao salvar ocorrências da execução: %w", err)
+		}
+	}
+	return nil
+}
+
+// ListarOcorrencias consulta as ocorrências da execução ordenadas por tipo e sequência.
+func (d *dataHistoricoRepository) ListarOcorrencias(ctx context.Context, runID, tipo string, pular, limite int64) ([]historico.Ocorrencia, int64, error) {
+	ctx, cancel := d.conn.ContextWithTimeoutFrom(ctx)
+	defer cancel()
+
+	filtro := bson.M{"runId": runID}
+	if tipo != "" {
+		filtro["tipo"] = tipo
+	}
+
+	total, err := d.ocorrencias().CountDocuments(ctx, filtro)
+	if err != nil {
+		return nil, 0, fmt.Errorf("erro ao contar ocorrências da execução '%s': %w", runID, err)
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "tipo", Value: 1}, {Key: "seq", Value: 1}}).
+		SetSkip(pular).
+		SetLimit(limite)
+	cursor, err := d.ocorrencias().Find(ctx, filtro, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("erro ao listar ocorrências da execução '%s': %w", runID, err)
+	}
+	defer cursor.Close(ctx)
+
+	ocorrencias := []historico.Ocorrencia{}
+	if err := cursor.All(ctx, &ocorrencias); err != nil {
+		return nil, 0, fmt.Errorf("erro ao decodificar ocorrências da execução '%s': %w", runID, err)
+	}
+	return ocorrencias, total, nil
+}
@@ -1,8 +1,9 @@
 package inicialrepository
 
 import (
-	bancofinal "etl-service/src/config/model/banco_final"
+	"context"
 	bancoinicial "etl-service/src/config/model/banco_inicial"
+	"time"
 )
 
 // InicialRepository define a interface para o repositório que gerencia o acesso aos dados
@@ -18,15 +19,21 @@ type InicialRepository interface {
 	// - Um erro caso a operação falhe, seja por problemas de conexão, timeout ou falha na consulta.
 	GetAllMembrosRequisicao() ([]bancoinicial.Membro, error)
 
-	// ExistsByNames verifica quais nomes dentre os passados existem atualmente no banco.
+	// Count retorna a quantidade de documentos existentes na coleção do banco inicial.
+	// Também é utilizado pelo preflight para validar a permissão de leitura na origem.
+	Count() (int64, error)
+
+	// StreamMembros percorre a coleção do banco inicial documento a documento, chamando fn para cada membro.
+	// Diferente de GetAllMembrosRequisicao, não mantém todos os membros em memória.
 	//
-	// Parâmetro:
-	// - names: slice de strings contendo os nomes a serem consultados.
+	// Quando desde não é zero e a origem possui um campo de data de modificação (veja CampoModificacao),
+	// apenas os membros modificados a partir desse instante são lidos, o que permite execuções incrementais.
+	// Sem esse campo, todos os membros são lidos, e cabe à comparação de hash da carga ignorar os inalterados.
 	//
-	// Retorna:
-	// - Um mapa string->bool indicando quais nomes existem no banco (true significa que o nome existe).
-	// - Um erro caso ocorra falha durante a consulta.
-	ExistsByNames(names []string) (map[string]bool, error)
+	// A iteração é interrompida quando fn retorna erro ou o contexto é cancelado.
+	StreamMembros(ctx context.Context, desde time.Time, fn func(bancoinicial.Membro) error) error
 
-	Insert(membro bancofinal.Membro) error
+	// CampoModificacao retorna o campo da origem com a data da última modificação de cada membro,
+	// usado para filtrar as execuções incrementais, ou vazio quando a origem não possui esse campo.
+	CampoModificacao() string
 }
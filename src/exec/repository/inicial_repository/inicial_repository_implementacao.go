@@ -3,11 +3,12 @@ package inicialrepository
 import (
 	"context"
 	"etl-service/src/config/database"
-	bancofinal "etl-service/src/config/model/banco_final"
+	"etl-service/src/config/logger"
 	bancoinicial "etl-service/src/config/model/banco_inicial"
 	"fmt"
-	"log"
 	"os"
+	"strings"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 )
@@ -42,12 +43,12 @@ func (d *dataInicialRepository) GetAllMembrosRequisicao() ([]bancoinicial.Membro
 
 	MONGO_DB_NAME := os.Getenv("MONGO_DB_NAME")
 	if MONGO_DB_NAME == "" {
-		log.Fatal("❌ Variável de ambiente MONGO_DB_NAME não configurada.")
+		logger.Fatal("variável de ambiente não configurada", "variavel", "MONGO_DB_NAME")
 	}
 
 	MONGO_COLLECTION_MEMBRO := os.Getenv("MONGO_COLLECTION_MEMBRO")
 	if MONGO_COLLECTION_MEMBRO == "" {
-		log.Fatal("❌ Variável de ambiente MONGO_COLLECTION_MEMBRO não configurada.")
+		logger.Fatal("variável de ambiente não configurada", "variavel", "MONGO_COLLECTION_MEMBRO")
 	}
 
 	collection := d.conn.Collection(MONGO_DB_NAME, MONGO_COLLECTION_MEMBRO)
@@ -72,88 +73,82 @@ func (d *dataInicialRepository) GetAllMembrosRequisicao() ([]bancoinicial.Membro
 	return membros, nil
 }
 
-// ExistsByNames verifica a existência de múltiplos nomes na coleção do banco final.
-//
-// Parâmetros:
-// - names: slice de strings com os nomes a serem verificados.
-//
-// Fluxo da função:
-// - Cria contexto com timeout.
-// - Obtém nomes do banco e da coleção via variáveis de ambiente.
-// - Executa uma consulta usando filtro {$in: names} para encontrar membros com esses nomes.
-// - Itera sobre os resultados e preenche um mapa string->bool indicando quais nomes existem.
-//
-// Retorna:
-// - Um mapa em que a chave é o nome e o valor bool indica se existe no banco.
-// - Erro em caso de falha na consulta ou decodificação.
-func (d *dataInicialRepository) ExistsByNames(names []string) (map[string]bool, error) {
+// Count retorna a quantidade de documentos da coleção membros no banco inicial.
+func (d *dataInicialRepository) Count() (int64, error) {
 	ctx, cancel := d.conn.ContextWithTimeout()
 	defer cancel()
 
-	dbName := os.Getenv("MONGO_DB_BANCO_FINAL")
-	collectionName := os.Getenv("MONGO_COLLECTION_BANCO_FINAL")
-
-	collection := d.conn.Collection(dbName, collectionName)
-
-	filter := bson.M{"name": bson.M{"$in": names}}
+	MONGO_DB_NAME := os.Getenv("MONGO_DB_NAME")
+	if MONGO_DB_NAME == "" {
+		logger.Fatal("variável de ambiente não configurada", "variavel", "MONGO_DB_NAME")
+	}
 
-	cursor, err := collection.Find(ctx, filter)
-	if err != nil {
-		return nil, err
+	MONGO_COLLECTION_MEMBRO := os.Getenv("MONGO_COLLECTION_MEMBRO")
+	if MONGO_COLLECTION_MEMBRO == "" {
+		logger.Fatal("variável de ambiente não configurada", "variavel", "MONGO_COLLECTION_MEMBRO")
 	}
-	defer cursor.Close(ctx)
 
-	existing := make(map[string]bool)
-	for cursor.Next(ctx) {
-		var m struct {
-			Name string `bson:"name"`
-		}
-		if err := cursor.Decode(&m); err != nil {
-			return nil, err
+	total, err := d.conn.Collection(MONGO_DB_NAME, MONGO_COLLECTION_MEMBRO).CountDocuments(ctx, bson.D{})
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return 0, fmt.Errorf("tempo limite excedido para contar membros")
 		}
-		existing[m.Name] = true
+		return 0, fmt.Errorf("erro ao contar membros: %w", err)
 	}
-
-	return existing, nil
+	return total, nil
 }
 
-// Insert insere um novo membro na coleção do banco inicial.
-//
-// Parâmetros:
-// - membro: objeto do tipo bancoinicial.Membro contendo os dados a serem inseridos.
-//
-// Fluxo da função:
-// - Obtém contexto com timeout da conexão para evitar operações longas.
-// - Lê as variáveis de ambiente MONGO_DB_BANCO_FINAL e MONGO_COLLECTION_BANCO_FINAL para determinar banco e coleção.
-// - Insere o documento na coleção usando InsertOne.
-// - Retorna erro em caso de falha na inserção ou no contexto.
+// StreamMembros percorre a coleção membros do banco inicial usando o cursor do driver,
+// decodificando e entregando um membro por vez para fn.
 //
-// Uso:
-// err := repo.Insert(novoMembro)
+// O contexto informado não recebe o timeout padrão de 15 segundos, pois a duração
+// da leitura acompanha o ritmo das etapas seguintes do pipeline.
 //
-//	if err != nil {
-//	    // Tratar erro
-//	}
-func (d *dataInicialRepository) Insert(membro bancofinal.Membro) error {
-	ctx, cancel := d.conn.ContextWithTimeout()
-	defer cancel()
-
-	MONGO_DB_BANCO_FINAL := os.Getenv("MONGO_DB_BANCO_FINAL")
-	if MONGO_DB_BANCO_FINAL == "" {
-		log.Fatal("❌ Variável de ambiente MONGO_DB_BANCO_FINAL não configurada.")
+// Quando desde não é zero e MONGO_CAMPO_MODIFICACAO está configurado, filtra os documentos cujo campo de
+// modificação é maior ou igual a desde. O _id não serve para esse filtro, pois só reflete a criação do documento
+// e deixaria de fora as edições de membros existentes; sem o campo, todos os documentos são lidos.
+func (d *dataInicialRepository) StreamMembros(ctx context.Context, desde time.Time, fn func(bancoinicial.Membro) error) error {
+	MONGO_DB_NAME := os.Getenv("MONGO_DB_NAME")
+	if MONGO_DB_NAME == "" {
+		logger.Fatal("variável de ambiente não configurada", "variavel", "MONGO_DB_NAME")
 	}
 
-	MONGO_COLLECTION_BANCO_FINAL := os.Getenv("MONGO_COLLECTION_BANCO_FINAL")
-	if MONGO_COLLECTION_BANCO_FINAL == "" {
-		log.Fatal("❌ Variável de ambiente MONGO_COLLECTION_BANCO_FINAL não configurada.")
+	MONGO_COLLECTION_MEMBRO := os.Getenv("MONGO_COLLECTION_MEMBRO")
+	if MONGO_COLLECTION_MEMBRO == "" {
+		logger.Fatal("variável de ambiente não configurada", "variavel", "MONGO_COLLECTION_MEMBRO")
 	}
 
-	collection := d.conn.Collection(MONGO_DB_BANCO_FINAL, MONGO_COLLECTION_BANCO_FINAL)
+	collection := d.conn.Collection(MONGO_DB_NAME, MONGO_COLLECTION_MEMBRO)
+
+	filtro := bson.D{}
+	if campo := d.CampoModificacao(); campo != "" && !desde.IsZero() {
+		filtro = bson.D{{Key: campo, Value: bson.M{"$gte": desde}}}
+	}
 
-	_, err := collection.InsertOne(ctx, membro)
+	cursor, err := collection.Find(ctx, filtro)
 	if err != nil {
-		return fmt.Errorf("erro ao inserir membro: %w", err)
+		return fmt.Errorf("erro ao buscar membros: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var m bancoinicial.Membro
+		if err := cursor.Decode(&m); err != nil {
+			return fmt.Errorf("erro ao decodificar membro: %w", err)
+		}
+		if err := fn(m); err != nil {
+			return err
+		}
 	}
 
+	if err := cursor.Err(); err != nil {
+		return fmt.Errorf("erro ao percorrer membros: %w", err)
+	}
 	return nil
 }
+
+// CampoModificacao lê a variável de ambiente MONGO_CAMPO_MODIFICACAO, com o nome do campo de data (BSON Date)
+// atualizado pela origem a cada alteração do membro. Vazia quando a origem não mantém esse campo.
+func (d *dataInicialRepository) CampoModificacao() string {
+	return strings.TrimSpace(os.Getenv("MONGO_CAMPO_MODIFICACAO"))
+}
@@ -0,0 +1,24 @@
+package travarepository
+
+import (
+	"context"
+	"time"
+)
+
+// TravaRepository define a interface para o repositório das leases (travas com prazo de validade)
+// que impedem duas instâncias do serviço de carregar o mesmo destino ao mesmo tempo.
+//
+// Cada recurso possui no máximo um dono. Uma lease expirada pode ser tomada por outro dono,
+// o que permite recuperar a trava após a queda da instância que a detinha.
+type TravaRepository interface {
+	// Adquirir tenta obter a lease do recurso para o dono até expiraEm.
+	// Retorna true quando a lease está livre, expirada ou já pertence ao mesmo dono.
+	Adquirir(ctx context.Context, recurso, dono string, expiraEm time.Time) (bool, error)
+
+	// Renovar estende a validade da lease do dono. Retorna false quando a lease não pertence
+	// mais ao dono (expirou e foi tomada por outra instância).
+	Renovar(ctx context.Context, recurso, dono string, expiraEm time.Time) (bool, error)
+
+	// Liberar remove a lease do recurso, caso ainda pertença ao dono.
+	Liberar(ctx context.Context, recurso, dono string) error
+}
@@ -0,0 +1,98 @@
+package travarepository
+
+import (
+	"context"
+	"etl-service/src/config/database"
+	"etl-service/src/config/env"
+	"etl-service/src/config/logger"
+	"fmt"
+	"os"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// dataTravaRepository é a implementação concreta da interface TravaRepository.
+// Cada lease é um documento {_id: recurso, dono, expiraEm, adquiridaEm, renovadaEm}
+// na coleção MONGO_COLLECTION_TRAVAS (padrão etl_travas) do banco final.
+type dataTravaRepository struct {
+	conn database.MongoConnection // Interface que gerencia a conexão com o MongoDB.
+}
+
+// NewDataTravaRepository cria e retorna uma nova instância de dataTravaRepository.
+func NewDataTravaRepository(conn database.MongoConnection) TravaRepository {
+	return &dataTravaRepository{
+		conn: conn,
+	}
+}
+
+// collection retorna a coleção de travas no banco definido em MONGO_DB_BANCO_FINAL.
+func (d *dataTravaRepository) collection() *mongo.Collection {
+	MONGO_DB_BANCO_FINAL := os.Getenv("MONGO_DB_BANCO_FINAL")
+	if MONGO_DB_BANCO_FINAL == "" {
+		logger.Fatal("variável de ambiente não configurada", "variavel", "MONGO_DB_BANCO_FINAL")
+	}
+	return d.conn.Collection(MONGO_DB_BANCO_FINAL, env.GetString("MONGO_COLLECTION_TRAVAS", "etl_travas"))
+}
+
+// Adquirir executa um upsert filtrando pelo recurso e por (lease expirada ou mesmo dono).
+//
+// Quando a lease pertence a outro dono e ainda é válida, o filtro não encontra o documento
+// e o upsert tenta inserir outro com o mesmo _id, falhando com chave duplicada (E11000):
+// nesse caso a lease está ocupada e o retorno é false, sem erro.
+func (d *dataTravaRepository) Adquirir(ctx context.Context, recurso, dono string, expiraEm time.Time) (bool, error) {
+	ctx, cancel := d.conn.ContextWithTimeoutFrom(ctx)
+	defer cancel()
+
+	agora := time.Now()
+	filtro := bson.M{
+		"_id": recurso,
+		"$or": bson.A{
+			bson.M{"expiraEm": bson.M{"$lte": agora}},
+			bson.M{"dono": dono},
+		},
+	}
+	atualizacao := bson.M{"$set": bson.M{
+		"dono":        dono,
+		"expiraEm":    expiraEm,
+		"adquiridaEm": agora,
+		"renovadaEm":  agora,
+	}}
+
+	_, err := d.collection().UpdateOne(ctx, filtro, atualizacao, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("erro ao adquirir trava '%s': %w", recurso, err)
+	}
+	return true, nil
+}
+
+// Renovar atualiza expiraEm somente se a lease ainda pertence ao dono.
+func (d *dataTravaRepository) Renovar(ctx context.Context, recurso, dono string, expiraEm time.Time) (bool, error) {
+	ctx, cancel := d.conn.ContextWithTimeoutFrom(ctx)
+	defer cancel()
+
+	resultado, err := d.collection().UpdateOne(ctx,
+		bson.M{"_id": recurso, "dono": dono},
+		bson.M{"$set": bson.M{"expiraEm": expiraEm, "renovadaEm": time.Now()}},
+	)
+	if err != nil {
+		return false, fmt.Errorf("erro ao renovar trava '%s': %w", recurso, err)
+	}
+	return resultado.MatchedCount == 1, nil
+}
+
+// Liberar remove o documento da lease se ainda pertencer ao dono.
+func (d *dataTravaRepository) Liberar(ctx context.Context, recurso, dono string) error {
+	ctx, cancel := d.conn.ContextWithTimeoutFrom(ctx)
+	defer cancel()
+
+	if _, err := d.collection().DeleteOne(ctx, bson.M{"_id": recurso, "dono": dono}); err != nil {
+		return fmt.Errorf("erro ao liberar trava '%s': %w", recurso, err)
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package resumos
+
+import "context"
+
+// Atualizador define a interface do serviço que mantém os campos derivados dos membros do banco final
+// e as coleções de resumo usadas pelas telas de relatório.
+//
+// Resumos materializados (um documento por valor, com o total de membros):
+//   - status, sexo e bairro (endereco.bairro);
+//   - faixa_etaria (faixaEtaria);
+//   - decada_batismo (década do anoBatismo, ex: 1990; membros sem batismo ficam em null).
+type Atualizador interface {
+	// Atualizar recalcula Idade, FaixaEtaria e AnosBatismo de todos os membros, gravando apenas os que mudaram,
+	// e materializa as coleções de resumo (nada é gravado quando simular é true).
+	Atualizar(ctx context.Context, simular bool) (Relatorio, error)
+}
+
+// Relatorio resume a atualização dos campos derivados e dos resumos.
+type Relatorio struct {
+	Derivados int      // Membros com campos derivados alterados (no dry-run, os que seriam alterados)
+	Resumos   []string // Resumos materializados
+}
@@ -0,0 +1,104 @@
+package resumos
+
+import (
+	"context"
+	bancofinal "etl-service/src/config/model/banco_final"
+	"etl-service/src/exec/domain"
+	finalrepository "etl-service/src/exec/repository/final_repository"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// camposDerivados são os campos lidos do banco final para recalcular os campos derivados.
+var camposDerivados = []string{"dataNascimento", "anoBatismo", "idade", "faixaEtaria", "anosBatismo"}
+
+// resumo associa o nome de uma coleção de resumo à expressão de agrupamento.
+type resumo struct {
+	nome      string
+	expressao any
+}
+
+// resumosMaterializados lista os resumos gravados a cada execução.
+var resumosMaterializados = []resumo{
+	{nome: "status", expressao: "$status"},
+	{nome: "sexo", expressao: "$sexo"},
+	{nome: "bairro", expressao: "$endereco.bairro"},
+	{nome: "faixa_etaria", expressao: "$faixaEtaria"},
+	{nome: "decada_batismo", expressao: bson.M{"$cond": bson.A{
+		bson.M{"$gt": bson.A{"$anoBatismo", 0}},
+		bson.M{"$subtract": bson.A{"$anoBatismo", bson.M{"$mod": bson.A{"$anoBatismo", 10}}}},
+		nil,
+	}}},
+}
+
+// atualizador é a implementação concreta da interface Atualizador.
+type atualizador struct {
+	final  finalrepository.FinalRepository
+	faixas domain.FaixasEtarias
+}
+
+// NewAtualizador cria o serviço de campos derivados e resumos sobre o repositório do banco final.
+func NewAtualizador(final finalrepository.FinalRepository, faixas domain.FaixasEtarias) Atualizador {
+	return &atualizador{final: final, faixas: faixas}
+}
+
+// Atualizar recalcula os campos derivados na data atual e, fora do dry-run, materializa os resumos.
+func (a *atualizador) Atualizar(ctx context.Context, simular bool) (Relatorio, error) {
+	membros, err := a.final.ListarCampos(ctx, camposDerivados...)
+	if err != nil {
+		return Relatorio{}, err
+	}
+
+	agora := time.Now()
+	alteracoes := make(map[primitive.ObjectID]bson.M)
+	for _, m := range membros {
+		novo := m
+		domain.AplicarDerivados(&novo, agora, a.faixas)
+		if mesmoInteiro(m.Idade, novo.Idade) && m.FaixaEtaria == novo.FaixaEtaria && mesmoInteiro(m.AnosBatismo, novo.AnosBatismo) {
+			continue
+		}
+		alteracoes[m.ID] = camposBSON(novo)
+	}
+	relatorio := Relatorio{Derivados: len(alteracoes)}
+
+	if simular {
+		return relatorio, nil
+	}
+	if len(alteracoes) > 0 {
+		if _, err := a.final.AtualizarCampos(ctx, alteracoes); err != nil {
+			return relatorio, err
+		}
+	}
+	for _, r := range resumosMaterializados {
+		if err := a.final.MaterializarContagem(ctx, r.nome, r.expressao); err != nil {
+			return relatorio, err
+		}
+		relatorio.Resumos = append(relatorio.Resumos, r.nome)
+	}
+	return relatorio, nil
+}
+
+// camposBSON converte os campos derivados do membro nos valores gravados; campos vazios são removidos (nil).
+func camposBSON(m bancofinal.Membro) bson.M {
+	campos := bson.M{"idade": nil, "faixaEtaria": nil, "anosBatismo": nil}
+	if m.Idade != nil {
+		campos["idade"] = *m.Idade
+	}
+	if m.FaixaEtaria != "" {
+		campos["faixaEtaria"] = m.FaixaEtaria
+	}
+	if m.AnosBatismo != nil {
+		campos["anosBatismo"] = *m.AnosBatismo
+	}
+	return campos
+}
+
+// mesmoInteiro compara dois inteiros opcionais.
+func mesmoInteiro(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,74 @@
+package trava
+
+import (
+	"context"
+	"errors"
+	"etl-service/src/config/env"
+	"etl-service/src/config/logger"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Erros retornados ao adquirir ou manter uma lease.
+var (
+	ErrOcupada = errors.New("trava ocupada por outra instância")
+	ErrPerdida = errors.New("lease perdida: a trava expirou ou foi tomada por outra instância")
+)
+
+// Trava define a interface do serviço de exclusão mútua entre instâncias do serviço,
+// baseado em leases com prazo de validade e renovação periódica (heartbeat).
+type Trava interface {
+	// Adquirir obtém a lease do recurso (normalmente o destino da carga). Se ela estiver ocupada,
+	// tenta novamente até o tempo de espera configurado e então retorna ErrOcupada.
+	// Com espera zero, retorna ErrOcupada imediatamente.
+	Adquirir(ctx context.Context, recurso string) (*Lease, error)
+}
+
+// Config define o dono e os prazos das leases.
+type Config struct {
+	Dono            string        // Identificador desta instância (host, pid e sufixo aleatório)
+	TTL             time.Duration // Validade da lease sem renovação; após esse prazo outra instância pode tomá-la
+	Renovacao       time.Duration // Intervalo entre as renovações (heartbeat)
+	Espera          time.Duration // Quanto tempo aguardar por uma lease ocupada (0 = não aguarda)
+	IntervaloEspera time.Duration // Intervalo entre as tentativas enquanto aguarda
+}
+
+// ConfigPadrao lê a configuração das variáveis de ambiente ETL_LOCK_TTL (padrão 60s),
+// ETL_LOCK_RENOVACAO (padrão um terço do TTL) e ETL_LOCK_ESPERA (padrão 0, não aguarda).
+func ConfigPadrao() Config {
+	ttl := env.GetDuration("ETL_LOCK_TTL", 60*time.Second)
+	return Config{
+		Dono:            DonoPadrao(),
+		TTL:             ttl,
+		Renovacao:       env.GetDuration("ETL_LOCK_RENOVACAO", ttl/3),
+		Espera:          env.GetDuration("ETL_LOCK_ESPERA", 0),
+		IntervaloEspera: 2 * time.Second,
+	}
+}
+
+// DonoPadrao gera o identificador da instância no formato host-pid-aleatorio.
+func DonoPadrao() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "desconhecido"
+	}
+	return fmt.Sprintf("%s-%d-%s", host, os.Getpid(), logger.NovoRunID()[:8])
+}
+
+// normalizar garante prazos válidos: a renovação precisa ocorrer antes da expiração.
+func (c Config) normalizar() Config {
+	if c.TTL <= 0 {
+		c.TTL = 60 * time.Second
+	}
+	if c.Renovacao <= 0 || c.Renovacao >= c.TTL {
+		c.Renovacao = c.TTL / 3
+	}
+	if c.IntervaloEspera <= 0 {
+		c.IntervaloEspera = 2 * time.Second
+	}
+	if c.Dono == "" {
+		c.Dono = DonoPadrao()
+	}
+	return c
+}
@@ -0,0 +1,134 @@
+package trava
+
+import (
+	"context"
+	"etl-service/src/config/logger"
+	travarepository "etl-service/src/exec/repository/trava_repository"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// trava é a implementação concreta da interface Trava.
+type trava struct {
+	repo travarepository.TravaRepository
+	cfg  Config
+}
+
+// NewTrava cria o serviço de travas sobre o repositório informado.
+func NewTrava(repo travarepository.TravaRepository, cfg Config) Trava {
+	return &trava{repo: repo, cfg: cfg.normalizar()}
+}
+
+// Adquirir tenta obter a lease, repetindo a cada IntervaloEspera enquanto durar a espera.
+// Ao obter a lease, inicia o heartbeat que a renova até Liberar ser chamado.
+func (t *trava) Adquirir(ctx context.Context, recurso string) (*Lease, error) {
+	limite := time.Now().Add(t.cfg.Espera)
+	aguardando := false
+
+	for {
+		expiraEm := time.Now().Add(t.cfg.TTL)
+		ok, err := t.repo.Adquirir(ctx, recurso, t.cfg.Dono, expiraEm)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return t.iniciarLease(ctx, recurso, expiraEm), nil
+		}
+
+		if !time.Now().Add(t.cfg.IntervaloEspera).Before(limite) {
+			return nil, fmt.Errorf("%w: %s", ErrOcupada, recurso)
+		}
+		if !aguardando {
+			slog.Info("trava ocupada: aguardando liberação", "recurso", recurso, "espera", t.cfg.Espera)
+			aguardando = true
+		}
+		select {
+		case <-time.After(t.cfg.IntervaloEspera):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// Lease é uma trava obtida por esta instância. Enquanto não for liberada, é renovada
+// periodicamente; se a renovação não for possível antes da expiração, o contexto da lease
+// é cancelado com a causa ErrPerdida, interrompendo o trabalho protegido por ela.
+type Lease struct {
+	repo     travarepository.TravaRepository
+	cfg      Config
+	recurso  string
+	ctx      context.Context
+	cancelar context.CancelCauseFunc
+	parar    chan struct{}
+	feito    chan struct{}
+	liberar  sync.Once
+}
+
+// iniciarLease cria a lease e dispara a goroutine de renovação.
+func (t *trava) iniciarLease(ctx context.Context, recurso string, expiraEm time.Time) *Lease {
+	ctxLease, cancelar := context.WithCancelCause(ctx)
+	l := &Lease{
+		repo:     t.repo,
+		cfg:      t.cfg,
+		recurso:  recurso,
+		ctx:      ctxLease,
+		cancelar: cancelar,
+		parar:    make(chan struct{}),
+		feito:    make(chan struct{}),
+	}
+	go l.renovar(expiraEm)
+	return l
+}
+
+// Contexto retorna o contexto que deve ser usado pelo trabalho protegido pela lease.
+// Ele é cancelado quando a lease é perdida (context.Cause retorna ErrPerdida) ou liberada.
+func (l *Lease) Contexto() context.Context {
+	return l.ctx
+}
+
+// renovar estende a validade da lease a cada intervalo de renovação. Falhas de comunicação
+// são toleradas enquanto a lease ainda é válida; a perda da lease cancela o contexto.
+func (l *Lease) renovar(validaAte time.Time) {
+	defer close(l.feito)
+
+	ticker := time.NewTicker(l.cfg.Renovacao)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.parar:
+			return
+		case <-ticker.C:
+			novaValidade := time.Now().Add(l.cfg.TTL)
+			ok, err := l.repo.Renovar(context.WithoutCancel(l.ctx), l.recurso, l.cfg.Dono, novaValidade)
+			switch {
+			case err != nil && time.Now().Before(validaAte):
+				slog.Warn("falha ao renovar trava: nova tentativa no próximo intervalo", "recurso", l.recurso, logger.Erro(err))
+			case err != nil || !ok:
+				slog.Error("lease perdida", "recurso", l.recurso, "dono", l.cfg.Dono, logger.Erro(ErrPerdida))
+				l.cancelar(ErrPerdida)
+				return
+			default:
+				validaAte = novaValidade
+			}
+		}
+	}
+}
+
+// Liberar interrompe a renovação e remove a lease, permitindo que outra instância a adquira.
+// Pode ser chamada mais de uma vez.
+func (l *Lease) Liberar() {
+	l.liberar.Do(func() {
+		close(l.parar)
+		<-l.feito
+
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(l.ctx), 5*time.Second)
+		defer cancel()
+		if err := l.repo.Liberar(ctx, l.recurso, l.cfg.Dono); err != nil {
+			slog.Warn("erro ao liberar trava: ela expirará ao final do TTL", "recurso", l.recurso, logger.Erro(err))
+		}
+		l.cancelar(context.Canceled)
+	})
+}
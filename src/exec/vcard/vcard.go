@@ -0,0 +1,94 @@
+package vcard
+
+import (
+	"bufio"
+	bancofinal "etl-service/src/config/model/banco_final"
+	"etl-service/src/exec/domain"
+	"etl-service/src/exec/linhaconteudo"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Escrever grava em w um vCard 4.0 (RFC 6350) para cada membro, em UTF-8 e com linhas terminadas em CRLF,
+// formando um único arquivo .vcf. Retorna a quantidade de contatos gravados.
+//
+// Cada contato tem nome (FN e N), telefone, e-mail, endereço, aniversário (BDAY), data de casamento
+// (ANNIVERSARY) e um UID estável derivado do _id do membro; campos vazios são omitidos.
+func Escrever(w io.Writer, membros []bancofinal.Membro) (int, error) {
+	buf := bufio.NewWriter(w)
+	for _, m := range membros {
+		escreverContato(buf, m)
+	}
+	if err := buf.Flush(); err != nil {
+		return 0, fmt.Errorf("erro ao gravar vCards: %w", err)
+	}
+	return len(membros), nil
+}
+
+// escreverContato grava o vCard de um membro.
+func escreverContato(w *bufio.Writer, m bancofinal.Membro) {
+	linha := func(texto string) { linhaconteudo.Escrever(w, texto) }
+
+	linha("BEGIN:VCARD")
+	linha("VERSION:4.0")
+	linha("KIND:individual")
+	linha("FN:" + linhaconteudo.Escapar(m.Name))
+	nome, sobrenome := separarNome(m.Name)
+	linha("N:" + linhaconteudo.Escapar(sobrenome) + ";" + linhaconteudo.Escapar(nome) + ";;;")
+	if !m.ID.IsZero() {
+		linha("UID:urn:etl-service:membro:" + m.ID.Hex())
+	}
+	if m.Telefone != "" {
+		linha("TEL;VALUE=uri;TYPE=cell:tel:" + m.Telefone)
+	}
+	if m.Email != "" {
+		linha("EMAIL;TYPE=home:" + linhaconteudo.Escapar(m.Email))
+	}
+	if adr := endereco(m.Endereco); adr != "" {
+		linha("ADR;TYPE=home:" + adr)
+	}
+	if !m.DataNascimento.IsZero() {
+		linha("BDAY:" + m.DataNascimento.In(domain.FusoHorario).Format("20060102"))
+	}
+	if m.DataCasamento != nil {
+		linha("ANNIVERSARY:" + m.DataCasamento.In(domain.FusoHorario).Format("20060102"))
+	}
+	if !m.DataModificacao.IsZero() {
+		linha("REV:" + m.DataModificacao.UTC().Format("20060102T150405Z"))
+	}
+	linha("END:VCARD")
+}
+
+// separarNome divide o nome completo em prenome (primeira palavra) e sobrenomes (demais palavras).
+func separarNome(nome string) (string, string) {
+	prenome, sobrenomes, _ := strings.Cut(strings.TrimSpace(nome), " ")
+	return prenome, strings.TrimSpace(sobrenomes)
+}
+
+// endereco monta o valor estruturado de ADR: caixa postal; complemento e bairro; rua e número;
+// cidade; UF; CEP; país. Retorna vazio quando o endereço não tem rua nem CEP.
+func endereco(e bancofinal.Endereco) string {
+	if e.Rua == "" && e.Cep == "" {
+		return ""
+	}
+	rua := e.Rua
+	if e.Numero != "" {
+		rua += ", " + e.Numero
+	}
+	var extra []string
+	for _, parte := range []string{e.Complemento, e.Bairro} {
+		if parte != "" {
+			extra = append(extra, parte)
+		}
+	}
+	cep := e.Cep
+	if len(cep) == 8 {
+		cep = cep[:5] + "-" + cep[5:]
+	}
+	partes := []string{"", strings.Join(extra, " - "), rua, e.Cidade, e.Uf, cep, "Brasil"}
+	for i, p := range partes {
+		partes[i] = linhaconteudo.Escapar(p)
+	}
+	return strings.Join(partes, ";")
+}